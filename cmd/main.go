@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/7-Dany/snip/internal/cli/commands"
 	"github.com/7-Dany/snip/internal/cli/components"
@@ -13,33 +14,142 @@ import (
 )
 
 func Run() {
-	config, err := config.LoadConfig()
+	args, configFile, err := globalFlag(os.Args, "config")
 	if err != nil {
-		commands.PrintError("Error loading config!" + err.Error())
+		commands.PrintError(err.Error())
 		os.Exit(1)
 	}
-
-	repos := storage.New(config.StoragePath)
-	err = repos.Load()
+	args, library, err := globalFlag(args, "library")
 	if err != nil {
-		commands.PrintError("Error loading repos!" + err.Error())
+		commands.PrintError(err.Error())
 		os.Exit(1)
 	}
-	defer repos.Save()
+	if commands.ReservesStdout(args) {
+		commands.UseStderr()
+	}
 
-	app := commands.NewCLI(repos)
+	// A .snip directory in the project is stacked over the global library
+	project := ""
+	if wd, err := os.Getwd(); err == nil {
+		project = config.FindProjectLibrary(wd)
+	}
 
-	// If arguments provided, use old CLI
-	if len(os.Args) > 1 {
-		app.Run(os.Args)
+	config, err := loadConfig(configFile)
+	if err != nil {
+		if config == nil || !commands.RepairsConfig(args) {
+			commands.PrintError("Error loading config! " + err.Error())
+			if config != nil {
+				commands.PrintInfo("Fix it with 'snip config edit'")
+			}
+			os.Exit(1)
+		}
+		// Report on stderr, so that 'snip config path' still prints only the path
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if commands.RunWithoutLibrary(args, config) {
 		return
 	}
+	if library == "" {
+		library = config.CurrentLibraryName()
+	}
+
+	for {
+		repos := openLibrary(config, library, project)
+		app := commands.NewCLI(repos, config)
+
+		// If arguments provided, use old CLI
+		if len(args) > 1 {
+			app.Run(args)
+			repos.Save()
+			return
+		}
+
+		// Otherwise, launch TUI; switching library reopens it
+		library = runTUI(repos, config, library)
+		repos.Save()
+		if library == "" {
+			return
+		}
+	}
+}
+
+// globalFlag removes a global flag such as --library from args and returns
+// the remaining args with the flag value. Arguments after "--" are kept.
+func globalFlag(args []string, name string) ([]string, string, error) {
+	flag := "--" + name
+	remaining := make([]string, 0, len(args))
+	value := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return append(remaining, args[i:]...), value, nil
+		case arg == flag:
+			if i+1 >= len(args) {
+				return nil, "", fmt.Errorf("missing value for '%s'", flag)
+			}
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(arg, flag+"="):
+			value = strings.TrimPrefix(arg, flag+"=")
+		default:
+			remaining = append(remaining, arg)
+		}
+	}
+	return remaining, value, nil
+}
+
+// loadConfig loads the config file named with --config, or the default one.
+func loadConfig(path string) (*config.Config, error) {
+	if path != "" {
+		return config.LoadConfigFile(path)
+	}
+	return config.LoadConfig()
+}
 
-	// Otherwise, launch TUI
-	homeTab := tui.NewHomeTab()
+// openLibrary loads the named library, with the team library and the
+// project library stacked over it. It exits on errors.
+func openLibrary(cfg *config.Config, name, project string) *storage.Repositories {
+	library, err := cfg.Library(name)
+	if err != nil {
+		commands.PrintError(err.Error() + ". Use 'snip library list' to see the libraries")
+		os.Exit(1)
+	}
+
+	repos := storage.New(library.StoragePath)
+	if cfg.TeamLibrary != "" && cfg.TeamLibrary != library.StoragePath {
+		repos.AddLayer(storage.OriginTeam, storage.NewReadOnlyLayer(cfg.TeamLibrary, storage.TeamIDBase))
+	}
+	if project != "" && project != library.StoragePath {
+		repos.AddLayer(storage.OriginProject, storage.NewLayer(project, storage.ProjectIDBase))
+	}
+	encrypted, err := storage.IsEncrypted(library.StoragePath)
+	if err != nil {
+		commands.PrintError("Error reading storage!" + err.Error())
+		os.Exit(1)
+	}
+	if encrypted {
+		passphrase := commands.ReadPassphrase()
+		if passphrase == "" {
+			os.Exit(1)
+		}
+		repos.SetPassphrase(passphrase)
+	}
+	err = repos.Load()
+	if err != nil {
+		commands.PrintError("Error loading repos!" + err.Error())
+		os.Exit(1)
+	}
+	return repos
+}
+
+// runTUI runs the tabbed interface on repos and returns the library picked
+// with the library switcher, or "" when the user quit.
+func runTUI(repos *storage.Repositories, config *config.Config, library string) string {
+	homeTab := tui.NewHomeTab(repos)
 	categoriesTab := tui.NewCategoriesTab(repos)
 	tagsTab := tui.NewTagsTab(repos)
-	snippetsTab := tui.NewSnippetsTab(repos)
+	snippetsTab := tui.NewSnippetsTab(repos, config)
 
 	tabs, err := components.NewTabs(
 		[]string{"Home", "Categories", "Tags", "Snippets"},
@@ -57,11 +167,14 @@ func Run() {
 
 	// Configure tabs
 	tabs.SetLabelWidth(20)
+	tabs.SetSwitcher("library", config.LibraryNames(), library)
 
 	// Run with alt screen for clean display
 	p := tea.NewProgram(tabs, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	model, err := p.Run()
+	if err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
+	return model.(components.Tabs).Switched()
 }
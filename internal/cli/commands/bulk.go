@@ -0,0 +1,338 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/7-Dany/snip/internal/storage"
+)
+
+// bulkUsage is shown when a bulk subcommand is missing or malformed.
+const bulkUsage = "snip snippet bulk <tag add|tag remove|move|set-language|delete> ... <ids|--query <text>>"
+
+// bulk routes bulk subcommands. Every bulk operation previews the affected
+// snippets, asks for confirmation once (unless --yes is given), and applies
+// the change atomically.
+func (sc *SnippetCommand) bulk(args []string) {
+	if len(args) == 0 {
+		PrintError(fmt.Sprintf("No bulk action provided. Use '%s'", bulkUsage))
+		return
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "tag":
+		sc.bulkTag(args[1:])
+	case "move":
+		sc.bulkMove(args[1:])
+	case "set-language":
+		sc.bulkSetLanguage(args[1:])
+	case "delete":
+		sc.bulkDelete(args[1:])
+	default:
+		PrintError(fmt.Sprintf("Unknown bulk action '%s'. Use 'snip help snippet' for available actions", args[0]))
+	}
+}
+
+// bulkTag adds or removes a tag on many snippets.
+func (sc *SnippetCommand) bulkTag(args []string) {
+	if len(args) < 2 || (args[0] != "add" && args[0] != "remove") {
+		PrintError("Usage: snip snippet bulk tag <add|remove> <tag> <ids|--query <text>>")
+		return
+	}
+
+	tag, err := resolveTag(sc.repos, args[1])
+	if err != nil {
+		PrintError(err.Error())
+		return
+	}
+
+	if args[0] == "add" {
+		sc.applyBulk(args[2:], fmt.Sprintf("Add tag '%s' to %%d snippet(s)?", tag.Name()), domain.AddTagEdit(tag.ID()))
+		return
+	}
+	sc.applyBulk(args[2:], fmt.Sprintf("Remove tag '%s' from %%d snippet(s)?", tag.Name()), domain.RemoveTagEdit(tag.ID()))
+}
+
+// bulkMove moves many snippets into a category.
+func (sc *SnippetCommand) bulkMove(args []string) {
+	if len(args) < 2 || args[0] != "--category" {
+		PrintError("Usage: snip snippet bulk move --category <id|path|none> <ids|--query <text>>")
+		return
+	}
+
+	categoryID := 0
+	target := "no category"
+	if strings.ToLower(args[1]) != "none" {
+		category, err := resolveCategory(sc.repos, args[1])
+		if err != nil {
+			PrintError(err.Error())
+			return
+		}
+		categoryID = category.ID()
+		target = fmt.Sprintf("'%s'", args[1])
+	}
+
+	sc.applyBulk(args[2:], fmt.Sprintf("Move %%d snippet(s) to %s?", target), domain.SetCategoryEdit(categoryID))
+}
+
+// bulkSetLanguage changes the language of many snippets.
+func (sc *SnippetCommand) bulkSetLanguage(args []string) {
+	if len(args) < 1 || strings.TrimSpace(args[0]) == "" {
+		PrintError("Usage: snip snippet bulk set-language <language> <ids|--query <text>>")
+		return
+	}
+
+	language := strings.TrimSpace(args[0])
+	sc.applyBulk(args[1:], fmt.Sprintf("Set language '%s' on %%d snippet(s)?", language), domain.SetLanguageEdit(language))
+}
+
+// bulkDelete deletes many snippets.
+func (sc *SnippetCommand) bulkDelete(args []string) {
+	snippets, confirmed := sc.previewBulk(args, "Delete %d snippet(s)?")
+	if !confirmed {
+		return
+	}
+
+	if err := sc.repos.Snippets.BulkDelete(snippetIDs(snippets)); err != nil {
+		PrintError(fmt.Sprintf("Failed to delete snippets, nothing was changed: %v", err))
+		return
+	}
+
+	PrintSuccess(fmt.Sprintf("Deleted %d snippet(s)", len(snippets)))
+}
+
+// applyBulk previews the selection, confirms, and applies edit to every selected snippet.
+// prompt is the confirmation question; its %d verb is replaced by the number of snippets.
+func (sc *SnippetCommand) applyBulk(args []string, prompt string, edit domain.SnippetEdit) {
+	snippets, confirmed := sc.previewBulk(args, prompt)
+	if !confirmed {
+		return
+	}
+
+	if err := sc.repos.Snippets.BulkUpdate(snippetIDs(snippets), edit); err != nil {
+		PrintError(fmt.Sprintf("Failed to update snippets, nothing was changed: %v", err))
+		return
+	}
+
+	PrintSuccess(fmt.Sprintf("Updated %d snippet(s)", len(snippets)))
+}
+
+// previewBulk resolves the selection in args, displays the affected snippets
+// and asks for confirmation. It returns the selected snippets and whether the
+// operation should proceed.
+func (sc *SnippetCommand) previewBulk(args []string, prompt string) ([]*domain.Snippet, bool) {
+	selection, assumeYes, err := parseBulkSelection(args)
+	if err != nil {
+		PrintError(err.Error())
+		return nil, false
+	}
+
+	snippets, err := sc.resolveBulkSelection(selection)
+	if err != nil {
+		PrintError(err.Error())
+		return nil, false
+	}
+	if len(snippets) == 0 {
+		PrintInfo("No snippets match the selection")
+		return nil, false
+	}
+
+	sc.displaySnippets(snippets)
+
+	if assumeYes {
+		return snippets, true
+	}
+
+	fmt.Printf(prompt+" (y/n): ", len(snippets))
+	var response string
+	fmt.Scanln(&response)
+
+	if strings.ToLower(strings.TrimSpace(response)) != "y" {
+		PrintInfo("Bulk operation cancelled")
+		return nil, false
+	}
+	return snippets, true
+}
+
+// bulkSelection is a parsed set of snippet IDs and ID ranges, or a search
+// query.
+type bulkSelection struct {
+	ids    []int
+	ranges []idRange
+	query  string
+}
+
+// idRange is an inclusive range of snippet IDs, such as "5-9".
+type idRange struct {
+	start, end int
+}
+
+// contains reports whether id is in the range.
+func (r idRange) contains(id int) bool {
+	return r.start <= id && id <= r.end
+}
+
+// parseBulkSelection parses selection arguments: IDs and ranges such as
+// "1 2 5-9" or "1,2,5-9", or "--query <text>". It also reports whether
+// --yes (or -y) was given.
+func parseBulkSelection(args []string) (bulkSelection, bool, error) {
+	var selection bulkSelection
+	assumeYes := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--yes", "-y":
+			assumeYes = true
+			continue
+		case "--query":
+			if i+1 >= len(args) || strings.TrimSpace(args[i+1]) == "" {
+				return selection, false, errors.New("flag '--query' requires a value")
+			}
+			selection.query = args[i+1]
+			i++
+			continue
+		}
+
+		ids, ranges, err := parseIDList(args[i])
+		if err != nil {
+			return selection, false, err
+		}
+		selection.ids = append(selection.ids, ids...)
+		selection.ranges = append(selection.ranges, ranges...)
+	}
+
+	hasIDs := len(selection.ids) > 0 || len(selection.ranges) > 0
+	if !hasIDs && selection.query == "" {
+		return selection, false, fmt.Errorf("no snippets selected. Use '%s'", bulkUsage)
+	}
+	if hasIDs && selection.query != "" {
+		return selection, false, errors.New("use either IDs or --query, not both")
+	}
+	return selection, assumeYes, nil
+}
+
+// parseIDList parses a comma-separated list of IDs and inclusive ranges,
+// e.g. "1,3,5-9". Ranges are returned as bounds rather than expanded.
+func parseIDList(spec string) ([]int, []idRange, error) {
+	var ids []int
+	var ranges []idRange
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		from, to, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(from)
+		if err != nil || start <= 0 {
+			return nil, nil, fmt.Errorf("invalid ID '%s'. IDs must be positive numbers", part)
+		}
+		if !isRange {
+			ids = append(ids, start)
+			continue
+		}
+		end, err := strconv.Atoi(to)
+		if err != nil || end < start {
+			return nil, nil, fmt.Errorf("invalid range '%s'. Use '<from>-<to>' with from <= to", part)
+		}
+		ranges = append(ranges, idRange{start: start, end: end})
+	}
+	return ids, ranges, nil
+}
+
+// resolveBulkSelection loads the selected snippets, ordered by ID.
+// It returns an error naming any single ID that does not exist; ranges
+// select the existing snippets within them.
+func (sc *SnippetCommand) resolveBulkSelection(selection bulkSelection) ([]*domain.Snippet, error) {
+	if selection.query != "" {
+		snippets, err := sc.repos.Snippets.Search(selection.query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search snippets: %w", err)
+		}
+		sort.Slice(snippets, func(i, j int) bool { return snippets[i].ID() < snippets[j].ID() })
+		return snippets, nil
+	}
+
+	seen := make(map[int]bool, len(selection.ids))
+	var snippets []*domain.Snippet
+	var missing []string
+	for _, id := range selection.ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		snippet, err := sc.repos.Snippets.FindByID(id)
+		if errors.Is(err, storage.ErrNotFound) {
+			missing = append(missing, strconv.Itoa(id))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find snippet: %w", err)
+		}
+		snippets = append(snippets, snippet)
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("snippet(s) not found: %s", strings.Join(missing, ", "))
+	}
+
+	if len(selection.ranges) > 0 {
+		all, err := sc.repos.Snippets.List()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snippets: %w", err)
+		}
+		for _, snippet := range all {
+			if seen[snippet.ID()] {
+				continue
+			}
+			for _, r := range selection.ranges {
+				if r.contains(snippet.ID()) {
+					seen[snippet.ID()] = true
+					snippets = append(snippets, snippet)
+					break
+				}
+			}
+		}
+	}
+
+	sort.Slice(snippets, func(i, j int) bool { return snippets[i].ID() < snippets[j].ID() })
+	return snippets, nil
+}
+
+// resolveTag finds a tag by numeric ID or by name.
+func resolveTag(repos *storage.Repositories, ref string) (*domain.Tag, error) {
+	if id, err := strconv.Atoi(ref); err == nil {
+		tag, err := repos.Tags.FindByID(id)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("tag with ID %d not found", id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find tag: %w", err)
+		}
+		return tag, nil
+	}
+
+	tag, err := repos.Tags.FindByName(ref)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("tag '%s' not found", ref)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tag: %w", err)
+	}
+	return tag, nil
+}
+
+// snippetIDs returns the IDs of the given snippets.
+func snippetIDs(snippets []*domain.Snippet) []int {
+	ids := make([]int, 0, len(snippets))
+	for _, s := range snippets {
+		ids = append(ids, s.ID())
+	}
+	return ids
+}
@@ -0,0 +1,191 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+func TestParseIDList(t *testing.T) {
+	t.Run("parses single IDs and comma lists", func(t *testing.T) {
+		ids, ranges, err := parseIDList("1,4,2")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(ids, []int{1, 4, 2}) || len(ranges) != 0 {
+			t.Errorf("expected [1 4 2] and no ranges, got %v and %v", ids, ranges)
+		}
+	})
+
+	t.Run("keeps inclusive ranges as bounds", func(t *testing.T) {
+		ids, ranges, err := parseIDList("1,5-8,1-999999999")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(ids, []int{1}) {
+			t.Errorf("expected [1], got %v", ids)
+		}
+		if !reflect.DeepEqual(ranges, []idRange{{5, 8}, {1, 999999999}}) {
+			t.Errorf("expected ranges 5-8 and 1-999999999, got %v", ranges)
+		}
+	})
+
+	t.Run("rejects invalid IDs and ranges", func(t *testing.T) {
+		for _, spec := range []string{"9-5", "abc", "0", "3-"} {
+			if _, _, err := parseIDList(spec); err == nil {
+				t.Errorf("expected error for %q", spec)
+			}
+		}
+	})
+}
+
+func TestParseBulkSelection(t *testing.T) {
+	t.Run("collects IDs and --yes", func(t *testing.T) {
+		selection, yes, err := parseBulkSelection([]string{"1", "3-4", "--yes"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !yes {
+			t.Error("expected --yes to be detected")
+		}
+		if !reflect.DeepEqual(selection.ids, []int{1}) || !reflect.DeepEqual(selection.ranges, []idRange{{3, 4}}) {
+			t.Errorf("expected ID 1 and range 3-4, got %v and %v", selection.ids, selection.ranges)
+		}
+	})
+
+	t.Run("accepts a query", func(t *testing.T) {
+		selection, _, err := parseBulkSelection([]string{"--query", "http"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if selection.query != "http" {
+			t.Errorf("expected query 'http', got %q", selection.query)
+		}
+	})
+
+	t.Run("rejects invalid selections", func(t *testing.T) {
+		for _, args := range [][]string{
+			{},
+			{"--yes"},
+			{"--query"},
+			{"1", "--query", "http"},
+			{"x"},
+		} {
+			if _, _, err := parseBulkSelection(args); err == nil {
+				t.Errorf("expected error for %v", args)
+			}
+		}
+	})
+}
+
+func TestSnippetCommand_bulk(t *testing.T) {
+	setup := func(t *testing.T) (*SnippetCommand, []*domain.Snippet) {
+		repos := setupTestRepos(t)
+		var snippets []*domain.Snippet
+		for _, title := range []string{"HTTP client", "HTTP server", "SQL join"} {
+			snip, _ := domain.NewSnippet(title, "go", "code "+title)
+			repos.Snippets.Create(snip)
+			snippets = append(snippets, snip)
+		}
+		return NewSnippetCommand(repos), snippets
+	}
+
+	t.Run("adds and removes a tag", func(t *testing.T) {
+		sc, snippets := setup(t)
+		tag, _ := domain.NewTag("web")
+		sc.repos.Tags.Create(tag)
+
+		sc.bulk([]string{"tag", "add", "web", "1-2", "--yes"})
+
+		for i, want := range []bool{true, true, false} {
+			got, _ := sc.repos.Snippets.FindByID(snippets[i].ID())
+			if got.HasTag(tag.ID()) != want {
+				t.Errorf("snippet %d: expected tag %v", got.ID(), want)
+			}
+		}
+
+		sc.bulk([]string{"tag", "remove", "web", "1", "--yes"})
+
+		got, _ := sc.repos.Snippets.FindByID(1)
+		if got.HasTag(tag.ID()) {
+			t.Error("expected tag to be removed from snippet 1")
+		}
+	})
+
+	t.Run("moves snippets selected by query", func(t *testing.T) {
+		sc, _ := setup(t)
+		category, _ := domain.NewCategory("network")
+		sc.repos.Categories.Create(category)
+
+		sc.bulk([]string{"move", "--category", "network", "--query", "HTTP", "--yes"})
+
+		moved, _ := sc.repos.Snippets.FindByCategory(category.ID())
+		if len(moved) != 2 {
+			t.Errorf("expected 2 snippets in category, got %d", len(moved))
+		}
+	})
+
+	t.Run("sets language", func(t *testing.T) {
+		sc, _ := setup(t)
+
+		sc.bulk([]string{"set-language", "python", "1,3", "--yes"})
+
+		for id, want := range map[int]string{1: "python", 2: "go", 3: "python"} {
+			got, _ := sc.repos.Snippets.FindByID(id)
+			if got.Language() != want {
+				t.Errorf("snippet %d: expected language %q, got %q", id, want, got.Language())
+			}
+		}
+	})
+
+	t.Run("deletes snippets", func(t *testing.T) {
+		sc, _ := setup(t)
+
+		sc.bulk([]string{"delete", "1", "3", "--yes"})
+
+		remaining, _ := sc.repos.Snippets.List()
+		if len(remaining) != 1 || remaining[0].ID() != 2 {
+			t.Errorf("expected only snippet 2 to remain, got %d snippet(s)", len(remaining))
+		}
+	})
+
+	t.Run("changes nothing when an ID is unknown", func(t *testing.T) {
+		sc, _ := setup(t)
+
+		sc.bulk([]string{"delete", "1", "99", "--yes"})
+
+		remaining, _ := sc.repos.Snippets.List()
+		if len(remaining) != 3 {
+			t.Errorf("expected 3 snippets, got %d", len(remaining))
+		}
+	})
+
+	t.Run("selects the existing snippets in a range", func(t *testing.T) {
+		sc, _ := setup(t)
+		sc.repos.Snippets.Delete(2)
+
+		sc.bulk([]string{"set-language", "python", "1-999999999", "--yes"})
+
+		for _, id := range []int{1, 3} {
+			got, _ := sc.repos.Snippets.FindByID(id)
+			if got.Language() != "python" {
+				t.Errorf("snippet %d: expected language 'python', got %q", id, got.Language())
+			}
+		}
+	})
+
+	t.Run("validates arguments", func(t *testing.T) {
+		sc, _ := setup(t)
+
+		sc.bulk([]string{})
+		sc.bulk([]string{"unknown"})
+		sc.bulk([]string{"tag", "add"})
+		sc.bulk([]string{"tag", "add", "missing", "1", "--yes"})
+		sc.bulk([]string{"move", "1"})
+		sc.bulk([]string{"move", "--category", "missing", "1", "--yes"})
+		sc.bulk([]string{"set-language"})
+		sc.bulk([]string{"delete"})
+	})
+}
@@ -0,0 +1,281 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/7-Dany/snip/internal/storage"
+)
+
+// captureUsage is shown when capture arguments are malformed.
+const captureUsage = "snip capture --history [n] [--file <history file>]"
+
+// defaultHistoryCount is the number of recent commands offered by capture --history.
+const defaultHistoryCount = 50
+
+// captureTitleLimit is the maximum length of a title taken from a command.
+const captureTitleLimit = 60
+
+// CaptureCommand handles turning existing code, such as shell history, into snippets.
+type CaptureCommand struct {
+	repos *storage.Repositories
+}
+
+// NewCaptureCommand creates a new CaptureCommand instance.
+func NewCaptureCommand(repos *storage.Repositories) *CaptureCommand {
+	return &CaptureCommand{repos: repos}
+}
+
+// manage parses capture flags and captures from the chosen source.
+func (cc *CaptureCommand) manage(args []string) {
+	history, count, file := false, defaultHistoryCount, ""
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--history":
+			history = true
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n < 1 {
+					PrintError(fmt.Sprintf("Invalid count '%s'. Count must be a positive number", args[i+1]))
+					return
+				}
+				count = n
+				i++
+			}
+		case arg == "--file":
+			if i+1 >= len(args) {
+				PrintError("Flag '--file' requires a path")
+				return
+			}
+			file = args[i+1]
+			i++
+		default:
+			PrintError(fmt.Sprintf("Unknown argument '%s'. Use '%s'", arg, captureUsage))
+			return
+		}
+	}
+	if !history {
+		PrintError(fmt.Sprintf("Missing source. Use '%s'", captureUsage))
+		return
+	}
+
+	cc.captureHistory(file, count)
+}
+
+// captureHistory offers the count most recent distinct commands of a shell
+// history file and saves the ones the user picks as shell snippets.
+func (cc *CaptureCommand) captureHistory(file string, count int) {
+	if file == "" {
+		var err error
+		if file, err = historyFile(); err != nil {
+			PrintError(fmt.Sprintf("Failed to find shell history: %v", err))
+			return
+		}
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to read history: %v", err))
+		return
+	}
+
+	language := "bash"
+	if strings.Contains(filepath.Base(file), "zsh") {
+		language = "zsh"
+	}
+
+	var snippets []*domain.Snippet
+	for _, command := range recentCommands(parseHistory(data), count) {
+		snippet, err := domain.NewSnippet(commandTitle(command), language, command)
+		if err != nil {
+			continue
+		}
+		snippet.SetSource(file)
+		snippets = append(snippets, snippet)
+	}
+	if len(snippets) == 0 {
+		PrintInfo("No commands found in " + file)
+		return
+	}
+
+	picked, ok := runSnippetPicker(newSnippetPickerModel(
+		"📜 Capture Shell History",
+		"Space toggles a command, a selects all. Most recent first.",
+		snippets,
+		false,
+	), snippets)
+	if !ok {
+		PrintInfo("Capture cancelled")
+		return
+	}
+	if len(picked) == 0 {
+		PrintInfo("No commands selected")
+		return
+	}
+
+	captured, skipped := 0, 0
+	for _, snippet := range picked {
+		if duplicates, _ := cc.repos.Snippets.FindDuplicates(snippet.Code(), snippet.Language()); len(duplicates) > 0 {
+			skipped++
+			continue
+		}
+		code, ok := checkSecrets(snippet.Code())
+		if !ok {
+			PrintInfo(fmt.Sprintf("Skipped '%s'", snippet.Title()))
+			skipped++
+			continue
+		}
+		if err := snippet.SetCode(code); err != nil {
+			PrintError(fmt.Sprintf("Failed to capture '%s': %v", snippet.Title(), err))
+			return
+		}
+		if err := cc.repos.Snippets.Create(snippet); err != nil {
+			PrintError(fmt.Sprintf("Failed to save snippet '%s': %v", snippet.Title(), err))
+			return
+		}
+		captured++
+	}
+	PrintSuccess(fmt.Sprintf("Captured %d command(s), skipped %d", captured, skipped))
+}
+
+// historyFile returns the history file of the user's shell: $HISTFILE if
+// set, else ~/.zsh_history for zsh and ~/.bash_history otherwise.
+func historyFile() (string, error) {
+	if file := os.Getenv("HISTFILE"); file != "" {
+		return file, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	if filepath.Base(os.Getenv("SHELL")) == "zsh" {
+		return filepath.Join(home, ".zsh_history"), nil
+	}
+	return filepath.Join(home, ".bash_history"), nil
+}
+
+// zshHistoryEntry matches the ": <start>:<duration>;" prefix of zsh's
+// extended history format.
+var zshHistoryEntry = regexp.MustCompile(`^: *[0-9]+:[0-9]+;`)
+
+// bashHistoryTime matches the timestamp comments bash writes with HISTTIMEFORMAT.
+var bashHistoryTime = regexp.MustCompile(`^#[0-9]+$`)
+
+// parseHistory returns the commands of a bash or zsh history file, oldest
+// first. zsh entries continued with a trailing backslash are joined with
+// newlines, and zsh's metafied bytes are restored.
+func parseHistory(data []byte) []string {
+	lines := strings.Split(strings.ReplaceAll(unmetafyZsh(data), "\r\n", "\n"), "\n")
+
+	var commands []string
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if bashHistoryTime.MatchString(line) {
+			continue
+		}
+		extended := zshHistoryEntry.MatchString(line)
+		if extended {
+			line = line[len(zshHistoryEntry.FindString(line)):]
+		}
+		for extended && strings.HasSuffix(line, `\`) && i+1 < len(lines) {
+			i++
+			line = strings.TrimSuffix(line, `\`) + "\n" + lines[i]
+		}
+		if strings.TrimSpace(line) != "" {
+			commands = append(commands, line)
+		}
+	}
+	return commands
+}
+
+// unmetafyZsh restores bytes zsh stores escaped in its history file: a 0x83
+// byte marks that the next byte was XORed with 0x20.
+func unmetafyZsh(data []byte) string {
+	const meta = 0x83
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] == meta && i+1 < len(data) {
+			i++
+			out = append(out, data[i]^0x20)
+			continue
+		}
+		out = append(out, data[i])
+	}
+	return string(out)
+}
+
+// recentCommands returns up to n distinct commands, most recent first.
+func recentCommands(commands []string, n int) []string {
+	seen := make(map[string]bool)
+	var recent []string
+	for i := len(commands) - 1; i >= 0 && len(recent) < n; i-- {
+		command := strings.TrimSpace(commands[i])
+		if seen[command] {
+			continue
+		}
+		seen[command] = true
+		recent = append(recent, command)
+	}
+	return recent
+}
+
+// commandTitle returns the first line of a command, shortened to
+// captureTitleLimit characters.
+func commandTitle(command string) string {
+	title, _, _ := strings.Cut(command, "\n")
+	if runes := []rune(title); len(runes) > captureTitleLimit {
+		title = string(runes[:captureTitleLimit-1]) + "…"
+	}
+	return title
+}
+
+// lineRangeSpec matches the ":start" or ":start-end" suffix of a --from path.
+var lineRangeSpec = regexp.MustCompile(`:([0-9]+)(?:-([0-9]+))?$`)
+
+// readLineRange reads the code for snippet create --from. spec is a path
+// optionally followed by a 1-based inclusive line range such as
+// "main.go:40-72" or "main.go:40". Common indentation is removed. It
+// returns the path, the code and the source to record: the absolute path
+// with the range.
+func readLineRange(spec string) (string, string, string, error) {
+	path, start, end := spec, 0, 0
+	if m := lineRangeSpec.FindStringSubmatch(spec); m != nil {
+		path = strings.TrimSuffix(spec, m[0])
+		start, _ = strconv.Atoi(m[1])
+		end = start
+		if m[2] != "" {
+			end, _ = strconv.Atoi(m[2])
+		}
+		if start < 1 || end < start {
+			return "", "", "", fmt.Errorf("invalid line range %s", strings.TrimPrefix(m[0], ":"))
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", "", err
+	}
+	source, err := filepath.Abs(path)
+	if err != nil {
+		source = path
+	}
+
+	code := string(data)
+	if start > 0 {
+		lines := strings.Split(strings.TrimSuffix(strings.ReplaceAll(code, "\r\n", "\n"), "\n"), "\n")
+		if end > len(lines) {
+			return "", "", "", fmt.Errorf("line range %d-%d is beyond the end of %s (%d lines)", start, end, path, len(lines))
+		}
+		code = domain.Dedent(strings.Join(lines[start-1:end], "\n"))
+		source += fmt.Sprintf(":%d-%d", start, end)
+	}
+	if strings.TrimSpace(code) == "" {
+		return "", "", "", fmt.Errorf("no code in %s", spec)
+	}
+	return path, code, source, nil
+}
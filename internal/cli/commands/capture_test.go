@@ -0,0 +1,105 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseHistory(t *testing.T) {
+	t.Run("reads bash history with timestamps", func(t *testing.T) {
+		data := "ls -la\n#1700000000\ngit status\n\nmake test\n"
+		want := []string{"ls -la", "git status", "make test"}
+		if got := parseHistory([]byte(data)); !slices.Equal(got, want) {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("reads zsh extended history", func(t *testing.T) {
+		data := ": 1700000000:0;cd /tmp\n: 1700000005:2;for f in *; do\\\n  echo $f\\\ndone\n"
+		want := []string{"cd /tmp", "for f in *; do\n  echo $f\ndone"}
+		if got := parseHistory([]byte(data)); !slices.Equal(got, want) {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("restores metafied bytes", func(t *testing.T) {
+		// zsh writes é (0xc3 0xa9) as 0xc3 0x83 0x89.
+		data := []byte(": 1700000000:0;echo caf\xc3\x83\x89\n")
+		if got := parseHistory(data); len(got) != 1 || got[0] != "echo café" {
+			t.Errorf("unexpected commands %q", got)
+		}
+	})
+}
+
+func TestRecentCommands(t *testing.T) {
+	commands := []string{"ls", "git status", "ls", "make", "git status"}
+	if got, want := recentCommands(commands, 10), []string{"git status", "make", "ls"}; !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got := recentCommands(commands, 2); len(got) != 2 {
+		t.Errorf("expected 2 commands, got %q", got)
+	}
+}
+
+func TestCommandTitle(t *testing.T) {
+	if got := commandTitle("for f in *; do\n  echo $f\ndone"); got != "for f in *; do" {
+		t.Errorf("unexpected title %q", got)
+	}
+	if got := commandTitle(strings.Repeat("x", 100)); len([]rune(got)) != captureTitleLimit || !strings.HasSuffix(got, "…") {
+		t.Errorf("expected a shortened title, got %q", got)
+	}
+}
+
+func TestReadLineRange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	os.WriteFile(path, []byte("package main\n\nfunc main() {\n\tif true {\n\t\tprintln(1)\n\t}\n}\n"), 0644)
+
+	t.Run("extracts and dedents a range", func(t *testing.T) {
+		gotPath, code, source, err := readLineRange(path + ":4-6")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if gotPath != path || code != "if true {\n\tprintln(1)\n}" {
+			t.Errorf("unexpected result %q %q", gotPath, code)
+		}
+		if source != path+":4-6" {
+			t.Errorf("expected source %q, got %q", path+":4-6", source)
+		}
+	})
+
+	t.Run("reads a single line or the whole file", func(t *testing.T) {
+		if _, code, source, _ := readLineRange(path + ":1"); code != "package main" || source != path+":1-1" {
+			t.Errorf("unexpected result %q %q", code, source)
+		}
+		if _, code, source, _ := readLineRange(path); !strings.HasPrefix(code, "package main\n") || source != path {
+			t.Errorf("unexpected result %q %q", code, source)
+		}
+	})
+
+	t.Run("rejects bad ranges", func(t *testing.T) {
+		for _, spec := range []string{path + ":5-2", path + ":0", path + ":6-40", path + ":2"} {
+			if _, _, _, err := readLineRange(spec); err == nil {
+				t.Errorf("%s: expected an error", spec)
+			}
+		}
+	})
+}
+
+func TestCaptureCommand_manage(t *testing.T) {
+	cc := NewCaptureCommand(setupTestRepos(t))
+	empty := filepath.Join(t.TempDir(), ".bash_history")
+	os.WriteFile(empty, []byte("\n"), 0644)
+
+	// Should not panic
+	cc.manage([]string{})
+	cc.manage([]string{"--bad"})
+	cc.manage([]string{"--history", "0"})
+	cc.manage([]string{"--history", "--file"})
+	cc.manage([]string{"--history", "--file", "/nonexistent/history"})
+	cc.manage([]string{"--history", "5", "--file", empty})
+}
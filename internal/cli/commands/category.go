@@ -40,12 +40,14 @@ func (cc *CategoryCommand) manage(args []string) {
 		cc.create(subcommandArgs)
 	case "delete":
 		cc.delete(subcommandArgs)
+	case "move":
+		cc.move(subcommandArgs)
 	default:
 		PrintError(fmt.Sprintf("Unknown command '%s'. Use 'snip help category' for available commands", args[0]))
 	}
 }
 
-// list displays all categories in a formatted table.
+// list displays all categories as a tree in a formatted table.
 func (cc *CategoryCommand) list() {
 	categories, err := cc.repos.Categories.List()
 	if err != nil {
@@ -62,29 +64,71 @@ func (cc *CategoryCommand) list() {
 	t.SetOutputMirror(os.Stdout)
 	t.AppendHeader(table.Row{"ID", "Name", "Created", "Updated"})
 
-	for _, category := range categories {
+	tree := domain.NewCategoryTree(categories)
+	walkCategoryTree(tree, func(category *domain.Category, prefix string) {
 		t.AppendRow(table.Row{
 			category.ID(),
-			category.Name(),
+			prefix + category.Name(),
 			category.CreatedAt().Format("2006-01-02 15:04"),
 			category.UpdatedAt().Format("2006-01-02 15:04"),
 		})
-	}
+	})
 
 	t.SetStyle(table.StyleColoredBright)
 	t.Render()
 }
 
+// walkCategoryTree visits every category depth-first, passing the
+// box-drawing prefix that renders its position in the tree.
+func walkCategoryTree(tree *domain.CategoryTree, visit func(category *domain.Category, prefix string)) {
+	var walk func(categories []*domain.Category, indent string, nested bool)
+	walk = func(categories []*domain.Category, indent string, nested bool) {
+		for i, category := range categories {
+			last := i == len(categories)-1
+			prefix, childIndent := indent, indent
+			if nested {
+				if last {
+					prefix += "└── "
+					childIndent += "    "
+				} else {
+					prefix += "├── "
+					childIndent += "│   "
+				}
+			}
+			visit(category, prefix)
+			walk(tree.Children(category.ID()), childIndent, true)
+		}
+	}
+	walk(tree.Roots(), "", false)
+}
+
 // create creates a new category with the given name or prompts for input.
+// A name containing "/" is treated as a path and any missing parent
+// categories are created along the way. The --parent flag places the new
+// category under an existing category given by ID or path.
 func (cc *CategoryCommand) create(args []string) {
-	var name string
+	var name, parentRef string
 
-	if len(args) == 0 {
+	positional := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--parent" {
+			if i+1 >= len(args) {
+				PrintError("Missing value for --parent flag")
+				return
+			}
+			parentRef = args[i+1]
+			i++
+			continue
+		}
+		positional = append(positional, args[i])
+	}
+
+	if len(positional) == 0 {
 		name = promptForInput(
 			"Create a new category",
 			"✨",
 			"Category name",
-			"e.g., algorithms, web-dev, utils...",
+			"e.g., algorithms, web-dev, backend/db...",
 			50,
 			40,
 		)
@@ -93,38 +137,153 @@ func (cc *CategoryCommand) create(args []string) {
 			return
 		}
 	} else {
-		name = strings.TrimSpace(args[0])
+		name = strings.TrimSpace(positional[0])
 		if name == "" {
 			PrintError("category name cannot be empty")
 			return
 		}
 	}
 
-	// Check for duplicates
-	existing, err := cc.repos.Categories.FindByName(name)
-	if err != nil && !errors.Is(err, storage.ErrNotFound) {
-		PrintError(fmt.Sprintf("failed to check for existing category: %v", err))
-		return
+	parentID := 0
+	if parentRef != "" {
+		parent, err := resolveCategory(cc.repos, parentRef)
+		if err != nil {
+			PrintError(err.Error())
+			return
+		}
+		parentID = parent.ID()
+	}
+
+	segments := []string{name}
+	if strings.Contains(name, domain.CategoryPathSeparator) {
+		segments = domain.SplitCategoryPath(name)
+		if len(segments) == 0 {
+			PrintError("category name cannot be empty")
+			return
+		}
 	}
 
-	if existing != nil {
-		PrintError("category already exists")
+	// Walk the path, reusing existing categories and creating missing ones
+	for i, segment := range segments {
+		existing, err := cc.findChild(parentID, segment)
+		if err != nil {
+			PrintError(fmt.Sprintf("failed to check for existing category: %v", err))
+			return
+		}
+
+		if existing != nil {
+			if i == len(segments)-1 {
+				PrintError("category already exists")
+				return
+			}
+			parentID = existing.ID()
+			continue
+		}
+
+		category, err := domain.NewCategory(segment)
+		if err != nil {
+			PrintError(fmt.Sprintf("failed to create category: %v", err))
+			return
+		}
+		if err := category.SetParent(parentID); err != nil {
+			PrintError(fmt.Sprintf("failed to create category: %v", err))
+			return
+		}
+
+		if err := cc.repos.Categories.Create(category); err != nil {
+			PrintError(fmt.Sprintf("failed to save category: %v", err))
+			return
+		}
+
+		PrintSuccess(fmt.Sprintf("Created category '%s' (ID: %d)", cc.categoryPath(category.ID()), category.ID()))
+		parentID = category.ID()
+	}
+}
+
+// move changes the parent of a category. The parent may be given by ID or
+// path; "none" or "0" turns the category into a top-level category.
+func (cc *CategoryCommand) move(args []string) {
+	if len(args) < 2 {
+		PrintError("Missing required arguments. Use 'snip category move <id> <parent|none>'")
 		return
 	}
 
-	// Create and save the category
-	category, err := domain.NewCategory(name)
+	id, err := strconv.Atoi(args[0])
 	if err != nil {
-		PrintError(fmt.Sprintf("failed to create category: %v", err))
+		PrintError(fmt.Sprintf("Invalid ID '%s'. ID must be a number", args[0]))
 		return
 	}
 
-	if err := cc.repos.Categories.Create(category); err != nil {
-		PrintError(fmt.Sprintf("failed to save category: %v", err))
+	if _, err := cc.repos.Categories.FindByID(id); errors.Is(err, storage.ErrNotFound) {
+		PrintError(fmt.Sprintf("Category with ID %d not found", id))
+		return
+	} else if err != nil {
+		PrintError(fmt.Sprintf("Failed to find category: %v", err))
+		return
+	}
+
+	parentID := 0
+	if ref := strings.TrimSpace(args[1]); ref != "none" && ref != "0" {
+		parent, err := resolveCategory(cc.repos, ref)
+		if err != nil {
+			PrintError(err.Error())
+			return
+		}
+		parentID = parent.ID()
+	}
+
+	if err := cc.repos.Categories.Move(id, parentID); err != nil {
+		PrintError(fmt.Sprintf("Failed to move category: %v", err))
 		return
 	}
 
-	PrintSuccess(fmt.Sprintf("Created category '%s' (ID: %d)", name, category.ID()))
+	PrintSuccess(fmt.Sprintf("Moved category to '%s' (ID: %d)", cc.categoryPath(id), id))
+}
+
+// resolveCategory finds a category by numeric ID or by path.
+func resolveCategory(repos *storage.Repositories, ref string) (*domain.Category, error) {
+	if id, err := strconv.Atoi(ref); err == nil {
+		category, err := repos.Categories.FindByID(id)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("category with ID %d not found", id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find category: %w", err)
+		}
+		return category, nil
+	}
+
+	category, err := repos.Categories.FindByPath(ref)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("category '%s' not found", ref)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find category: %w", err)
+	}
+	return category, nil
+}
+
+// findChild returns the direct child of parentID with the given name, or nil.
+func (cc *CategoryCommand) findChild(parentID int, name string) (*domain.Category, error) {
+	categories, err := cc.repos.Categories.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		if category.ParentID() == parentID && category.Name() == name {
+			return category, nil
+		}
+	}
+	return nil, nil
+}
+
+// categoryPath returns the full path of a category for display.
+func (cc *CategoryCommand) categoryPath(id int) string {
+	categories, err := cc.repos.Categories.List()
+	if err != nil {
+		return ""
+	}
+	return domain.NewCategoryTree(categories).Path(id)
 }
 
 // delete removes a category after user confirmation.
@@ -153,6 +312,11 @@ func (cc *CategoryCommand) delete(args []string) {
 	}
 
 	// Confirm deletion
+	if categories, err := cc.repos.Categories.List(); err == nil {
+		if children := domain.NewCategoryTree(categories).Children(id); len(children) > 0 {
+			PrintInfo(fmt.Sprintf("%d subcategory(ies) will be moved up one level", len(children)))
+		}
+	}
 	fmt.Printf("Are you sure you want to delete category '%s'? (y/n): ", category.Name())
 	var response string
 	fmt.Scanln(&response)
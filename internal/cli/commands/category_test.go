@@ -89,6 +89,144 @@ func TestCategoryCommand_create(t *testing.T) {
 	})
 }
 
+func TestCategoryCommand_createHierarchy(t *testing.T) {
+	t.Run("creates missing categories along a path", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		cc := NewCategoryCommand(repos)
+
+		cc.create([]string{"backend/db/postgres"})
+
+		categories, _ := repos.Categories.List()
+		if len(categories) != 3 {
+			t.Fatalf("Expected 3 categories, got %d", len(categories))
+		}
+
+		found, err := repos.Categories.FindByPath("backend/db/postgres")
+		if err != nil {
+			t.Fatalf("Expected nested category to exist: %v", err)
+		}
+		if found.Name() != "postgres" {
+			t.Errorf("Expected name 'postgres', got '%s'", found.Name())
+		}
+	})
+
+	t.Run("reuses existing parents in a path", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		cc := NewCategoryCommand(repos)
+
+		cc.create([]string{"backend/db"})
+		cc.create([]string{"backend/cache"})
+
+		categories, _ := repos.Categories.List()
+		if len(categories) != 3 {
+			t.Errorf("Expected 3 categories, got %d", len(categories))
+		}
+	})
+
+	t.Run("creates category under --parent given by path", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		cc := NewCategoryCommand(repos)
+
+		cc.create([]string{"backend/db"})
+		cc.create([]string{"redis", "--parent", "backend/db"})
+
+		if _, err := repos.Categories.FindByPath("backend/db/redis"); err != nil {
+			t.Errorf("Expected category under parent: %v", err)
+		}
+	})
+
+	t.Run("creates category under --parent given by ID", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		cc := NewCategoryCommand(repos)
+
+		cc.create([]string{"backend"})
+		cc.create([]string{"db", "--parent", "1"})
+
+		if _, err := repos.Categories.FindByPath("backend/db"); err != nil {
+			t.Errorf("Expected category under parent: %v", err)
+		}
+	})
+
+	t.Run("rejects unknown parent", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		cc := NewCategoryCommand(repos)
+
+		cc.create([]string{"db", "--parent", "missing"})
+
+		categories, _ := repos.Categories.List()
+		if len(categories) != 0 {
+			t.Errorf("Expected 0 categories, got %d", len(categories))
+		}
+	})
+
+	t.Run("rejects existing full path", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		cc := NewCategoryCommand(repos)
+
+		cc.create([]string{"backend/db"})
+		cc.create([]string{"backend/db"})
+
+		categories, _ := repos.Categories.List()
+		if len(categories) != 2 {
+			t.Errorf("Expected 2 categories, got %d", len(categories))
+		}
+	})
+}
+
+func TestCategoryCommand_move(t *testing.T) {
+	t.Run("moves category under new parent", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		cc := NewCategoryCommand(repos)
+
+		cc.create([]string{"backend"})
+		cc.create([]string{"db"})
+
+		cc.move([]string{"2", "backend"})
+
+		if _, err := repos.Categories.FindByPath("backend/db"); err != nil {
+			t.Errorf("Expected category to be moved: %v", err)
+		}
+	})
+
+	t.Run("moves category to top level with none", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		cc := NewCategoryCommand(repos)
+
+		cc.create([]string{"backend/db"})
+
+		cc.move([]string{"2", "none"})
+
+		db, _ := repos.Categories.FindByID(2)
+		if db.ParentID() != 0 {
+			t.Errorf("Expected top-level category, got parent %d", db.ParentID())
+		}
+	})
+
+	t.Run("rejects moving under a descendant", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		cc := NewCategoryCommand(repos)
+
+		cc.create([]string{"backend/db/postgres"})
+
+		cc.move([]string{"1", "backend/db/postgres"})
+
+		backend, _ := repos.Categories.FindByID(1)
+		if backend.ParentID() != 0 {
+			t.Errorf("Expected parent to be unchanged, got %d", backend.ParentID())
+		}
+	})
+
+	t.Run("validates arguments", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		cc := NewCategoryCommand(repos)
+
+		cc.move([]string{})
+		cc.move([]string{"1"})
+		cc.move([]string{"not-a-number", "none"})
+		cc.move([]string{"999", "none"})
+	})
+}
+
 func TestCategoryCommand_list(t *testing.T) {
 	t.Run("lists all categories", func(t *testing.T) {
 		repos := setupTestRepos(t)
@@ -107,6 +245,16 @@ func TestCategoryCommand_list(t *testing.T) {
 		}
 	})
 
+	t.Run("lists nested categories", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		cc := NewCategoryCommand(repos)
+
+		cc.create([]string{"backend/db/postgres"})
+		cc.create([]string{"frontend"})
+
+		cc.list()
+	})
+
 	t.Run("handles empty category list", func(t *testing.T) {
 		repos := setupTestRepos(t)
 		cc := NewCategoryCommand(repos)
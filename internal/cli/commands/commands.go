@@ -3,25 +3,53 @@
 package commands
 
 import (
+	"os"
+
+	"github.com/7-Dany/snip/internal/cli/config"
 	"github.com/7-Dany/snip/internal/storage"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
 )
 
 // CLI coordinates all command handlers and provides the main entry point
 // for command execution.
 type CLI struct {
-	snippet  *SnippetCommand
-	category *CategoryCommand
-	tag      *TagCommand
-	help     *HelpCommand
+	snippet    *SnippetCommand
+	category   *CategoryCommand
+	tag        *TagCommand
+	stats      *StatsCommand
+	encryption *EncryptionCommand
+	imports    *ImportCommand
+	exports    *ExportCommand
+	capture    *CaptureCommand
+	harvest    *HarvestCommand
+	pick       *PickCommand
+	launcher   *LauncherCommand
+	library    *LibraryCommand
+	config     *ConfigCommand
+	help       *HelpCommand
 }
 
 // NewCLI creates a new CLI instance with all command handlers initialized.
-func NewCLI(repos *storage.Repositories) *CLI {
+func NewCLI(repos *storage.Repositories, cfg *config.Config) *CLI {
+	snippet := NewSnippetCommand(repos)
+	snippet.config = cfg
+
 	return &CLI{
-		snippet:  NewSnippetCommand(repos),
-		category: NewCategoryCommand(repos),
-		tag:      NewTagCommand(repos),
-		help:     NewHelpCommand(repos),
+		snippet:    snippet,
+		category:   NewCategoryCommand(repos),
+		tag:        NewTagCommand(repos),
+		stats:      NewStatsCommand(repos),
+		encryption: NewEncryptionCommand(repos),
+		imports:    NewImportCommand(repos),
+		exports:    NewExportCommand(repos),
+		capture:    NewCaptureCommand(repos),
+		harvest:    NewHarvestCommand(repos),
+		pick:       NewPickCommand(repos),
+		launcher:   NewLauncherCommand(repos),
+		library:    NewLibraryCommand(cfg),
+		config:     NewConfigCommand(cfg),
+		help:       NewHelpCommand(repos),
 	}
 }
 
@@ -46,8 +74,72 @@ func (cli *CLI) Run(args []string) {
 		cli.category.manage(commandArgs)
 	case "tag":
 		cli.tag.manage(commandArgs)
+	case "stats":
+		cli.stats.manage(commandArgs)
+	case "encrypt":
+		cli.encryption.encrypt(commandArgs)
+	case "decrypt":
+		cli.encryption.decrypt(commandArgs)
+	case "import":
+		cli.imports.manage(commandArgs)
+	case "export":
+		cli.exports.manage(commandArgs)
+	case "insert":
+		cli.snippet.insert(commandArgs)
+	case "capture":
+		cli.capture.manage(commandArgs)
+	case "harvest":
+		cli.harvest.manage(commandArgs)
+	case "pick":
+		cli.pick.manage(commandArgs)
+	case "launcher":
+		cli.launcher.manage(commandArgs)
+	case "library":
+		cli.library.manage(commandArgs)
+	case "config":
+		cli.config.manage(commandArgs)
 	default:
 		// Assume it's a snippet command for backward compatibility
 		cli.snippet.manage(args[1:])
 	}
 }
+
+// RunWithoutLibrary runs the commands that only use the configuration,
+// 'library' and 'config', so that they work before any library is opened,
+// without its passphrase and whatever library is selected. It reports
+// whether args named such a command.
+func RunWithoutLibrary(args []string, cfg *config.Config) bool {
+	if len(args) < 2 {
+		return false
+	}
+	switch args[1] {
+	case "library":
+		NewLibraryCommand(cfg).manage(args[2:])
+	case "config":
+		NewConfigCommand(cfg).manage(args[2:])
+	default:
+		return false
+	}
+	return true
+}
+
+// RepairsConfig reports whether args name 'config path' or 'config edit',
+// which run even if the config file is invalid, to find and fix it.
+func RepairsConfig(args []string) bool {
+	return len(args) > 2 && args[1] == "config" && (args[2] == "path" || args[2] == "edit")
+}
+
+// ReservesStdout reports whether the command in args prints its result on
+// stdout for a shell or launcher to read, as pick and launcher do. Such
+// commands need UseStderr before the library is opened.
+func ReservesStdout(args []string) bool {
+	return len(args) > 1 && (args[1] == "pick" || args[1] == "launcher")
+}
+
+// UseStderr sends messages and the passphrase prompt to stderr, so that
+// nothing but a command's result reaches stdout.
+func UseStderr() {
+	color.Output = os.Stderr
+	promptOutput = os.Stderr
+	lipgloss.SetDefaultRenderer(lipgloss.NewRenderer(os.Stderr))
+}
@@ -2,13 +2,16 @@
 package commands
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/7-Dany/snip/internal/cli/config"
 )
 
 func TestNewCLI(t *testing.T) {
 	t.Run("creates CLI with all command handlers", func(t *testing.T) {
 		repos := setupTestRepos(t)
-		cli := NewCLI(repos)
+		cli := NewCLI(repos, &config.Config{})
 
 		if cli == nil {
 			t.Fatal("NewCLI returned nil")
@@ -26,6 +29,30 @@ func TestNewCLI(t *testing.T) {
 			t.Error("tag command handler is nil")
 		}
 
+		if cli.stats == nil {
+			t.Error("stats command handler is nil")
+		}
+
+		if cli.encryption == nil {
+			t.Error("encryption command handler is nil")
+		}
+
+		if cli.imports == nil || cli.exports == nil {
+			t.Error("import/export command handlers are nil")
+		}
+
+		if cli.capture == nil || cli.harvest == nil {
+			t.Error("capture/harvest command handlers are nil")
+		}
+
+		if cli.pick == nil || cli.launcher == nil {
+			t.Error("pick/launcher command handlers are nil")
+		}
+
+		if cli.library == nil || cli.config == nil {
+			t.Error("library/config command handlers are nil")
+		}
+
 		if cli.help == nil {
 			t.Error("help command handler is nil")
 		}
@@ -34,7 +61,7 @@ func TestNewCLI(t *testing.T) {
 
 func TestCLI_Run(t *testing.T) {
 	repos := setupTestRepos(t)
-	cli := NewCLI(repos)
+	cli := NewCLI(repos, &config.Config{})
 
 	t.Run("shows error when no command provided", func(t *testing.T) {
 		// Should show error, not panic
@@ -61,8 +88,58 @@ func TestCLI_Run(t *testing.T) {
 		cli.Run([]string{"snip", "tag", "list"})
 	})
 
+	t.Run("routes stats command", func(t *testing.T) {
+		// Should not panic
+		cli.Run([]string{"snip", "stats"})
+	})
+
 	t.Run("routes unknown command to snippet handler", func(t *testing.T) {
 		// Should treat as snippet command for backward compatibility
 		cli.Run([]string{"snip", "list"})
 	})
 }
+
+func TestReservesStdout(t *testing.T) {
+	for args, want := range map[string]bool{
+		"snip pick":             true,
+		"snip launcher list":    true,
+		"snip snippet list":     false,
+		"snip":                  false,
+		"snip library use work": false,
+	} {
+		if got := ReservesStdout(strings.Fields(args)); got != want {
+			t.Errorf("%q: expected %v, got %v", args, want, got)
+		}
+	}
+}
+
+func TestRunWithoutLibrary(t *testing.T) {
+	cfg := setupTestConfig(t)
+
+	if !RunWithoutLibrary([]string{"snip", "library", "add", "work"}, cfg) {
+		t.Fatal("expected 'library' to run without a library")
+	}
+	if _, err := cfg.Library("work"); err != nil {
+		t.Errorf("expected library work to be added, got %v", err)
+	}
+	if !RunWithoutLibrary([]string{"snip", "config", "path"}, cfg) {
+		t.Error("expected 'config' to run without a library")
+	}
+	if RunWithoutLibrary([]string{"snip", "snippet", "list"}, cfg) || RunWithoutLibrary([]string{"snip"}, cfg) {
+		t.Error("expected other commands to need a library")
+	}
+}
+
+func TestRepairsConfig(t *testing.T) {
+	for args, want := range map[string]bool{
+		"snip config path": true,
+		"snip config edit": true,
+		"snip config list": false,
+		"snip config":      false,
+		"snip list":        false,
+	} {
+		if got := RepairsConfig(strings.Fields(args)); got != want {
+			t.Errorf("%q: expected %v, got %v", args, want, got)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/cli/config"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// configValueWidth is the maximum width of the value column of 'snip config list'.
+const configValueWidth = 60
+
+// ConfigCommand reads and changes the configuration file.
+type ConfigCommand struct {
+	config *config.Config
+}
+
+// NewConfigCommand creates a new ConfigCommand instance.
+func NewConfigCommand(cfg *config.Config) *ConfigCommand {
+	return &ConfigCommand{config: cfg}
+}
+
+// manage routes config subcommands to the appropriate handler.
+func (cc *ConfigCommand) manage(args []string) {
+	if len(args) == 0 {
+		PrintError("No subcommand provided. Use 'snip help config' for available commands")
+		return
+	}
+
+	subcommand := strings.ToLower(args[0])
+	subcommandArgs := args[1:]
+
+	switch subcommand {
+	case "get":
+		cc.get(subcommandArgs)
+	case "set":
+		cc.set(subcommandArgs)
+	case "list":
+		cc.list()
+	case "edit":
+		cc.edit()
+	case "path":
+		cc.path()
+	default:
+		PrintError(fmt.Sprintf("Unknown command '%s'. Use 'snip help config' for available commands", args[0]))
+	}
+}
+
+// get prints the value of a key.
+func (cc *ConfigCommand) get(args []string) {
+	if len(args) != 1 {
+		PrintError("Use 'snip config get <key>'. List the keys with 'snip config list'")
+		return
+	}
+
+	value, err := cc.config.Get(args[0])
+	if err != nil {
+		PrintError(err.Error())
+		return
+	}
+	fmt.Println(value)
+}
+
+// set changes the value of a key and saves the file.
+func (cc *ConfigCommand) set(args []string) {
+	if len(args) != 2 {
+		PrintError("Use 'snip config set <key> <value>'. List the keys with 'snip config list'")
+		return
+	}
+
+	key, value := args[0], args[1]
+	if err := cc.config.Set(key, value); err != nil {
+		PrintError(err.Error())
+		return
+	}
+	if err := cc.config.Save(); err != nil {
+		PrintError(fmt.Sprintf("failed to save config: %v", err))
+		return
+	}
+
+	PrintSuccess(fmt.Sprintf("Set %s to '%s'", key, value))
+	if key == "storage_path" && cc.config.StoragePathOverridden() {
+		PrintInfo(fmt.Sprintf("%s overrides storage_path while it is set", config.StoragePathEnv))
+	}
+}
+
+// list displays every key with its value.
+func (cc *ConfigCommand) list() {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Key", "Value", "Description"})
+
+	for _, key := range config.Keys() {
+		value, _ := cc.config.Get(key)
+		if key == "storage_path" && cc.config.StoragePathOverridden() {
+			value += fmt.Sprintf(" (from %s)", config.StoragePathEnv)
+		}
+		t.AppendRow(table.Row{key, value, config.Describe(key)})
+	}
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 2, WidthMax: configValueWidth, WidthMaxEnforcer: text.WrapHard},
+	})
+	t.SetStyle(table.StyleColoredBright)
+	t.Render()
+}
+
+// edit opens the config file in $VISUAL or $EDITOR. The edited file is
+// checked before it replaces the config, and can be edited again if it is
+// invalid.
+func (cc *ConfigCommand) edit() {
+	path := cc.config.Path()
+	if path == "" {
+		PrintError("The config was not loaded from a file")
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		PrintError(fmt.Sprintf("failed to read config: %v", err))
+		return
+	}
+
+	draft, err := os.CreateTemp("", "snip-config-*.json")
+	if err != nil {
+		PrintError(fmt.Sprintf("failed to create a draft: %v", err))
+		return
+	}
+	defer os.Remove(draft.Name())
+	draft.Write(data)
+	draft.Close()
+
+	for {
+		if err := runEditor(draft.Name()); err != nil {
+			PrintError(fmt.Sprintf("Editor failed: %v", err))
+			return
+		}
+		edited, err := os.ReadFile(draft.Name())
+		if err != nil {
+			PrintError(fmt.Sprintf("failed to read the edited config: %v", err))
+			return
+		}
+
+		if _, err = config.Parse(edited); err == nil {
+			if err := os.WriteFile(path, edited, 0644); err != nil {
+				PrintError(fmt.Sprintf("failed to write config: %v", err))
+				return
+			}
+			PrintSuccess(fmt.Sprintf("Saved %s", path))
+			return
+		}
+
+		PrintError(fmt.Sprintf("Invalid config: %v", err))
+		fmt.Print("Edit again? (y/n): ")
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(strings.TrimSpace(response)) != "y" {
+			PrintInfo("Config not changed")
+			return
+		}
+	}
+}
+
+// path prints the config file path.
+func (cc *ConfigCommand) path() {
+	fmt.Println(cc.config.Path())
+}
+
+// runEditor opens a file in $VISUAL or $EDITOR, or vi, and waits for it.
+// The variable may hold arguments, such as "code --wait".
+func runEditor(file string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		fields = []string{"vi"}
+	}
+
+	cmd := exec.Command(fields[0], append(fields[1:], file)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,60 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/cli/config"
+)
+
+func TestConfigCommand_manage(t *testing.T) {
+	t.Run("sets and saves values", func(t *testing.T) {
+		cfg := setupTestConfig(t)
+		cc := NewConfigCommand(cfg)
+
+		cc.manage([]string{"set", "run_timeout_seconds", "25"})
+		cc.manage([]string{"set", "format_on_save", "false"})
+		reloaded, err := config.LoadConfigFile(cfg.Path())
+		if err != nil || reloaded.RunTimeoutSeconds != 25 || reloaded.FormatOnSave {
+			t.Errorf("expected saved values, got %+v (%v)", reloaded, err)
+		}
+
+		// Should not panic
+		cc.manage([]string{"get", "run_timeout_seconds"})
+		cc.manage([]string{"list"})
+		cc.manage([]string{"path"})
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		cfg := setupTestConfig(t)
+		cc := NewConfigCommand(cfg)
+		timeout := cfg.RunTimeoutSeconds
+
+		// Should print errors, not panic
+		cc.manage(nil)
+		cc.manage([]string{"unknown"})
+		cc.manage([]string{"get"})
+		cc.manage([]string{"get", "colour"})
+		cc.manage([]string{"set", "run_timeout_seconds"})
+		cc.manage([]string{"set", "run_timeout_seconds", "soon"})
+		cc.manage([]string{"set", "runners", "{}"})
+
+		if cfg.RunTimeoutSeconds != timeout {
+			t.Errorf("expected run_timeout_seconds %d, got %d", timeout, cfg.RunTimeoutSeconds)
+		}
+	})
+
+	t.Run("saves the file after editing", func(t *testing.T) {
+		cfg := setupTestConfig(t)
+		cc := NewConfigCommand(cfg)
+		t.Setenv("VISUAL", "")
+		t.Setenv("EDITOR", "true")
+
+		before, _ := os.ReadFile(cfg.Path())
+		cc.manage([]string{"edit"})
+		if after, _ := os.ReadFile(cfg.Path()); string(after) != string(before) {
+			t.Error("expected the unchanged config to be saved as is")
+		}
+	})
+}
@@ -0,0 +1,203 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// dedupeColumnWidth is the maximum width of a snippet column in the side-by-side view.
+const dedupeColumnWidth = 48
+
+// dedupe finds duplicate snippets and merges them interactively.
+func (sc *SnippetCommand) dedupe(args []string) {
+	threshold := domain.DefaultSimilarityThreshold
+	dryRun := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--exact":
+			threshold = 1
+		case "--dry-run":
+			dryRun = true
+		case "--threshold":
+			if i+1 >= len(args) {
+				PrintError("Flag '--threshold' requires a value. Use 'snip snippet dedupe --threshold <0-1>'")
+				return
+			}
+			value, err := strconv.ParseFloat(args[i+1], 64)
+			if err != nil || value <= 0 || value > 1 {
+				PrintError(fmt.Sprintf("Invalid threshold '%s'. Must be a number between 0 and 1", args[i+1]))
+				return
+			}
+			threshold = value
+			i++
+		default:
+			PrintError(fmt.Sprintf("Unknown flag '%s'. Use 'snip help snippet' for usage", args[i]))
+			return
+		}
+	}
+
+	snippets, err := sc.repos.Snippets.List()
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to list snippets: %v", err))
+		return
+	}
+
+	clusters := domain.FindDuplicates(snippets, threshold)
+	if len(clusters) == 0 {
+		PrintSuccess("No duplicate snippets found")
+		return
+	}
+
+	PrintInfo(fmt.Sprintf("Found %d group(s) of duplicate snippets", len(clusters)))
+
+	merged := 0
+	for i, cluster := range clusters {
+		sc.displayCluster(i+1, len(clusters), cluster)
+		if dryRun {
+			continue
+		}
+
+		keep, quit := promptForMergeTarget(cluster)
+		if quit {
+			break
+		}
+		if keep == nil {
+			PrintInfo("Skipped")
+			continue
+		}
+
+		if err := sc.mergeCluster(cluster, keep); err != nil {
+			PrintError(fmt.Sprintf("Failed to merge snippets: %v", err))
+			continue
+		}
+		merged += len(cluster.Snippets) - 1
+		PrintSuccess(fmt.Sprintf("Merged %d snippet(s) into '%s' (ID: %d)",
+			len(cluster.Snippets)-1, keep.Title(), keep.ID()))
+	}
+
+	if !dryRun {
+		PrintInfo(fmt.Sprintf("Removed %d duplicate snippet(s)", merged))
+	}
+}
+
+// displayCluster shows the snippets of a duplicate cluster side by side.
+func (sc *SnippetCommand) displayCluster(n, total int, cluster domain.DuplicateCluster) {
+	kind := "exact duplicates"
+	if !cluster.Exact {
+		kind = fmt.Sprintf("near-duplicates, %.0f%% similar", cluster.Similarity*100)
+	}
+	fmt.Printf("\nGroup %d of %d (%s)\n", n, total, kind)
+
+	categoryMap, tagMap, err := sc.loadLookupMaps()
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to load lookup data: %v", err))
+		return
+	}
+
+	header := table.Row{""}
+	rows := []table.Row{{"Language"}, {"Category"}, {"Tags"}, {"Description"}, {"Updated"}, {"Code"}}
+	for _, s := range cluster.Snippets {
+		header = append(header, fmt.Sprintf("#%d %s", s.ID(), s.Title()))
+
+		categoryName := "N/A"
+		if cat, ok := categoryMap[s.CategoryID()]; ok {
+			categoryName = cat.Name()
+		}
+		description := s.Description()
+		if description == "" {
+			description = "N/A"
+		}
+
+		rows[0] = append(rows[0], s.Language())
+		rows[1] = append(rows[1], categoryName)
+		rows[2] = append(rows[2], strings.Join(resolveTagNames(s.Tags(), tagMap), ", "))
+		rows[3] = append(rows[3], description)
+		rows[4] = append(rows[4], s.UpdatedAt().Format("2006-01-02 15:04"))
+		rows[5] = append(rows[5], s.Code())
+	}
+
+	configs := make([]table.ColumnConfig, 0, len(cluster.Snippets))
+	for i := range cluster.Snippets {
+		configs = append(configs, table.ColumnConfig{
+			Number:           i + 2,
+			WidthMax:         dedupeColumnWidth,
+			WidthMaxEnforcer: text.WrapHard,
+		})
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(header)
+	t.AppendRows(rows)
+	t.SetColumnConfigs(configs)
+	t.SetStyle(table.StyleColoredBright)
+	t.Style().Options.SeparateRows = true
+	t.Render()
+}
+
+// promptForMergeTarget asks which snippet of the cluster to keep.
+// It returns nil if the cluster should be skipped, and quit if the user
+// wants to stop deduplicating.
+func promptForMergeTarget(cluster domain.DuplicateCluster) (keep *domain.Snippet, quit bool) {
+	def := cluster.Snippets[0]
+
+	for {
+		fmt.Printf("Keep which snippet? Enter ID, 's' to skip, 'q' to quit [%d]: ", def.ID())
+		var response string
+		if _, err := fmt.Scanln(&response); errors.Is(err, io.EOF) {
+			return nil, true
+		}
+
+		response = strings.ToLower(strings.TrimSpace(response))
+		switch response {
+		case "":
+			return def, false
+		case "s":
+			return nil, false
+		case "q":
+			return nil, true
+		}
+
+		if id, err := strconv.Atoi(response); err == nil {
+			for _, s := range cluster.Snippets {
+				if s.ID() == id {
+					return s, false
+				}
+			}
+		}
+		PrintError(fmt.Sprintf("Invalid choice '%s'", response))
+	}
+}
+
+// mergeCluster merges all snippets of the cluster into keep and deletes the others.
+func (sc *SnippetCommand) mergeCluster(cluster domain.DuplicateCluster, keep *domain.Snippet) error {
+	for _, s := range cluster.Snippets {
+		if s.ID() != keep.ID() {
+			keep.Merge(s)
+		}
+	}
+
+	if err := sc.repos.Snippets.Update(keep); err != nil {
+		return err
+	}
+
+	for _, s := range cluster.Snippets {
+		if s.ID() == keep.ID() {
+			continue
+		}
+		if err := sc.repos.Snippets.Delete(s.ID()); err != nil {
+			return fmt.Errorf("failed to delete snippet %d: %w", s.ID(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+func TestSnippetCommand_dedupe(t *testing.T) {
+	t.Run("reports no duplicates", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+
+		snip, _ := domain.NewSnippet("Test", "go", "func test() {}")
+		repos.Snippets.Create(snip)
+
+		sc.dedupe([]string{})
+	})
+
+	t.Run("lists clusters in dry run without changes", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+
+		snip1, _ := domain.NewSnippet("First", "go", "x := 1\ny := 2")
+		snip2, _ := domain.NewSnippet("Second", "go", "x:=1\r\n  y := 2\r\n")
+		repos.Snippets.Create(snip1)
+		repos.Snippets.Create(snip2)
+
+		sc.dedupe([]string{"--dry-run"})
+		sc.dedupe([]string{"--dry-run", "--exact"})
+
+		snippets, _ := repos.Snippets.List()
+		if len(snippets) != 2 {
+			t.Errorf("expected dry run to keep 2 snippets, got %d", len(snippets))
+		}
+	})
+
+	t.Run("validates flags", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+
+		sc.dedupe([]string{"--threshold"})
+		sc.dedupe([]string{"--threshold", "2"})
+		sc.dedupe([]string{"--threshold", "abc"})
+		sc.dedupe([]string{"--unknown"})
+	})
+}
+
+func TestSnippetCommand_mergeCluster(t *testing.T) {
+	t.Run("merges metadata and deletes duplicates", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+
+		tag1, _ := domain.NewTag("one")
+		tag2, _ := domain.NewTag("two")
+		repos.Tags.Create(tag1)
+		repos.Tags.Create(tag2)
+
+		keep, _ := domain.NewSnippet("Keep", "go", "x := 1")
+		keep.AddTag(tag1.ID())
+		dup, _ := domain.NewSnippet("Dup", "go", "x := 1")
+		dup.AddTag(tag2.ID())
+		dup.SetDescription("richer description")
+		repos.Snippets.Create(keep)
+		repos.Snippets.Create(dup)
+
+		cluster := domain.DuplicateCluster{Snippets: []*domain.Snippet{keep, dup}, Exact: true, Similarity: 1}
+		if err := sc.mergeCluster(cluster, keep); err != nil {
+			t.Fatalf("mergeCluster failed: %v", err)
+		}
+
+		snippets, _ := repos.Snippets.List()
+		if len(snippets) != 1 {
+			t.Fatalf("expected 1 snippet after merge, got %d", len(snippets))
+		}
+
+		merged := snippets[0]
+		if merged.ID() != keep.ID() {
+			t.Errorf("expected snippet %d to be kept, got %d", keep.ID(), merged.ID())
+		}
+		if !merged.HasTag(tag1.ID()) || !merged.HasTag(tag2.ID()) {
+			t.Errorf("expected combined tags, got %v", merged.Tags())
+		}
+		if merged.Description() != "richer description" {
+			t.Errorf("expected richer description, got %q", merged.Description())
+		}
+	})
+}
+
+func TestWarnDuplicates(t *testing.T) {
+	t.Run("does not panic", func(t *testing.T) {
+		snip, _ := domain.NewSnippet("Test", "go", "code")
+		warnDuplicates(nil)
+		warnDuplicates([]*domain.Snippet{snip})
+	})
+}
@@ -0,0 +1,137 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/storage"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PassphraseEnv names the environment variable that holds the storage
+// passphrase. When it is set, snip does not prompt for the passphrase.
+const PassphraseEnv = "SNIP_PASSPHRASE"
+
+// promptOutput is where the passphrase prompt is drawn, see UseStderr.
+var promptOutput = os.Stdout
+
+// EncryptionCommand handles encrypting and decrypting the snippet store.
+type EncryptionCommand struct {
+	repos *storage.Repositories
+}
+
+// NewEncryptionCommand creates a new EncryptionCommand instance.
+func NewEncryptionCommand(repos *storage.Repositories) *EncryptionCommand {
+	return &EncryptionCommand{repos: repos}
+}
+
+// encrypt encrypts the store with a new passphrase. On an encrypted store
+// it changes the passphrase.
+func (ec *EncryptionCommand) encrypt(args []string) {
+	if len(args) > 0 {
+		PrintError(fmt.Sprintf("Unexpected argument '%s'. Use 'snip encrypt'", args[0]))
+		return
+	}
+
+	passphrase := os.Getenv(PassphraseEnv)
+	if passphrase == "" {
+		title := "Encrypt your snippets"
+		if ec.repos.Encrypted() {
+			title = "Change the passphrase"
+		}
+		passphrase = promptForPassphrase(title, "New passphrase")
+		if passphrase == "" {
+			return
+		}
+		if promptForPassphrase(title, "Repeat passphrase") != passphrase {
+			PrintError("Passphrases do not match")
+			return
+		}
+	}
+
+	wasEncrypted := ec.repos.Encrypted()
+	if err := ec.repos.Encrypt(passphrase); err != nil {
+		PrintError(fmt.Sprintf("Failed to encrypt snippets: %v", err))
+		return
+	}
+
+	if wasEncrypted {
+		PrintSuccess("Passphrase changed")
+		return
+	}
+	PrintSuccess("Snippets encrypted")
+	PrintInfo(fmt.Sprintf("snip will ask for the passphrase on start, or read it from %s", PassphraseEnv))
+}
+
+// decrypt stores the snippets as plain JSON again after confirmation.
+func (ec *EncryptionCommand) decrypt(args []string) {
+	yes := false
+	for _, arg := range args {
+		switch arg {
+		case "--yes", "-y":
+			yes = true
+		default:
+			PrintError(fmt.Sprintf("Unknown flag '%s'. Use 'snip decrypt [--yes]'", arg))
+			return
+		}
+	}
+
+	if !ec.repos.Encrypted() {
+		PrintInfo("Snippets are not encrypted")
+		return
+	}
+
+	if !yes {
+		fmt.Print("Store your snippets unencrypted? (y/n): ")
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(strings.TrimSpace(response)) != "y" {
+			PrintInfo("Decrypt cancelled")
+			return
+		}
+	}
+
+	if err := ec.repos.Decrypt(); err != nil {
+		PrintError(fmt.Sprintf("Failed to decrypt snippets: %v", err))
+		return
+	}
+	PrintSuccess("Snippets decrypted")
+}
+
+// ReadPassphrase returns the storage passphrase from SNIP_PASSPHRASE, or
+// prompts for it. It returns an empty string if the prompt is cancelled.
+func ReadPassphrase() string {
+	if passphrase := os.Getenv(PassphraseEnv); passphrase != "" {
+		return passphrase
+	}
+	return promptForPassphrase("Your snippets are encrypted", "Passphrase")
+}
+
+// promptForPassphrase prompts for a passphrase without echoing it.
+// Returns empty string if cancelled or empty.
+func promptForPassphrase(title, fieldName string) string {
+	model := newSimpleInputModel("", 256, 40)
+	model.textInput.EchoMode = textinput.EchoPassword
+	model.textInput.EchoCharacter = '•'
+
+	p := tea.NewProgram(inputModelWrapper{model: model, title: title, emoji: "🔒", fieldName: fieldName}, tea.WithOutput(promptOutput))
+	finalModel, err := p.Run()
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to run passphrase prompt: %v", err))
+		return ""
+	}
+
+	wrapper := finalModel.(inputModelWrapper)
+	if wrapper.model.cancelled {
+		return ""
+	}
+
+	passphrase := wrapper.model.textInput.Value()
+	if passphrase == "" {
+		PrintError(fmt.Sprintf("%s cannot be empty", fieldName))
+	}
+	return passphrase
+}
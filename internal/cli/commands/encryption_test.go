@@ -0,0 +1,47 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/storage"
+)
+
+func TestEncryptionCommand(t *testing.T) {
+	t.Run("encrypts and decrypts the store", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snippets.json")
+		repos := storage.New(path)
+		ec := NewEncryptionCommand(repos)
+		t.Setenv(PassphraseEnv, "correct horse")
+
+		ec.encrypt([]string{})
+		if encrypted, _ := storage.IsEncrypted(path); !encrypted {
+			t.Fatal("expected store to be encrypted")
+		}
+
+		ec.decrypt([]string{"--yes"})
+		if encrypted, _ := storage.IsEncrypted(path); encrypted {
+			t.Error("expected store to be decrypted")
+		}
+	})
+
+	t.Run("handles invalid arguments", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		ec := NewEncryptionCommand(repos)
+
+		ec.encrypt([]string{"extra"})
+		ec.decrypt([]string{"--unknown"})
+		ec.decrypt([]string{"--yes"})
+		if repos.Encrypted() {
+			t.Error("expected store to stay unencrypted")
+		}
+	})
+}
+
+func TestReadPassphrase(t *testing.T) {
+	t.Setenv(PassphraseEnv, "correct horse")
+	if got := ReadPassphrase(); got != "correct horse" {
+		t.Errorf("expected passphrase from %s, got %q", PassphraseEnv, got)
+	}
+}
@@ -0,0 +1,249 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/7-Dany/snip/internal/exchange"
+	"github.com/7-Dany/snip/internal/storage"
+)
+
+// exportUsage is shown when export arguments are malformed.
+const exportUsage = "snip export vscode|ultisnips|yasnippet|pet|navi|site [--out <file|dir>] [--format html|markdown] [--language <lang>] [--category <id|path>] [--tag <id|name>]"
+
+// ExportCommand handles exporting snippets to other tools' files.
+type ExportCommand struct {
+	repos *storage.Repositories
+}
+
+// NewExportCommand creates a new ExportCommand instance.
+func NewExportCommand(repos *storage.Repositories) *ExportCommand {
+	return &ExportCommand{repos: repos}
+}
+
+// manage routes export formats to the appropriate writer.
+func (ec *ExportCommand) manage(args []string) {
+	if len(args) == 0 {
+		PrintError(fmt.Sprintf("Missing format. Use '%s'", exportUsage))
+		return
+	}
+
+	switch args[0] {
+	case "vscode":
+		ec.exportFile(args[1:], exchange.WriteVSCode)
+	case "ultisnips":
+		ec.exportDir(args[1:], exchange.WriteUltiSnips)
+	case "yasnippet":
+		ec.exportDir(args[1:], exchange.WriteYasnippet)
+	case "site":
+		ec.exportSite(args[1:])
+	case "pet":
+		ec.exportCheat(args[1:], exchange.WritePet)
+	case "navi":
+		ec.exportCheat(args[1:], exchange.WriteNavi)
+	default:
+		PrintError(fmt.Sprintf("Unknown export format '%s'. Use 'snip help export' for available formats", args[0]))
+	}
+}
+
+// exportFile writes the selected snippets with write, to --out or stdout.
+func (ec *ExportCommand) exportFile(args []string, write func([]*domain.Snippet) ([]byte, error)) {
+	out, snippets, ok := ec.parseExportArgs(args)
+	if !ok {
+		return
+	}
+
+	data, err := write(snippets)
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to export snippets: %v", err))
+		return
+	}
+
+	if out == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		PrintError(fmt.Sprintf("Failed to write file: %v", err))
+		return
+	}
+	PrintSuccess(fmt.Sprintf("Exported %d snippet(s) to %s", len(snippets), out))
+}
+
+// exportDir writes the selected snippets with write as files below the
+// --out directory, which is created if needed.
+func (ec *ExportCommand) exportDir(args []string, write func([]*domain.Snippet) (exchange.Files, error)) {
+	out, snippets, ok := ec.parseExportArgs(args)
+	if !ok {
+		return
+	}
+	if out == "" {
+		PrintError(fmt.Sprintf("Missing output directory. Use '%s'", exportUsage))
+		return
+	}
+
+	files, err := write(snippets)
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to export snippets: %v", err))
+		return
+	}
+
+	names := slices.Sorted(maps.Keys(files))
+	for _, name := range names {
+		path := filepath.Join(out, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			PrintError(fmt.Sprintf("Failed to create directory: %v", err))
+			return
+		}
+		if err := os.WriteFile(path, files[name], 0644); err != nil {
+			PrintError(fmt.Sprintf("Failed to write file: %v", err))
+			return
+		}
+	}
+	PrintSuccess(fmt.Sprintf("Exported %d snippet(s) to %d file(s) in %s", len(snippets), len(names), out))
+}
+
+// exportSite writes the selected snippets as a static HTML site, or as
+// Markdown cheat sheets with --format markdown.
+func (ec *ExportCommand) exportSite(args []string) {
+	write := exchange.WriteSite
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--format" {
+			rest = append(rest, args[i])
+			continue
+		}
+		if i+1 >= len(args) {
+			PrintError("Flag '--format' requires a value")
+			return
+		}
+		switch args[i+1] {
+		case "html":
+			write = exchange.WriteSite
+		case "markdown", "md":
+			write = exchange.WriteCheatSheets
+		default:
+			PrintError(fmt.Sprintf("Unknown site format '%s'. Use 'html' or 'markdown'", args[i+1]))
+			return
+		}
+		i++
+	}
+
+	ec.exportDir(rest, func(snippets []*domain.Snippet) (exchange.Files, error) {
+		library, err := ec.library(snippets)
+		if err != nil {
+			return nil, err
+		}
+		return write(library)
+	})
+}
+
+// library returns the selected snippets with all categories and tags, for
+// writers that show category paths and tag names.
+func (ec *ExportCommand) library(snippets []*domain.Snippet) (exchange.Library, error) {
+	categories, err := ec.repos.Categories.List()
+	if err != nil {
+		return exchange.Library{}, fmt.Errorf("failed to list categories: %w", err)
+	}
+	tags, err := ec.repos.Tags.List()
+	if err != nil {
+		return exchange.Library{}, fmt.Errorf("failed to list tags: %w", err)
+	}
+	return exchange.Library{Snippets: snippets, Categories: categories, Tags: tags}, nil
+}
+
+// exportCheat writes the selected snippets with a writer that needs the
+// library, such as pet or navi, to --out or stdout.
+func (ec *ExportCommand) exportCheat(args []string, write func(exchange.Library) ([]byte, error)) {
+	ec.exportFile(args, func(snippets []*domain.Snippet) ([]byte, error) {
+		library, err := ec.library(snippets)
+		if err != nil {
+			return nil, err
+		}
+		return write(library)
+	})
+}
+
+// parseExportArgs reads --out and the filters, and selects the snippets to
+// export. It reports false after printing an error, or if there is nothing
+// to export.
+func (ec *ExportCommand) parseExportArgs(args []string) (string, []*domain.Snippet, bool) {
+	out := ""
+	filterArgs := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--out" {
+			if i+1 >= len(args) {
+				PrintError("Flag '--out' requires a path")
+				return "", nil, false
+			}
+			out = args[i+1]
+			i++
+			continue
+		}
+		filterArgs = append(filterArgs, args[i])
+	}
+
+	snippets, err := ec.selectSnippets(filterArgs)
+	if err != nil {
+		PrintError(err.Error())
+		return "", nil, false
+	}
+	if len(snippets) == 0 {
+		PrintInfo("No snippets to export")
+		return "", nil, false
+	}
+	return out, snippets, true
+}
+
+// selectSnippets returns the snippets matching --language, --category and
+// --tag filters, ordered by ID. Without filters it returns all snippets.
+func (ec *ExportCommand) selectSnippets(args []string) ([]*domain.Snippet, error) {
+	snippets, err := ec.repos.Snippets.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snippets: %w", err)
+	}
+
+	for i := 0; i < len(args); i++ {
+		flag := args[i]
+		if flag != "--language" && flag != "--category" && flag != "--tag" {
+			return nil, fmt.Errorf("unknown flag '%s'. Use '%s'", flag, exportUsage)
+		}
+		if i+1 >= len(args) {
+			return nil, fmt.Errorf("flag '%s' requires a value", flag)
+		}
+		value := args[i+1]
+		i++
+
+		var matches []*domain.Snippet
+		switch flag {
+		case "--language":
+			matches, err = ec.repos.Snippets.FindByLanguage(value)
+		case "--category":
+			var category *domain.Category
+			if category, err = resolveCategory(ec.repos, value); err == nil {
+				matches, err = ec.repos.Snippets.FindByCategory(category.ID())
+			}
+		case "--tag":
+			var tag *domain.Tag
+			if tag, err = resolveTag(ec.repos, value); err == nil {
+				matches, err = ec.repos.Snippets.FindByTag(tag.ID())
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ids := snippetIDs(matches)
+		snippets = slices.DeleteFunc(snippets, func(s *domain.Snippet) bool {
+			return !slices.Contains(ids, s.ID())
+		})
+	}
+
+	slices.SortFunc(snippets, func(a, b *domain.Snippet) int { return a.ID() - b.ID() })
+	return snippets, nil
+}
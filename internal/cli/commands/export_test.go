@@ -0,0 +1,125 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+func TestExportCommand_manage(t *testing.T) {
+	setup := func(t *testing.T) *ExportCommand {
+		repos := setupTestRepos(t)
+		goSnippet, _ := domain.NewSnippet("Print", "go", "fmt.Println({{1:msg}})")
+		goSnippet.AddAlias("pr")
+		repos.Snippets.Create(goSnippet)
+		bashSnippet, _ := domain.NewSnippet("Echo", "bash", "echo \"$1\"")
+		repos.Snippets.Create(bashSnippet)
+		return NewExportCommand(repos)
+	}
+
+	t.Run("writes a VS Code snippet file", func(t *testing.T) {
+		ec := setup(t)
+		out := filepath.Join(t.TempDir(), "snip.code-snippets")
+
+		ec.manage([]string{"vscode", "--out", out})
+
+		data, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("expected export file: %v", err)
+		}
+		for _, want := range []string{`"Print"`, `"prefix": "pr"`, `${1:msg}`, `\\$1`, `"scope": "shellscript"`} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("expected export to contain %s:\n%s", want, data)
+			}
+		}
+	})
+
+	t.Run("filters by language", func(t *testing.T) {
+		ec := setup(t)
+		out := filepath.Join(t.TempDir(), "go.code-snippets")
+
+		ec.manage([]string{"vscode", "--language", "golang", "--out", out})
+
+		data, _ := os.ReadFile(out)
+		if !strings.Contains(string(data), "Print") || strings.Contains(string(data), "Echo") {
+			t.Errorf("expected only Go snippets:\n%s", data)
+		}
+	})
+
+	t.Run("writes UltiSnips and yasnippet directories", func(t *testing.T) {
+		ec := setup(t)
+		dir := t.TempDir()
+
+		ec.manage([]string{"ultisnips", "--out", filepath.Join(dir, "UltiSnips")})
+		ec.manage([]string{"yasnippet", "--out", filepath.Join(dir, "snippets")})
+
+		data, err := os.ReadFile(filepath.Join(dir, "UltiSnips", "go.snippets"))
+		if err != nil || !strings.Contains(string(data), `snippet pr "Print"`) {
+			t.Errorf("expected UltiSnips file, got %v:\n%s", err, data)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "UltiSnips", "sh.snippets")); err != nil {
+			t.Errorf("expected bash snippets in sh.snippets: %v", err)
+		}
+		data, err = os.ReadFile(filepath.Join(dir, "snippets", "go-mode", "pr"))
+		if err != nil || !strings.Contains(string(data), "# key: pr") {
+			t.Errorf("expected yasnippet file, got %v:\n%s", err, data)
+		}
+	})
+
+	t.Run("writes an HTML site and Markdown cheat sheets", func(t *testing.T) {
+		ec := setup(t)
+		dir := t.TempDir()
+
+		ec.manage([]string{"site", "--out", filepath.Join(dir, "html")})
+		ec.manage([]string{"site", "--out", filepath.Join(dir, "md"), "--format", "markdown"})
+
+		for _, name := range []string{"html/index.html", "html/search-index.js", "html/categories/uncategorized.html", "md/README.md", "md/uncategorized.md"} {
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+				t.Errorf("expected %s: %v", name, err)
+			}
+		}
+		data, _ := os.ReadFile(filepath.Join(dir, "md", "uncategorized.md"))
+		if !strings.Contains(string(data), "## Print") || !strings.Contains(string(data), "```go") {
+			t.Errorf("unexpected cheat sheet:\n%s", data)
+		}
+	})
+
+	t.Run("writes pet and navi files with tag names", func(t *testing.T) {
+		ec := setup(t)
+		tag, _ := domain.NewTag("fmt")
+		ec.repos.Tags.Create(tag)
+		snippet, _ := ec.repos.Snippets.FindByID(1)
+		snippet.AddTag(tag.ID())
+		dir := t.TempDir()
+
+		ec.manage([]string{"pet", "--out", filepath.Join(dir, "snippet.toml")})
+		ec.manage([]string{"navi", "--out", filepath.Join(dir, "snip.cheat")})
+
+		data, _ := os.ReadFile(filepath.Join(dir, "snippet.toml"))
+		if !strings.Contains(string(data), `command = "fmt.Println(<1=msg>)"`) || !strings.Contains(string(data), `tag = ["fmt"]`) {
+			t.Errorf("unexpected pet file:\n%s", data)
+		}
+		data, _ = os.ReadFile(filepath.Join(dir, "snip.cheat"))
+		if !strings.Contains(string(data), "% fmt\n\n# Print\nfmt.Println(<1>)\n\n$ 1: echo 'msg'\n") {
+			t.Errorf("unexpected navi file:\n%s", data)
+		}
+	})
+
+	t.Run("handles invalid arguments", func(t *testing.T) {
+		ec := setup(t)
+
+		// Should not panic
+		ec.manage([]string{})
+		ec.manage([]string{"ultisnips"})
+		ec.manage([]string{"site", "--out", t.TempDir(), "--format", "pdf"})
+		ec.manage([]string{"site", "--format"})
+		ec.manage([]string{"unknown"})
+		ec.manage([]string{"vscode", "--out"})
+		ec.manage([]string{"vscode", "--tag", "missing"})
+		ec.manage([]string{"vscode", "--bad", "x"})
+	})
+}
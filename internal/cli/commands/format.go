@@ -0,0 +1,34 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/formatter"
+)
+
+// formatCode validates and formats code before it is saved when format_on_save
+// is enabled. If the code is invalid, the user decides whether to save it as
+// is; formatCode returns false if they decline.
+func (sc *SnippetCommand) formatCode(language, code string) (string, bool) {
+	if !sc.config.FormatOnSave {
+		return code, true
+	}
+
+	external, _ := sc.config.FormatterFor(language)
+	formatted, err := formatter.Format(language, code, external.Command, formatter.DefaultTimeout)
+	if err == nil {
+		if formatted != code {
+			PrintInfo("Code formatted")
+		}
+		return formatted, true
+	}
+
+	PrintError(err.Error())
+	fmt.Print("Save the code as is? (y/n): ")
+	var response string
+	fmt.Scanln(&response)
+
+	return code, strings.ToLower(strings.TrimSpace(response)) == "y"
+}
@@ -0,0 +1,29 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"testing"
+
+	"github.com/7-Dany/snip/internal/cli/config"
+)
+
+func TestSnippetCommand_formatCode(t *testing.T) {
+	t.Run("leaves code unchanged when disabled", func(t *testing.T) {
+		sc := NewSnippetCommand(setupTestRepos(t))
+
+		code, ok := sc.formatCode("go", "x:=1")
+		if !ok || code != "x:=1" {
+			t.Errorf("expected unchanged code, got %q, %v", code, ok)
+		}
+	})
+
+	t.Run("formats valid code when enabled", func(t *testing.T) {
+		sc := NewSnippetCommand(setupTestRepos(t))
+		sc.config = &config.Config{FormatOnSave: true}
+
+		code, ok := sc.formatCode("go", "x:=1")
+		if !ok || code != "x := 1" {
+			t.Errorf("expected formatted code, got %q, %v", code, ok)
+		}
+	})
+}
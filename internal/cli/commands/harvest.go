@@ -0,0 +1,262 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/7-Dany/snip/internal/exchange"
+	"github.com/7-Dany/snip/internal/storage"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// harvestUsage is shown when harvest arguments are malformed.
+const harvestUsage = "snip harvest <dir|file>... [--dry-run]"
+
+// harvestFileLimit is the size above which files are not scanned for regions.
+const harvestFileLimit = 1 << 20
+
+// HarvestCommand handles keeping snippets in sync with marked regions of
+// source files.
+type HarvestCommand struct {
+	repos *storage.Repositories
+}
+
+// NewHarvestCommand creates a new HarvestCommand instance.
+func NewHarvestCommand(repos *storage.Repositories) *HarvestCommand {
+	return &HarvestCommand{repos: repos}
+}
+
+// manage scans the given files and directories for snip:begin/snip:end
+// regions. Regions are matched to snippets by name through the snippet
+// source: new regions are created, changed or moved ones updated, and
+// harvested snippets whose region is gone below the scanned paths are
+// reported. With --dry-run nothing is saved.
+func (hc *HarvestCommand) manage(args []string) {
+	dryRun := false
+	var paths []string
+	for _, arg := range args {
+		switch {
+		case arg == "--dry-run":
+			dryRun = true
+		case strings.HasPrefix(arg, "-"):
+			PrintError(fmt.Sprintf("Unknown flag '%s'. Use '%s'", arg, harvestUsage))
+			return
+		default:
+			abs, err := filepath.Abs(arg)
+			if err != nil {
+				PrintError(fmt.Sprintf("Invalid path '%s': %v", arg, err))
+				return
+			}
+			paths = append(paths, abs)
+		}
+	}
+	if len(paths) == 0 {
+		PrintError(fmt.Sprintf("Missing directory. Use '%s'", harvestUsage))
+		return
+	}
+
+	files, err := expandFiles(paths, func(string) bool { return true })
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to read directory: %v", err))
+		return
+	}
+
+	snippets, err := hc.repos.Snippets.List()
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to list snippets: %v", err))
+		return
+	}
+	harvested := make(map[string]*domain.Snippet)
+	aliases := make(map[string]bool)
+	for _, snippet := range snippets {
+		if _, name, ok := exchange.RegionSource(snippet.Source()); ok {
+			harvested[name] = snippet
+		}
+		for _, alias := range snippet.Aliases() {
+			aliases[alias] = true
+		}
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"ID", "Region", "Source", "Status"})
+	counts := make(map[string]int)
+	found := make(map[string]string)
+	failed := make(map[string]bool)
+	var changed []*domain.Snippet
+
+	fail := func(file string, err error) {
+		t.AppendRow(table.Row{"-", "-", displayPath(file), fmt.Sprintf("error: %v", err)})
+		failed[file] = true
+		counts["error"]++
+	}
+
+	for _, file := range files {
+		// Large and special files are skipped before they are read
+		info, err := os.Stat(file)
+		if err != nil {
+			fail(file, err)
+			continue
+		}
+		if !info.Mode().IsRegular() || info.Size() > harvestFileLimit {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			fail(file, err)
+			continue
+		}
+		if bytes.IndexByte(data, 0) >= 0 || !bytes.Contains(data, []byte("snip:")) {
+			continue
+		}
+		regions, err := exchange.ParseRegions(file, data)
+		if err != nil {
+			fail(file, err)
+			continue
+		}
+
+		for _, region := range regions {
+			location := fmt.Sprintf("%s:%d-%d", displayPath(file), region.Start, region.End)
+			if other, ok := found[region.Name]; ok {
+				t.AppendRow(table.Row{"-", region.Name, location, "duplicate of " + other})
+				counts["error"]++
+				continue
+			}
+			found[region.Name] = location
+
+			snippet, status := hc.harvestRegion(region, harvested[region.Name], aliases, dryRun)
+			if status == "" {
+				return
+			}
+			counts[status]++
+			if status == "created" || status == "updated" {
+				// A dry run leaves the old code in existing snippets
+				if dryRun {
+					changed = append(changed, region.Snippet)
+				} else {
+					changed = append(changed, snippet)
+				}
+			}
+			id := "-"
+			if snippet.ID() > 0 {
+				id = fmt.Sprint(snippet.ID())
+			}
+			t.AppendRow(table.Row{id, region.Name, location, status})
+		}
+	}
+
+	names := make([]string, 0, len(harvested))
+	for name := range harvested {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		snippet := harvested[name]
+		file, _, _ := exchange.RegionSource(snippet.Source())
+		if _, ok := found[name]; ok || failed[file] || !underAny(file, paths) {
+			continue
+		}
+		status := "missing: region removed"
+		if _, err := os.Stat(file); err != nil {
+			status = "missing: file removed"
+		}
+		t.AppendRow(table.Row{snippet.ID(), name, displayPath(file), status})
+		counts["missing"]++
+	}
+
+	if t.Length() == 0 {
+		PrintInfo("No snip:begin regions found")
+		return
+	}
+	t.SetStyle(table.StyleColoredBright)
+	t.Render()
+	// Code follows the source file, so secrets are reported, not redacted
+	warnSecrets(changed)
+
+	summary := fmt.Sprintf("%d created, %d updated, %d moved, %d unchanged, %d missing, %d error(s)",
+		counts["created"], counts["updated"], counts["moved"], counts["unchanged"], counts["missing"], counts["error"])
+	if dryRun {
+		PrintInfo("Dry run: " + summary)
+		return
+	}
+	PrintSuccess("Harvested regions: " + summary)
+}
+
+// harvestRegion creates or updates the snippet of a region and returns it
+// with its status: created, updated (code or title changed), moved (only
+// the lines changed) or unchanged. existing is the snippet previously
+// harvested from a region with the same name, or nil. New snippets get the
+// region name as alias unless another snippet uses it. Nothing is changed
+// with dryRun. The status is "" if saving failed.
+func (hc *HarvestCommand) harvestRegion(region exchange.Region, existing *domain.Snippet, aliases map[string]bool, dryRun bool) (*domain.Snippet, string) {
+	if existing == nil {
+		if dryRun {
+			return region.Snippet, "created"
+		}
+		if !aliases[region.Name] {
+			region.Snippet.AddAlias(region.Name)
+			aliases[region.Name] = true
+		}
+		if err := hc.repos.Snippets.Create(region.Snippet); err != nil {
+			PrintError(fmt.Sprintf("Failed to save snippet '%s': %v", region.Snippet.Title(), err))
+			return nil, ""
+		}
+		return region.Snippet, "created"
+	}
+
+	status := "unchanged"
+	switch {
+	case existing.Code() != region.Snippet.Code() || existing.Title() != region.Snippet.Title():
+		status = "updated"
+	case existing.Source() != region.Snippet.Source():
+		status = "moved"
+	}
+	if dryRun || status == "unchanged" {
+		return existing, status
+	}
+
+	if err := existing.SetCode(region.Snippet.Code()); err != nil {
+		PrintError(fmt.Sprintf("Failed to update snippet '%s': %v", existing.Title(), err))
+		return nil, ""
+	}
+	if err := existing.SetTitle(region.Snippet.Title()); err != nil {
+		PrintError(fmt.Sprintf("Failed to update snippet '%s': %v", existing.Title(), err))
+		return nil, ""
+	}
+	existing.SetSource(region.Snippet.Source())
+	if err := hc.repos.Snippets.Update(existing); err != nil {
+		PrintError(fmt.Sprintf("Failed to save snippet '%s': %v", existing.Title(), err))
+		return nil, ""
+	}
+	return existing, status
+}
+
+// underAny reports whether file is one of paths or lies below one of them.
+func underAny(file string, paths []string) bool {
+	for _, path := range paths {
+		if file == path || strings.HasPrefix(file, path+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// displayPath returns path relative to the working directory when it lies
+// below it, and unchanged otherwise.
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
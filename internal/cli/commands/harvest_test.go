@@ -0,0 +1,105 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/exchange"
+)
+
+func TestHarvestCommand_manage(t *testing.T) {
+	const retry = "package retry\n\n// snip:begin retry-backoff Retry with backoff\nfunc Retry() {\n\ttime.Sleep(delay)\n}\n// snip:end\n"
+	const edited = "package retry\n\nimport \"time\"\n\n// snip:begin retry-backoff Retry with backoff\nfunc Retry() {\n\ttime.Sleep(2 * delay)\n}\n// snip:end\n"
+
+	setup := func(t *testing.T) (*HarvestCommand, string) {
+		dir := t.TempDir()
+		os.WriteFile(filepath.Join(dir, "retry.go"), []byte(retry), 0644)
+		os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("no regions here\n"), 0644)
+		return NewHarvestCommand(setupTestRepos(t)), dir
+	}
+
+	t.Run("creates snippets keyed by region name", func(t *testing.T) {
+		hc, dir := setup(t)
+
+		hc.manage([]string{dir, "--dry-run"})
+		if snippets, _ := hc.repos.Snippets.List(); len(snippets) != 0 {
+			t.Fatalf("expected dry run to create nothing, got %d", len(snippets))
+		}
+
+		hc.manage([]string{dir})
+		hc.manage([]string{dir})
+
+		snippets, _ := hc.repos.Snippets.List()
+		if len(snippets) != 1 {
+			t.Fatalf("expected 1 snippet, got %d", len(snippets))
+		}
+		snippet := snippets[0]
+		if snippet.Title() != "Retry with backoff" || snippet.Language() != "go" || !snippet.HasAlias("retry-backoff") {
+			t.Errorf("unexpected snippet: %s %v", snippet, snippet.Aliases())
+		}
+		if want := filepath.Join(dir, "retry.go") + ":4-6#retry-backoff"; snippet.Source() != want {
+			t.Errorf("expected source %q, got %q", want, snippet.Source())
+		}
+	})
+
+	t.Run("updates changed and moved regions", func(t *testing.T) {
+		hc, dir := setup(t)
+		hc.manage([]string{dir})
+
+		os.WriteFile(filepath.Join(dir, "retry.go"), []byte(edited), 0644)
+		hc.manage([]string{dir, "--dry-run"})
+		snippet, _ := hc.repos.Snippets.FindByID(1)
+		if snippet.Code() != "func Retry() {\n\ttime.Sleep(delay)\n}" {
+			t.Fatalf("expected dry run to keep the code, got %q", snippet.Code())
+		}
+
+		hc.manage([]string{dir})
+		if snippet.Code() != "func Retry() {\n\ttime.Sleep(2 * delay)\n}" {
+			t.Errorf("expected updated code, got %q", snippet.Code())
+		}
+		if _, _, ok := exchange.RegionSource(snippet.Source()); !ok || snippet.Source() != filepath.Join(dir, "retry.go")+":6-8#retry-backoff" {
+			t.Errorf("expected moved source, got %q", snippet.Source())
+		}
+	})
+
+	t.Run("keeps snippets whose region disappeared", func(t *testing.T) {
+		hc, dir := setup(t)
+		hc.manage([]string{dir})
+
+		os.Remove(filepath.Join(dir, "retry.go"))
+		hc.manage([]string{dir})
+
+		if snippets, _ := hc.repos.Snippets.List(); len(snippets) != 1 {
+			t.Errorf("expected the snippet to be kept, got %d", len(snippets))
+		}
+	})
+
+	t.Run("skips vendored directories and reports unreadable files", func(t *testing.T) {
+		hc, dir := setup(t)
+		vendored := filepath.Join(dir, "node_modules", "retry")
+		os.MkdirAll(vendored, 0755)
+		os.WriteFile(filepath.Join(vendored, "copy.go"), []byte(strings.Replace(retry, "retry-backoff", "vendored", 1)), 0644)
+		os.Symlink(filepath.Join(dir, "gone.go"), filepath.Join(dir, "dangling.go"))
+
+		hc.manage([]string{dir})
+
+		snippets, _ := hc.repos.Snippets.List()
+		if len(snippets) != 1 || !snippets[0].HasAlias("retry-backoff") {
+			t.Errorf("expected only the retry-backoff region, got %v", snippets)
+		}
+	})
+
+	t.Run("handles invalid arguments and markers", func(t *testing.T) {
+		hc, dir := setup(t)
+		os.WriteFile(filepath.Join(dir, "broken.go"), []byte("// snip:begin open\nx\n"), 0644)
+
+		// Should not panic
+		hc.manage([]string{})
+		hc.manage([]string{"--bad"})
+		hc.manage([]string{dir})
+		hc.manage([]string{filepath.Join(dir, "missing")})
+	})
+}
@@ -43,9 +43,23 @@ func (hc *HelpCommand) Print(topic string) {
 		hc.printCategoryHelp(cyan, white, gray)
 	case "tag":
 		hc.printTagHelp(cyan, white, gray)
+	case "stats":
+		hc.printStatsHelp(cyan, white, gray)
+	case "encryption":
+		hc.printEncryptionHelp(cyan, white, gray)
+	case "import", "export":
+		hc.printExchangeHelp(cyan, white, gray)
+	case "capture", "harvest":
+		hc.printCaptureHelp(cyan, white, gray)
+	case "pick", "launcher":
+		hc.printPickHelp(cyan, white, gray)
+	case "library":
+		hc.printLibraryHelp(cyan, white, gray)
+	case "config":
+		hc.printConfigHelp(cyan, white, gray)
 	default:
 		PrintError(fmt.Sprintf("Unknown help topic: %s", topic))
-		fmt.Println("\nAvailable topics: snippet, category, tag")
+		fmt.Println("\nAvailable topics: snippet, category, tag, stats, encryption, import, export, capture, harvest, pick, launcher, library, config")
 	}
 }
 
@@ -55,16 +69,29 @@ func (hc *HelpCommand) printGeneralHelp(cyan, white, gray *color.Color) {
 
 	cyan.Println("\nCOMMANDS")
 	white.Println("  Snippet Management:")
-	fmt.Println("    snippet create                Create a new snippet interactively")
+	fmt.Println("    snippet create [--file f]     Create a new snippet interactively")
+	fmt.Println("    snippet add --from f:40-72    Create a snippet from a file's line range")
 	fmt.Println("    snippet list [--flags]        List all snippets (optional filters)")
 	fmt.Println("    snippet show <id>             Display a specific snippet")
 	fmt.Println("    snippet update <id>           Update an existing snippet")
 	fmt.Println("    snippet delete <id>           Delete a snippet")
 	fmt.Println("    snippet search <query>        Search for snippets")
+	fmt.Println("    snippet copy <id>             Copy a snippet's code to the clipboard")
+	fmt.Println("    insert <id> --into f --at n   Insert a snippet into a file (or --after <regex>)")
+	fmt.Println("    snippet run <id> [-- args]    Run a snippet with its language runner")
+	fmt.Println("    snippet favorite <id>         Mark a snippet as favorite (unfavorite to undo)")
+	fmt.Println("    snippet pin <id>              Pin a snippet to the top (unpin to undo)")
+	fmt.Println("    snippet fork <id>             Copy a read-only team snippet to edit it")
+	fmt.Println("    snippet recent [n]            List recently used snippets")
+	fmt.Println("    snippet top [n]               List most used snippets")
+	fmt.Println("    snippet dedupe [--flags]      Find and merge duplicate snippets")
+	fmt.Println("    snippet bulk <action> <ids>   Tag, move, relabel or delete many snippets")
+	fmt.Println("    snippet scan [ids] [--redact] Find credentials in snippets")
 
 	white.Println("\n  Category Management:")
-	fmt.Println("    category create [name]        Create a new category")
-	fmt.Println("    category list                 List all categories")
+	fmt.Println("    category create [name]        Create a new category (supports paths)")
+	fmt.Println("    category list                 List all categories as a tree")
+	fmt.Println("    category move <id> <parent>   Move a category under another one")
 	fmt.Println("    category delete <id>          Delete a category")
 
 	white.Println("\n  Tag Management:")
@@ -73,15 +100,32 @@ func (hc *HelpCommand) printGeneralHelp(cyan, white, gray *color.Color) {
 	fmt.Println("    tag delete <id>               Delete a tag")
 
 	white.Println("\n  Other:")
+	fmt.Println("    stats [--json]                Show library statistics")
+	fmt.Println("    encrypt                       Encrypt the snippet store with a passphrase")
+	fmt.Println("    decrypt [--yes]               Store snippets unencrypted again")
+	fmt.Println("    capture --history [n]         Save recent shell commands as snippets")
+	fmt.Println("    harvest <dir>... [--dry-run]  Sync snippets with snip:begin regions in files")
+	fmt.Println("    pick [--query q] [--id]       Pick a snippet inline and print its code")
+	fmt.Println("    launcher list|select          List snippets for dmenu, rofi or Alfred")
+	fmt.Println("    library list|add|use|remove   Manage named snippet libraries")
+	fmt.Println("    config get|set|list|edit|path Read and change the configuration")
+	fmt.Println("    import <format> <file>...     Import snippets (vscode, ultisnips, yasnippet, markdown, pet, navi)")
+	fmt.Println("    export <format> [--out f]     Export snippets (vscode, ultisnips, yasnippet, pet, navi, site)")
 	fmt.Println("    help [topic]                  Show help for a specific topic")
 
+	cyan.Println("\nGLOBAL FLAGS")
+	fmt.Println("  --library <name>                Use a named library for this command")
+	fmt.Println("  --config <file>                 Use another config file for this command")
+
 	cyan.Println("\nEXAMPLES")
 	fmt.Println("  snip snippet create                       # Interactive snippet creation")
 	fmt.Println("  snip snippet list --language go           # List all Go snippets")
 	fmt.Println("  snip snippet list --category 1            # List snippets in category 1")
 	fmt.Println("  snip snippet search \"quicksort\"           # Search for 'quicksort'")
 	fmt.Println("  snip category create algorithms           # Create 'algorithms' category")
+	fmt.Println("  snip category create backend/db/postgres  # Create a nested category path")
 	fmt.Println("  snip tag create                           # Interactive tag creation")
+	fmt.Println("  snip stats --json                         # Library statistics as JSON")
 
 	gray.Println("\nFor more information on a specific command, use: snip help <topic>")
 	fmt.Println()
@@ -90,16 +134,33 @@ func (hc *HelpCommand) printGeneralHelp(cyan, white, gray *color.Color) {
 func (hc *HelpCommand) printSnippetHelp(cyan, white, gray *color.Color) {
 	cyan.Println("\nSNIPPET COMMANDS")
 
-	white.Println("\n  snippet create")
-	fmt.Println("    Create a new code snippet using an interactive form.")
-	gray.Println("    Usage: snip snippet create")
+	white.Println("\n  snippet create [--file <path> | --from <path[:start-end]>] [--layer global|project]")
+	fmt.Println("    Create a new code snippet using an interactive form ('add' is an alias).")
+	fmt.Println("    The language is detected from the file extension, a shebang line or the code;")
+	fmt.Println("    low-confidence guesses are confirmed before saving. --from takes a line")
+	fmt.Println("    range of a file, removes its common indentation, and records the file and")
+	fmt.Println("    range as the snippet's source.")
+	fmt.Println("    Inside a project with a .snip directory (found in the working directory or")
+	fmt.Println("    a parent), its library is stacked over the global one and lists show each")
+	fmt.Println("    snippet's origin. --layer project saves the snippet in the project library,")
+	fmt.Println("    copying its category and tags there.")
+	gray.Println("    Usage: snip snippet create [--file <path> | --from <path[:start-end]>] [--layer global|project]")
+	gray.Println("    Examples:")
+	gray.Println("      snip snippet create")
+	gray.Println("      snip snippet create --file scripts/backup.sh")
+	gray.Println("      snip snippet add --from internal/server/handler.go:40-72")
+	gray.Println("      snip snippet add --from Makefile:12-20 --layer project")
 
 	white.Println("\n  snippet list [--flags]")
 	fmt.Println("    List all snippets or filter by category, tag, or language.")
-	gray.Println("    Usage: snip snippet list [--category <id>] [--tag <id>] [--language <lang>]")
+	fmt.Println("    Filtering by category includes snippets in its subcategories.")
+	fmt.Println("    Pinned snippets are listed first.")
+	gray.Println("    Usage: snip snippet list [--category <id|path>] [--tag <id>] [--language <lang>]")
+	gray.Println("                             [--favorites] [--pinned]")
 	gray.Println("    Examples:")
 	gray.Println("      snip snippet list")
 	gray.Println("      snip snippet list --category 1")
+	gray.Println("      snip snippet list --category backend/db")
 	gray.Println("      snip snippet list --language python")
 
 	white.Println("\n  snippet show <id>")
@@ -122,25 +183,133 @@ func (hc *HelpCommand) printSnippetHelp(cyan, white, gray *color.Color) {
 	gray.Println("    Usage: snip snippet search <query>")
 	gray.Println("    Example: snip snippet search \"binary tree\"")
 
+	white.Println("\n  snippet copy <id>")
+	fmt.Println("    Copy a snippet's code to the system clipboard and record the use.")
+	gray.Println("    Usage: snip snippet copy <id>")
+	gray.Println("    Example: snip snippet copy 5")
+
+	white.Println("\n  snippet insert <id> --into <file> --at <line>|--after <regex>")
+	fmt.Println("    Insert a snippet into a file before line --at, or after the first line")
+	fmt.Println("    matching --after. Placeholders take --set values or are asked for (Enter")
+	fmt.Println("    keeps the default). The code is indented like the surrounding lines, a")
+	fmt.Println("    diff is shown for confirmation, and the file is replaced atomically.")
+	fmt.Println("    --yes skips the questions and uses defaults; 'snip insert' is a shortcut.")
+	gray.Println("    Usage: snip insert <id> --into <file> --at <line>|--after <regex> [--set name=value]... [--yes]")
+	gray.Println("    Examples:")
+	gray.Println("      snip insert 5 --into main.go --after '^func main'")
+	gray.Println("      snip insert 12 --into handler.go --at 40 --set ret=nil --yes")
+
+	white.Println("\n  snippet run <id> [--timeout <seconds>] [-- args...]")
+	fmt.Println("    Run a snippet in a temporary directory using the runner configured for its")
+	fmt.Println("    language under \"runners\" in ~/.snip/config.json (bash, python3, go run, ...).")
+	fmt.Println("    Arguments after -- are passed to the snippet. The run is killed after")
+	fmt.Println("    \"run_timeout_seconds\" (default 10) unless --timeout is given.")
+	gray.Println("    Usage: snip snippet run <id> [--timeout <seconds>] [-- args...]")
+	gray.Println("    Examples:")
+	gray.Println("      snip snippet run 5")
+	gray.Println("      snip snippet run 5 --timeout 30 -- input.txt")
+
+	white.Println("\n  snippet favorite|unfavorite <id>")
+	fmt.Println("    Mark or unmark a snippet as a favorite.")
+	gray.Println("    Usage: snip snippet favorite <id>")
+	gray.Println("    Example: snip snippet unfavorite 5")
+
+	white.Println("\n  snippet pin|unpin <id>")
+	fmt.Println("    Pin a snippet so it is listed first, or unpin it.")
+	gray.Println("    Usage: snip snippet pin <id>")
+	gray.Println("    Example: snip snippet pin 5")
+
+	white.Println("\n  snippet fork <id>")
+	fmt.Println("    Copy a snippet into your personal library, with its category and tags.")
+	fmt.Println("    Snippets of the team library (team_library in the config) are read-only:")
+	fmt.Println("    fork one to edit, pin or delete your own copy.")
+	gray.Println("    Usage: snip snippet fork <id>")
+	gray.Println("    Example: snip snippet fork 1000004")
+
+	white.Println("\n  snippet recent [n]")
+	fmt.Println("    List the n most recently shown or copied snippets (default 10).")
+	gray.Println("    Usage: snip snippet recent [n]")
+	gray.Println("    Example: snip snippet recent 5")
+
+	white.Println("\n  snippet top [n]")
+	fmt.Println("    List the n most used snippets by views and copies (default 10).")
+	gray.Println("    Usage: snip snippet top [n]")
+	gray.Println("    Example: snip snippet top 5")
+
+	white.Println("\n  snippet dedupe [--flags]")
+	fmt.Println("    Find snippets with identical or near-identical code, ignoring whitespace,")
+	fmt.Println("    line endings and trivial formatting. Each group is shown side by side and")
+	fmt.Println("    you choose which snippet to keep; the others are merged into it, combining")
+	fmt.Println("    tags and keeping the richer description, and then deleted.")
+	fmt.Println("    Creating a snippet warns when identical code already exists.")
+	gray.Println("    Usage: snip snippet dedupe [--exact] [--threshold <0-1>] [--dry-run]")
+	gray.Println("    Examples:")
+	gray.Println("      snip snippet dedupe")
+	gray.Println("      snip snippet dedupe --exact")
+	gray.Println("      snip snippet dedupe --threshold 0.9 --dry-run")
+
+	white.Println("\n  snippet bulk <action> <selection> [--yes]")
+	fmt.Println("    Apply one change to many snippets. Select snippets by IDs and ranges")
+	fmt.Println("    (\"1 4 7-9\" or \"1,4,7-9\") or with --query <text>. The affected snippets")
+	fmt.Println("    are previewed and you confirm once; --yes skips the confirmation.")
+	fmt.Println("    A range selects the snippets within it; single IDs must exist.")
+	fmt.Println("    The change is atomic: if any snippet cannot be changed, none are.")
+	gray.Println("    Actions:")
+	gray.Println("      tag add <tag> <selection>                  Add a tag (ID or name)")
+	gray.Println("      tag remove <tag> <selection>               Remove a tag")
+	gray.Println("      move --category <id|path|none> <selection> Move to a category")
+	gray.Println("      set-language <language> <selection>        Change the language")
+	gray.Println("      delete <selection>                         Delete the snippets")
+	gray.Println("    Examples:")
+	gray.Println("      snip snippet bulk tag add web 3 5-9")
+	gray.Println("      snip snippet bulk move --category backend/http --query http")
+	gray.Println("      snip snippet bulk set-language typescript 12,14")
+	gray.Println("      snip snippet bulk delete 20-25 --yes")
+
+	white.Println("\n  snippet scan [ids] [--redact] [--yes]")
+	fmt.Println("    Audit snippets for credentials: API keys and tokens in well-known formats,")
+	fmt.Println("    private keys, passwords in URLs and assignments, and high-entropy strings.")
+	fmt.Println("    Findings are listed with their line and a masked value. With --redact the")
+	fmt.Println("    secrets are replaced with placeholders such as <GITHUB_TOKEN> after")
+	fmt.Println("    confirmation; --yes skips the confirmation.")
+	fmt.Println("    Creating or updating a snippet runs the same check and offers to replace")
+	fmt.Println("    the secrets before saving.")
+	gray.Println("    Usage: snip snippet scan [ids] [--redact] [--yes]")
+	gray.Println("    Examples:")
+	gray.Println("      snip snippet scan")
+	gray.Println("      snip snippet scan 3 7-9")
+	gray.Println("      snip snippet scan --redact")
+
 	fmt.Println()
 }
 
 func (hc *HelpCommand) printCategoryHelp(cyan, white, gray *color.Color) {
 	cyan.Println("\nCATEGORY COMMANDS")
 
-	white.Println("\n  category create [name]")
+	white.Println("\n  category create [name] [--parent <id|path>]")
 	fmt.Println("    Create a new category. Provides interactive prompt if name not given.")
-	gray.Println("    Usage: snip category create [name]")
+	fmt.Println("    A name like 'backend/db/postgres' creates any missing parent categories.")
+	gray.Println("    Usage: snip category create [name] [--parent <id|path>]")
 	gray.Println("    Examples:")
 	gray.Println("      snip category create algorithms")
+	gray.Println("      snip category create backend/db/postgres")
+	gray.Println("      snip category create redis --parent backend/db")
 	gray.Println("      snip category create              # Interactive mode")
 
 	white.Println("\n  category list")
-	fmt.Println("    Display all available categories.")
+	fmt.Println("    Display all available categories as a tree.")
 	gray.Println("    Usage: snip category list")
 
+	white.Println("\n  category move <id> <parent|none>")
+	fmt.Println("    Move a category under another category, given by ID or path.")
+	fmt.Println("    Use 'none' to make it a top-level category. Cycles are rejected.")
+	gray.Println("    Usage: snip category move <id> <parent|none>")
+	gray.Println("    Examples:")
+	gray.Println("      snip category move 4 backend/db")
+	gray.Println("      snip category move 4 none")
+
 	white.Println("\n  category delete <id>")
-	fmt.Println("    Delete a category after confirmation.")
+	fmt.Println("    Delete a category after confirmation. Subcategories move up one level.")
 	gray.Println("    Usage: snip category delete <id>")
 	gray.Println("    Example: snip category delete 3")
 
@@ -168,3 +337,284 @@ func (hc *HelpCommand) printTagHelp(cyan, white, gray *color.Color) {
 
 	fmt.Println()
 }
+
+func (hc *HelpCommand) printStatsHelp(cyan, white, gray *color.Color) {
+	cyan.Println("\nSTATS COMMAND")
+
+	white.Println("\n  stats [--json] [--recent <n>]")
+	fmt.Println("    Show library statistics: snippet counts per language, category and tag,")
+	fmt.Println("    total lines of code, recently added and updated snippets, untagged and")
+	fmt.Println("    uncategorized snippets, and references to deleted categories or tags.")
+	fmt.Println("    --json prints the report as JSON, --recent sets the number of recent")
+	fmt.Println("    snippets listed (default 5).")
+	gray.Println("    Usage: snip stats [--json] [--recent <n>]")
+	gray.Println("    Examples:")
+	gray.Println("      snip stats")
+	gray.Println("      snip stats --json")
+	gray.Println("      snip stats --recent 10")
+
+	fmt.Println()
+}
+
+func (hc *HelpCommand) printEncryptionHelp(cyan, white, gray *color.Color) {
+	cyan.Println("\nENCRYPTION COMMANDS")
+
+	white.Println("\n  encrypt")
+	fmt.Println("    Encrypt the snippet store with a passphrase. The file is sealed with")
+	fmt.Println("    AES-256-GCM using a key derived with PBKDF2-SHA256, and written with mode")
+	fmt.Println("    0600. snip asks for the passphrase on start, or reads it from")
+	fmt.Println("    SNIP_PASSPHRASE. A modified file or wrong passphrase is refused.")
+	fmt.Println("    Running encrypt on an encrypted store changes the passphrase.")
+	gray.Println("    Usage: snip encrypt")
+	gray.Println("    Examples:")
+	gray.Println("      snip encrypt")
+	gray.Println("      SNIP_PASSPHRASE=... snip snippet list")
+
+	white.Println("\n  decrypt [--yes]")
+	fmt.Println("    Store the snippets as plain JSON again after confirmation.")
+	fmt.Println("    --yes skips the confirmation.")
+	gray.Println("    Usage: snip decrypt [--yes]")
+	gray.Println("    Example: snip decrypt")
+
+	fmt.Println()
+}
+
+func (hc *HelpCommand) printExchangeHelp(cyan, white, gray *color.Color) {
+	cyan.Println("\nIMPORT AND EXPORT COMMANDS")
+
+	white.Println("\n  import vscode <file>... [--dry-run]")
+	fmt.Println("    Import VS Code snippet files: global .code-snippets files, or language")
+	fmt.Println("    files such as go.json. Entry names become titles, prefixes become aliases,")
+	fmt.Println("    the scope sets the language, and tab stops such as ${1:name} become")
+	fmt.Println("    {{1:name}} placeholders. Snippets whose code already exists are skipped.")
+	fmt.Println("    Entries that can't be imported, such as empty ones, are skipped with a")
+	fmt.Println("    warning. Directories are searched for .code-snippets files and .json")
+	fmt.Println("    snippet files. --dry-run lists the snippets without importing them.")
+	gray.Println("    Usage: snip import vscode <file>... [--dry-run]")
+	gray.Println("    Examples:")
+	gray.Println("      snip import vscode .vscode/team.code-snippets")
+	gray.Println("      snip import vscode ~/.config/Code/User/snippets/go.json --dry-run")
+
+	white.Println("\n  export vscode [--out <file>] [filters]")
+	fmt.Println("    Write snippets as a VS Code .code-snippets file, to --out or stdout.")
+	fmt.Println("    Aliases become prefixes (the title is used if there are none) and")
+	fmt.Println("    placeholders become tab stops. Filter with --language, --category")
+	fmt.Println("    <id|path> and --tag <id|name>; filters can be combined.")
+	gray.Println("    Usage: snip export vscode [--out <file>] [--language <lang>] [--category <id|path>] [--tag <id|name>]")
+	gray.Println("    Examples:")
+	gray.Println("      snip export vscode --out .vscode/snip.code-snippets")
+	gray.Println("      snip export vscode --language go --tag web")
+
+	white.Println("\n  import ultisnips|yasnippet <file|dir>... [--dry-run]")
+	fmt.Println("    Import Vim UltiSnips .snippets files or Emacs yasnippet files.")
+	fmt.Println("    Directories are searched for snippet files. Triggers and keys become")
+	fmt.Println("    aliases and descriptions or names become titles. UltiSnips files set the")
+	fmt.Println("    language by file type (go.snippets, sh_extra.snippets); yasnippet files")
+	fmt.Println("    by their mode directory (go-mode/, sh-mode/). Interpolated code such as")
+	fmt.Println("    `!p snip.rv` or `(elisp)` becomes a placeholder named after its language.")
+	gray.Println("    Usage: snip import ultisnips|yasnippet <file|dir>... [--dry-run]")
+	gray.Println("    Examples:")
+	gray.Println("      snip import ultisnips ~/.vim/UltiSnips")
+	gray.Println("      snip import yasnippet ~/.emacs.d/snippets/go-mode --dry-run")
+
+	white.Println("\n  import markdown <file|dir>... [--all] [--dry-run]")
+	fmt.Println("    Import fenced code blocks from Markdown documents (.md, .markdown).")
+	fmt.Println("    The info string sets the language, the nearest heading the title, and")
+	fmt.Println("    the paragraph before the block (or after it) the description. The file")
+	fmt.Println("    and heading are recorded as the snippet's source. A selector lets you")
+	fmt.Println("    choose the blocks to import; --all imports every block without asking.")
+	gray.Println("    Usage: snip import markdown <file|dir>... [--all] [--dry-run]")
+	gray.Println("    Examples:")
+	gray.Println("      snip import markdown docs/")
+	gray.Println("      snip import markdown README.md --all")
+
+	white.Println("\n  export ultisnips|yasnippet --out <dir> [filters]")
+	fmt.Println("    Write snippets to a directory: one <filetype>.snippets file per language")
+	fmt.Println("    for UltiSnips, or one <mode>/<key> file per snippet for yasnippet. The")
+	fmt.Println("    first alias becomes the trigger or key. Filters are as for vscode.")
+	gray.Println("    Usage: snip export ultisnips|yasnippet --out <dir> [--language <lang>] [--category <id|path>] [--tag <id|name>]")
+	gray.Println("    Examples:")
+	gray.Println("      snip export ultisnips --out ~/.vim/UltiSnips")
+	gray.Println("      snip export yasnippet --out ~/.emacs.d/snippets --language python")
+
+	white.Println("\n  import pet|navi <file|dir>... [--dry-run]")
+	fmt.Println("    Import command snippets from pet (snippet.toml) or navi (.cheat files).")
+	fmt.Println("    Snippets are imported as bash. Pet descriptions and navi # lines become")
+	fmt.Println("    titles, and navi ; comments descriptions. Pet tags and the tags of navi")
+	fmt.Println("    % lines are added to the snippets, creating tags as needed. <name> and")
+	fmt.Println("    <name=default> parameters become {{name}} placeholders; a navi variable")
+	fmt.Println("    whose $ line just echoes a value gets it as default.")
+	gray.Println("    Usage: snip import pet|navi <file|dir>... [--dry-run]")
+	gray.Println("    Examples:")
+	gray.Println("      snip import pet ~/.config/pet/snippet.toml")
+	gray.Println("      snip import navi ~/.local/share/navi/cheats --dry-run")
+
+	white.Println("\n  export pet|navi [--out <file>] [filters]")
+	fmt.Println("    Write snippets as a pet snippet file or a navi cheat sheet, to --out or")
+	fmt.Println("    stdout. Placeholders become <name> parameters; pet keeps defaults as")
+	fmt.Println("    <name=default> and navi as $ name: echo 'default' lines. Navi sections")
+	fmt.Println("    are tagged with the category path and tag names. Filters are as for vscode.")
+	gray.Println("    Usage: snip export pet|navi [--out <file>] [--language <lang>] [--category <id|path>] [--tag <id|name>]")
+	gray.Println("    Examples:")
+	gray.Println("      snip export pet --language bash --out ~/.config/pet/snippet.toml")
+	gray.Println("      snip export navi --tag docker --out ~/.local/share/navi/cheats/snip.cheat")
+
+	white.Println("\n  export site --out <dir> [--format html|markdown] [filters]")
+	fmt.Println("    Publish the library as a static site: an index page with client-side")
+	fmt.Println("    search, a page per snippet with highlighted code, and index pages per")
+	fmt.Println("    category and tag. The site works from the file system or any web server.")
+	fmt.Println("    --format markdown writes one cheat sheet per category and a README.md.")
+	gray.Println("    Usage: snip export site --out <dir> [--format html|markdown] [--language <lang>] [--category <id|path>] [--tag <id|name>]")
+	gray.Println("    Examples:")
+	gray.Println("      snip export site --out public/snippets")
+	gray.Println("      snip export site --out docs/cheatsheets --format markdown --category backend")
+
+	fmt.Println()
+}
+
+func (hc *HelpCommand) printCaptureHelp(cyan, white, gray *color.Color) {
+	cyan.Println("\nCAPTURE COMMANDS")
+
+	white.Println("\n  capture --history [n] [--file <history file>]")
+	fmt.Println("    Pick commands from your shell history and save them as snippets. The")
+	fmt.Println("    n most recent distinct commands (50 by default) are listed, newest first;")
+	fmt.Println("    none are selected until you choose them. The history file is $HISTFILE,")
+	fmt.Println("    or ~/.zsh_history or ~/.bash_history depending on $SHELL. Commands become")
+	fmt.Println("    bash or zsh snippets titled by their first line, with the history file as")
+	fmt.Println("    source. Existing code is skipped and secrets are checked before saving.")
+	gray.Println("    Usage: snip capture --history [n] [--file <history file>]")
+	gray.Println("    Examples:")
+	gray.Println("      snip capture --history")
+	gray.Println("      snip capture --history 200 --file ~/.zsh_history")
+
+	white.Println("\n  harvest <dir|file>... [--dry-run]")
+	fmt.Println("    Keep snippets in sync with marked regions of source files. A region runs")
+	fmt.Println("    from a '// snip:begin <name> [title]' line to a '// snip:end' line; any")
+	fmt.Println("    comment leader (#, --, <!--, ...) works and regions may nest. Each region")
+	fmt.Println("    is matched to a snippet by name: new regions are created (with the name")
+	fmt.Println("    as alias), changed regions updated, moved ones get their new lines as")
+	fmt.Println("    source. Harvested snippets whose region or file is gone are reported as")
+	fmt.Println("    missing but kept. --dry-run shows the changes without saving them.")
+	fmt.Println("    Hidden and dependency directories (node_modules, vendor, dist, ...) and")
+	fmt.Println("    files over 1 MiB are skipped; unreadable files are reported as errors.")
+	gray.Println("    Usage: snip harvest <dir|file>... [--dry-run]")
+	gray.Println("    Examples:")
+	gray.Println("      snip harvest ~/src/reference-impls")
+	gray.Println("      snip harvest internal/retry --dry-run")
+
+	fmt.Println()
+}
+
+func (hc *HelpCommand) printPickHelp(cyan, white, gray *color.Color) {
+	cyan.Println("\nPICK COMMANDS")
+
+	white.Println("\n  pick [--query <text>] [--language <lang>] [--id]")
+	fmt.Println("    Choose a snippet in a compact picker drawn below the prompt and print its")
+	fmt.Println("    code, or its ID with --id. Type to search; pinned and recently used")
+	fmt.Println("    snippets come first and the code under the cursor is previewed. Enter")
+	fmt.Println("    picks, Esc cancels. The picker draws on stderr, so the result can be")
+	fmt.Println("    captured with $(snip pick).")
+	gray.Println("    Usage: snip pick [--query <text>] [--language <lang>] [--id]")
+	gray.Println("    Examples:")
+	gray.Println("      snip pick --language bash")
+	gray.Println("      snip snippet show $(snip pick --id)")
+
+	white.Println("\n  pick --init bash|zsh|fish")
+	fmt.Println("    Print a key binding for your shell: Ctrl+S opens the picker and inserts")
+	fmt.Println("    the chosen snippet at the cursor. Add the line below to your shell rc.")
+	gray.Println("    Usage: snip pick --init bash|zsh|fish")
+	gray.Println("    Examples:")
+	gray.Println("      eval \"$(snip pick --init bash)\"    # ~/.bashrc")
+	gray.Println("      eval \"$(snip pick --init zsh)\"     # ~/.zshrc")
+	gray.Println("      snip pick --init fish | source      # ~/.config/fish/config.fish")
+
+	white.Println("\n  launcher list [--format dmenu|rofi|alfred-json] [--language <lang>]")
+	fmt.Println("    List snippets for an application launcher, pinned and recently used")
+	fmt.Println("    first. dmenu gets lines such as '12: Download with curl [bash] #http',")
+	fmt.Println("    rofi the same lines with the ID as info and aliases as hidden search text,")
+	fmt.Println("    and alfred-json a script filter document whose items pass on the ID.")
+	gray.Println("    Usage: snip launcher list [--format dmenu|rofi|alfred-json] [--language <lang>]")
+
+	white.Println("\n  launcher select <entry> [--print]")
+	fmt.Println("    Resolve the entry chosen in a launcher back to its snippet and copy its")
+	fmt.Println("    code to the clipboard, or print it with --print. The entry may be a")
+	fmt.Println("    listed line, an ID, an alias or a title; an empty entry does nothing.")
+	fmt.Println("    Messages go to stderr, so the output can be piped safely.")
+	gray.Println("    Usage: snip launcher select <entry> [--print]")
+	gray.Println("    Examples:")
+	gray.Println("      snip launcher select \"$(snip launcher list | dmenu -i -l 15)\"")
+	gray.Println("      snip launcher select \"$(snip launcher list --format rofi | rofi -dmenu -p snip)\"")
+	gray.Println("      snip launcher select \"{query}\" --print    # Alfred Run Script action")
+
+	fmt.Println()
+}
+
+func (hc *HelpCommand) printLibraryHelp(cyan, white, gray *color.Color) {
+	cyan.Println("\nLIBRARY COMMANDS")
+	fmt.Println("  The library at the configured storage_path is named 'default'. Any other")
+	fmt.Println("  library is a separate snippets file. --library <name> uses one for a single")
+	fmt.Println("  command, and Ctrl+L in the TUI switches to the next library.")
+
+	white.Println("\n  library list")
+	fmt.Println("    List the libraries with their backend and storage path; '*' marks the")
+	fmt.Println("    current one.")
+	gray.Println("    Usage: snip library list")
+
+	white.Println("\n  library add <name> [path] [--backend json]")
+	fmt.Println("    Add a named library. Without a path it is stored in libraries/<name>.json")
+	fmt.Println("    next to the default library. json is the only backend so far.")
+	gray.Println("    Usage: snip library add <name> [path] [--backend json]")
+	gray.Println("    Examples:")
+	gray.Println("      snip library add work")
+	gray.Println("      snip library add team ~/Dropbox/snip/team.json")
+
+	white.Println("\n  library use <name>")
+	fmt.Println("    Make a library the current one for later commands.")
+	gray.Println("    Usage: snip library use <name>")
+	gray.Println("    Examples:")
+	gray.Println("      snip library use work")
+	gray.Println("      snip --library default snippet list")
+
+	white.Println("\n  library remove <name>")
+	fmt.Println("    Remove a library from the configuration. Its snippets file is kept, and")
+	fmt.Println("    the default library cannot be removed.")
+	gray.Println("    Usage: snip library remove <name>")
+
+	fmt.Println()
+}
+
+func (hc *HelpCommand) printConfigHelp(cyan, white, gray *color.Color) {
+	cyan.Println("\nCONFIG COMMANDS")
+	fmt.Println("  The config file is ~/.snip/config.json. On Linux new installs follow the")
+	fmt.Println("  XDG base directories instead: $XDG_CONFIG_HOME/snip/config.json, with")
+	fmt.Println("  snippets in $XDG_DATA_HOME/snip. SNIP_CONFIG names another config file and")
+	fmt.Println("  SNIP_STORAGE_PATH overrides storage_path without changing the file.")
+
+	white.Println("\n  config list")
+	fmt.Println("    List every key with its value and a description.")
+	gray.Println("    Usage: snip config list")
+
+	white.Println("\n  config get <key>")
+	fmt.Println("    Print the value of a key. Maps such as runners are printed as JSON.")
+	gray.Println("    Usage: snip config get <key>")
+
+	white.Println("\n  config set <key> <value>")
+	fmt.Println("    Change a key and save the file. Unknown keys and bad values are rejected.")
+	fmt.Println("    Maps such as runners and formatters are changed with 'snip config edit'.")
+	gray.Println("    Usage: snip config set <key> <value>")
+	gray.Println("    Examples:")
+	gray.Println("      snip config set run_timeout_seconds 30")
+	gray.Println("      snip config set team_library ~/team/snippets.json")
+
+	white.Println("\n  config edit")
+	fmt.Println("    Open the file in $VISUAL or $EDITOR. It is checked before it is saved,")
+	fmt.Println("    and can be edited again if it is invalid.")
+	gray.Println("    Usage: snip config edit")
+
+	white.Println("\n  config path")
+	fmt.Println("    Print the path of the config file.")
+	gray.Println("    Usage: snip config path")
+	gray.Println("    Example: snip --config ./ci.json config path")
+
+	fmt.Println()
+}
@@ -61,6 +61,28 @@ func TestHelpCommand_Print(t *testing.T) {
 		hc.Print("tag")
 	})
 
+	t.Run("prints stats help", func(t *testing.T) {
+		// Should not panic
+		hc.Print("stats")
+	})
+
+	t.Run("prints encryption help", func(t *testing.T) {
+		// Should not panic
+		hc.Print("encryption")
+	})
+
+	t.Run("prints import and export help", func(t *testing.T) {
+		// Should not panic
+		hc.Print("import")
+		hc.Print("capture")
+		hc.Print("harvest")
+		hc.Print("pick")
+		hc.Print("launcher")
+		hc.Print("library")
+		hc.Print("config")
+		hc.Print("export")
+	})
+
 	t.Run("handles unknown topic", func(t *testing.T) {
 		// Should show error, not panic
 		hc.Print("unknown")
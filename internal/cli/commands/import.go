@@ -0,0 +1,301 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/7-Dany/snip/internal/exchange"
+	"github.com/7-Dany/snip/internal/storage"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// importUsage is shown when import arguments are malformed.
+const importUsage = "snip import vscode|ultisnips|yasnippet|markdown|pet|navi <file|dir>... [--dry-run] [--all]"
+
+// ImportCommand handles importing snippets from other tools' files.
+type ImportCommand struct {
+	repos *storage.Repositories
+}
+
+// NewImportCommand creates a new ImportCommand instance.
+func NewImportCommand(repos *storage.Repositories) *ImportCommand {
+	return &ImportCommand{repos: repos}
+}
+
+// manage routes import formats to the appropriate parser.
+func (ic *ImportCommand) manage(args []string) {
+	if len(args) == 0 {
+		PrintError(fmt.Sprintf("Missing format. Use '%s'", importUsage))
+		return
+	}
+
+	switch args[0] {
+	case "vscode":
+		ic.importFiles(args[1:], untagged(exchange.ParseVSCode), isVSCodeFile, nil)
+	case "ultisnips":
+		ic.importFiles(args[1:], untagged(exchange.ParseUltiSnips), isUltiSnipsFile, nil)
+	case "yasnippet":
+		ic.importFiles(args[1:], untagged(exchange.ParseYasnippet), isYasnippetFile, nil)
+	case "markdown":
+		ic.importFiles(args[1:], untagged(exchange.ParseMarkdown), isMarkdownFile, pickSnippets)
+	case "pet":
+		ic.importFiles(args[1:], exchange.ParsePet, isPetFile, nil)
+	case "navi":
+		ic.importFiles(args[1:], exchange.ParseNavi, isNaviFile, nil)
+	default:
+		PrintError(fmt.Sprintf("Unknown import format '%s'. Use 'snip help import' for available formats", args[0]))
+	}
+}
+
+// untagged adapts a parser of a format without tags to importFiles. The
+// snippets are passed on along with an error, such as *exchange.SkippedError.
+func untagged(parse func(filename string, data []byte) ([]*domain.Snippet, error)) func(string, []byte) ([]exchange.Imported, error) {
+	return func(filename string, data []byte) ([]exchange.Imported, error) {
+		snippets, err := parse(filename, data)
+		imported := make([]exchange.Imported, len(snippets))
+		for i, snippet := range snippets {
+			imported[i] = exchange.Imported{Snippet: snippet}
+		}
+		return imported, err
+	}
+}
+
+// importFiles parses each file with parse and creates the snippets, along
+// with any of their tags that do not exist yet. Directories are searched
+// for files accepted by match. If pick is not nil,
+// the user chooses which snippets to import unless --all or --dry-run is
+// given. Snippets whose code already exists in the library are skipped.
+// With --dry-run the snippets are only listed.
+func (ic *ImportCommand) importFiles(args []string, parse func(filename string, data []byte) ([]exchange.Imported, error), match func(path string) bool, pick func([]*domain.Snippet) ([]*domain.Snippet, bool)) {
+	dryRun, all := false, false
+	var files []string
+	for _, arg := range args {
+		switch {
+		case arg == "--dry-run":
+			dryRun = true
+		case arg == "--all" && pick != nil:
+			all = true
+		case strings.HasPrefix(arg, "-"):
+			PrintError(fmt.Sprintf("Unknown flag '%s'. Use '%s'", arg, importUsage))
+			return
+		default:
+			files = append(files, arg)
+		}
+	}
+	if len(files) == 0 {
+		PrintError(fmt.Sprintf("Missing file. Use '%s'", importUsage))
+		return
+	}
+
+	files, err := expandFiles(files, match)
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to read directory: %v", err))
+		return
+	}
+
+	var entries []exchange.Imported
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			PrintError(fmt.Sprintf("Failed to read file: %v", err))
+			return
+		}
+		parsed, err := parse(file, data)
+		var skipped *exchange.SkippedError
+		if errors.As(err, &skipped) {
+			PrintInfo(fmt.Sprintf("⚠ %s: %v", file, err))
+		} else if err != nil {
+			PrintError(fmt.Sprintf("Failed to parse %s: %v", file, err))
+			return
+		}
+		entries = append(entries, parsed...)
+	}
+
+	if pick != nil && !all && !dryRun && len(entries) > 0 {
+		snippets := make([]*domain.Snippet, len(entries))
+		for i, entry := range entries {
+			snippets[i] = entry.Snippet
+		}
+		picked, ok := pick(snippets)
+		if !ok {
+			PrintInfo("Import cancelled")
+			return
+		}
+		if len(picked) == 0 {
+			PrintInfo("No snippets selected")
+			return
+		}
+		entries = slices.DeleteFunc(entries, func(entry exchange.Imported) bool {
+			return !slices.Contains(picked, entry.Snippet)
+		})
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"ID", "Title", "Language", "Aliases", "Tags", "Status"})
+
+	imported, skipped := 0, 0
+	var added []*domain.Snippet
+	for _, entry := range entries {
+		snippet := entry.Snippet
+		duplicates, _ := ic.repos.Snippets.FindDuplicates(snippet.Code(), snippet.Language())
+		status := "new"
+		switch {
+		case len(duplicates) > 0:
+			status = fmt.Sprintf("duplicate of %d", duplicates[0].ID())
+			skipped++
+		case !dryRun:
+			if err := ic.tagSnippet(snippet, entry.Tags); err != nil {
+				PrintError(fmt.Sprintf("Failed to tag snippet '%s': %v", snippet.Title(), err))
+				return
+			}
+			if err := ic.repos.Snippets.Create(snippet); err != nil {
+				PrintError(fmt.Sprintf("Failed to save snippet '%s': %v", snippet.Title(), err))
+				return
+			}
+			status = "imported"
+			imported++
+		default:
+			imported++
+		}
+		if len(duplicates) == 0 {
+			added = append(added, snippet)
+		}
+
+		id := "-"
+		if snippet.ID() > 0 {
+			id = fmt.Sprint(snippet.ID())
+		}
+		t.AppendRow(table.Row{id, snippet.Title(), snippet.Language(), strings.Join(snippet.Aliases(), ", "), strings.Join(entry.Tags, ", "), status})
+	}
+
+	if len(entries) == 0 {
+		PrintInfo("No snippets found")
+		return
+	}
+
+	t.SetStyle(table.StyleColoredBright)
+	t.Render()
+	warnSecrets(added)
+
+	if dryRun {
+		PrintInfo(fmt.Sprintf("Dry run: %d snippet(s) would be imported, %d duplicate(s) skipped", imported, skipped))
+		return
+	}
+	PrintSuccess(fmt.Sprintf("Imported %d snippet(s), skipped %d duplicate(s)", imported, skipped))
+}
+
+// tagSnippet adds the named tags to snippet, creating tags that do not
+// exist yet.
+func (ic *ImportCommand) tagSnippet(snippet *domain.Snippet, names []string) error {
+	for _, name := range names {
+		tag, err := ic.repos.Tags.FindByName(name)
+		if err != nil {
+			if tag, err = domain.NewTag(name); err != nil {
+				return err
+			}
+			if err := ic.repos.Tags.Create(tag); err != nil {
+				return err
+			}
+		}
+		snippet.AddTag(tag.ID())
+	}
+	return nil
+}
+
+// vendoredDirs are directories of dependencies and build output, which
+// expandFiles skips.
+var vendoredDirs = map[string]bool{
+	"node_modules":     true,
+	"vendor":           true,
+	"bower_components": true,
+	"__pycache__":      true,
+	"dist":             true,
+}
+
+// expandFiles replaces directories in paths with the files below them that
+// match accepts, skipping hidden files and directories, and vendoredDirs.
+func expandFiles(paths []string, match func(path string) bool) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if file != path && strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if file != path && d.IsDir() && vendoredDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			if !d.IsDir() && match(file) {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// isVSCodeFile reports whether path is a VS Code snippet file: a
+// .code-snippets file, or a .json file in a snippets directory or shaped
+// like a snippet file, so that files such as package.json are left out.
+func isVSCodeFile(path string) bool {
+	switch filepath.Ext(path) {
+	case ".code-snippets":
+		return true
+	case ".json":
+		if filepath.Base(filepath.Dir(path)) == "snippets" {
+			return true
+		}
+		data, err := os.ReadFile(path)
+		return err == nil && exchange.IsVSCodeSnippets(data)
+	}
+	return false
+}
+
+// isUltiSnipsFile reports whether path is an UltiSnips snippet file.
+func isUltiSnipsFile(path string) bool {
+	return filepath.Ext(path) == ".snippets"
+}
+
+// isPetFile reports whether path is a pet snippet file.
+func isPetFile(path string) bool {
+	return filepath.Ext(path) == ".toml"
+}
+
+// isNaviFile reports whether path is a navi cheat sheet.
+func isNaviFile(path string) bool {
+	return filepath.Ext(path) == ".cheat"
+}
+
+// isMarkdownFile reports whether path is a Markdown document.
+func isMarkdownFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// isYasnippetFile reports whether path is a yasnippet snippet file, which
+// lives in a directory named after an Emacs mode.
+func isYasnippetFile(path string) bool {
+	return strings.HasSuffix(filepath.Base(filepath.Dir(path)), "-mode")
+}
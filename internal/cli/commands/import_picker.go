@@ -0,0 +1,115 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/cli/components"
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// snippetPickerModel is a Bubble Tea model for choosing which parsed or
+// captured snippets to save. Rows are numbered from 1 in the given order.
+type snippetPickerModel struct {
+	selector  components.SelectorView
+	count     int
+	cancelled bool
+}
+
+// newSnippetPickerModel creates a picker with every snippet selected if
+// selectAll is true, or none otherwise.
+func newSnippetPickerModel(title, subtitle string, snippets []*domain.Snippet, selectAll bool) snippetPickerModel {
+	selector := components.NewSelectorView(title, subtitle, true, 80, 24)
+
+	rows := make([]table.Row, len(snippets))
+	numbers := make([]int, len(snippets))
+	for i, snippet := range snippets {
+		numbers[i] = i + 1
+		name := fmt.Sprintf("%s (%s)", snippet.Title(), snippet.Language())
+		lines := strings.Count(snippet.Code(), "\n") + 1
+		rows[i] = table.Row{"", fmt.Sprint(i + 1), name, fmt.Sprint(lines)}
+	}
+	selector.SetRows(rows)
+	if selectAll {
+		selector.SetSelected(numbers)
+	}
+	selector.UpdateCheckboxDisplay()
+
+	return snippetPickerModel{selector: selector, count: len(snippets)}
+}
+
+// Init initializes the picker.
+func (m snippetPickerModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles selection keys and delegates the rest to the selector.
+func (m snippetPickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			return m, tea.Quit
+		case "esc", "ctrl+c":
+			m.cancelled = true
+			return m, tea.Quit
+		case "a":
+			numbers := make([]int, m.count)
+			for i := range numbers {
+				numbers[i] = i + 1
+			}
+			m.selector.SetSelected(numbers)
+			m.selector.UpdateCheckboxDisplay()
+			return m, nil
+		case "n":
+			m.selector.SetSelected(nil)
+			m.selector.UpdateCheckboxDisplay()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.selector, cmd = m.selector.Update(msg)
+	return m, cmd
+}
+
+// View renders the picker.
+func (m snippetPickerModel) View() string {
+	return "\n" + m.selector.View() + "\n"
+}
+
+// pickSnippets shows an interactive selector with every snippet selected
+// and returns the chosen snippets. It reports false if the selection was
+// cancelled.
+func pickSnippets(snippets []*domain.Snippet) ([]*domain.Snippet, bool) {
+	return runSnippetPicker(newSnippetPickerModel(
+		"📥 Import Snippets",
+		"Space toggles a block, a selects all. Columns: title (language) and line count.",
+		snippets,
+		true,
+	), snippets)
+}
+
+// runSnippetPicker runs a picker over snippets and returns the chosen ones.
+// It reports false if the selection was cancelled.
+func runSnippetPicker(picker snippetPickerModel, snippets []*domain.Snippet) ([]*domain.Snippet, bool) {
+	p := tea.NewProgram(picker)
+	finalModel, err := p.Run()
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to run selector: %v", err))
+		return nil, false
+	}
+
+	model := finalModel.(snippetPickerModel)
+	if model.cancelled {
+		return nil, false
+	}
+
+	var picked []*domain.Snippet
+	for _, number := range model.selector.GetSelectedIDs() {
+		picked = append(picked, snippets[number-1])
+	}
+	return picked, true
+}
@@ -0,0 +1,313 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/fatih/color"
+)
+
+// insertUsage is shown when insert arguments are malformed.
+const insertUsage = "snip insert <id> --into <file> --at <line>|--after <regex> [--set name=value]... [--yes]"
+
+// insertContext is the number of unchanged lines shown around an insertion.
+const insertContext = 3
+
+// insertOptions are the parsed arguments of insert.
+type insertOptions struct {
+	into   string
+	at     int
+	after  *regexp.Regexp
+	values map[string]string
+	yes    bool
+}
+
+// insert renders a snippet into a file: before line --at, or after the
+// first line matching --after. Placeholders take their --set values, or are
+// asked for; with --yes their defaults are used. The code is indented like
+// the insertion point, and after a diff preview and confirmation the file
+// is replaced atomically.
+func (sc *SnippetCommand) insert(args []string) {
+	opts, rest, ok := parseInsertArgs(args)
+	if !ok {
+		return
+	}
+	snippet := sc.snippetFromArgs(rest, insertUsage)
+	if snippet == nil {
+		return
+	}
+
+	info, err := os.Stat(opts.into)
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to read file: %v", err))
+		return
+	}
+	data, err := os.ReadFile(opts.into)
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to read file: %v", err))
+		return
+	}
+	lines, newline, trailing := splitLines(string(data))
+
+	at := opts.at
+	if opts.after != nil {
+		at = 0
+		for i, line := range lines {
+			if opts.after.MatchString(line) {
+				at = i + 2
+				break
+			}
+		}
+		if at == 0 {
+			PrintError(fmt.Sprintf("No line in %s matches '%s'", opts.into, opts.after))
+			return
+		}
+	}
+	if at < 1 || at > len(lines)+1 {
+		PrintError(fmt.Sprintf("Line %d is outside %s (%d lines)", at, opts.into, len(lines)))
+		return
+	}
+
+	values := opts.values
+	if !opts.yes {
+		values = promptPlaceholders(snippet.Code(), values)
+	}
+	code := domain.RenderPlaceholders(snippet.Code(), values)
+	inserted := indentCode(code, insertionIndent(lines, at))
+	if len(inserted) == 0 {
+		PrintError("The rendered snippet is empty")
+		return
+	}
+
+	updated := make([]string, 0, len(lines)+len(inserted))
+	updated = append(updated, lines[:at-1]...)
+	updated = append(updated, inserted...)
+	updated = append(updated, lines[at-1:]...)
+
+	printInsertDiff(opts.into, lines, inserted, at)
+	if !opts.yes {
+		fmt.Printf("Insert %d line(s) into %s? (y/n): ", len(inserted), opts.into)
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(strings.TrimSpace(response)) != "y" {
+			PrintInfo("Insert cancelled")
+			return
+		}
+	}
+
+	content := strings.Join(updated, newline)
+	if trailing || len(lines) == 0 {
+		content += newline
+	}
+	if err := writeFileAtomic(opts.into, []byte(content), info.Mode().Perm()); err != nil {
+		PrintError(fmt.Sprintf("Failed to write file: %v", err))
+		return
+	}
+
+	snippet.RecordCopy()
+	if err := sc.repos.RecordUsage(snippet); err != nil {
+		PrintError(fmt.Sprintf("Failed to record usage: %v", err))
+		return
+	}
+	PrintSuccess(fmt.Sprintf("Inserted '%s' into %s at line %d", snippet.Title(), opts.into, at))
+}
+
+// parseInsertArgs reads the insert flags and returns them with the
+// remaining arguments. It prints an error and reports false if they are
+// malformed.
+func parseInsertArgs(args []string) (insertOptions, []string, bool) {
+	opts := insertOptions{values: make(map[string]string)}
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		flag := args[i]
+		switch flag {
+		case "--yes", "-y":
+			opts.yes = true
+			continue
+		case "--into", "--at", "--after", "--set":
+		default:
+			if strings.HasPrefix(flag, "-") {
+				PrintError(fmt.Sprintf("Unknown flag '%s'. Use '%s'", flag, insertUsage))
+				return opts, nil, false
+			}
+			rest = append(rest, flag)
+			continue
+		}
+
+		if i+1 >= len(args) {
+			PrintError(fmt.Sprintf("Flag '%s' requires a value", flag))
+			return opts, nil, false
+		}
+		value := args[i+1]
+		i++
+		switch flag {
+		case "--into":
+			opts.into = value
+		case "--at":
+			line, err := strconv.Atoi(value)
+			if err != nil || line < 1 {
+				PrintError(fmt.Sprintf("Invalid line '%s'. Lines are numbered from 1", value))
+				return opts, nil, false
+			}
+			opts.at = line
+		case "--after":
+			re, err := regexp.Compile(value)
+			if err != nil {
+				PrintError(fmt.Sprintf("Invalid pattern '%s': %v", value, err))
+				return opts, nil, false
+			}
+			opts.after = re
+		case "--set":
+			name, val, ok := strings.Cut(value, "=")
+			if !ok || name == "" {
+				PrintError(fmt.Sprintf("Invalid value '%s'. Use --set name=value", value))
+				return opts, nil, false
+			}
+			opts.values[name] = val
+		}
+	}
+
+	switch {
+	case opts.into == "":
+		PrintError(fmt.Sprintf("Missing target file. Use '%s'", insertUsage))
+		return opts, nil, false
+	case (opts.at == 0) == (opts.after == nil):
+		PrintError(fmt.Sprintf("Use either --at or --after. Use '%s'", insertUsage))
+		return opts, nil, false
+	}
+	return opts, rest, true
+}
+
+// promptPlaceholders asks for the value of each placeholder that has no
+// value yet; an empty answer keeps the default. The final cursor {{0}} is
+// not asked for.
+func promptPlaceholders(code string, values map[string]string) map[string]string {
+	reader := bufio.NewReader(os.Stdin)
+	for _, p := range domain.Placeholders(code) {
+		if _, ok := values[p.Name]; ok || p.Name == "0" {
+			continue
+		}
+		if p.Default != "" {
+			fmt.Printf("%s [%s]: ", p.Name, p.Default)
+		} else {
+			fmt.Printf("%s: ", p.Name)
+		}
+		answer, _ := reader.ReadString('\n')
+		if answer = strings.TrimRight(answer, "\r\n"); answer != "" {
+			values[p.Name] = answer
+		}
+	}
+	return values
+}
+
+// splitLines splits file content into lines. It returns the line ending
+// used ("\r\n" or "\n") and whether the content ended with one.
+func splitLines(content string) ([]string, string, bool) {
+	newline := "\n"
+	if strings.Contains(content, "\r\n") {
+		newline = "\r\n"
+	}
+	if content == "" {
+		return nil, newline, false
+	}
+	trailing := strings.HasSuffix(content, "\n")
+	content = strings.TrimSuffix(strings.TrimSuffix(content, "\n"), "\r")
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	return lines, newline, trailing
+}
+
+// insertionIndent returns the indentation for code inserted before line at
+// (1-based): the deeper of the nearest non-blank lines above and below, so
+// code lands inside a block opened above or closed below.
+func insertionIndent(lines []string, at int) string {
+	indent := func(line string) string {
+		return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	}
+	above, below := "", ""
+	for i := at - 2; i >= 0; i-- {
+		if strings.TrimSpace(lines[i]) != "" {
+			above = indent(lines[i])
+			break
+		}
+	}
+	for i := at - 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != "" {
+			below = indent(lines[i])
+			break
+		}
+	}
+	if len(below) > len(above) {
+		return below
+	}
+	return above
+}
+
+// indentCode dedents code and indents its non-blank lines with indent.
+// Leading and trailing blank lines are dropped.
+func indentCode(code, indent string) []string {
+	code = strings.Trim(domain.Dedent(strings.ReplaceAll(code, "\r\n", "\n")), "\n")
+	if code == "" {
+		return nil
+	}
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return lines
+}
+
+// printInsertDiff prints the insertion as a unified diff hunk with a few
+// lines of context.
+func printInsertDiff(file string, lines, inserted []string, at int) {
+	green := color.New(color.FgGreen)
+	cyan := color.New(color.FgCyan)
+
+	from := max(1, at-insertContext)
+	to := min(len(lines), at-1+insertContext)
+	fmt.Printf("--- %s\n+++ %s\n", file, file)
+	cyan.Printf("@@ -%d,%d +%d,%d @@\n", from, to-from+1, from, to-from+1+len(inserted))
+	for n := from; n < at; n++ {
+		fmt.Println(" " + lines[n-1])
+	}
+	for _, line := range inserted {
+		green.Println("+" + line)
+	}
+	for n := at; n <= to; n++ {
+		fmt.Println(" " + lines[n-1])
+	}
+}
+
+// writeFileAtomic replaces path with data by writing a temporary file in
+// the same directory and renaming it, so readers never see a partial file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".snip-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,102 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+func TestSnippetCommand_insert(t *testing.T) {
+	const source = "package main\n\nfunc main() {\n\tx := 1\n}\n"
+
+	setup := func(t *testing.T, code string) (*SnippetCommand, string) {
+		repos := setupTestRepos(t)
+		snippet, _ := domain.NewSnippet("Print", "go", code)
+		repos.Snippets.Create(snippet)
+		path := filepath.Join(t.TempDir(), "main.go")
+		os.WriteFile(path, []byte(source), 0600)
+		return NewSnippetCommand(repos), path
+	}
+
+	t.Run("inserts after a matching line with its indentation", func(t *testing.T) {
+		sc, path := setup(t, "fmt.Println({{msg:\"hi\"}}){{0}}")
+
+		sc.insert([]string{"1", "--into", path, "--after", `^func main`, "--yes"})
+
+		data, _ := os.ReadFile(path)
+		if want := "package main\n\nfunc main() {\n\tfmt.Println(\"hi\")\n\tx := 1\n}\n"; string(data) != want {
+			t.Errorf("expected %q, got %q", want, data)
+		}
+		if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+			t.Errorf("expected the file mode to be kept, got %v", info.Mode().Perm())
+		}
+		if snippet, _ := sc.repos.Snippets.FindByID(1); snippet.CopyCount() != 1 {
+			t.Errorf("expected the insert to count as a use")
+		}
+	})
+
+	t.Run("inserts at a line with placeholder values", func(t *testing.T) {
+		sc, path := setup(t, "if err != nil {\n\treturn {{ret:err}}\n}")
+
+		sc.insert([]string{"1", "--into", path, "--at", "5", "--set", "ret=nil", "--yes"})
+
+		data, _ := os.ReadFile(path)
+		if want := "package main\n\nfunc main() {\n\tx := 1\n\tif err != nil {\n\t\treturn nil\n\t}\n}\n"; string(data) != want {
+			t.Errorf("expected %q, got %q", want, data)
+		}
+	})
+
+	t.Run("leaves the file alone on errors", func(t *testing.T) {
+		sc, path := setup(t, "x")
+
+		// Should not panic
+		sc.insert([]string{})
+		sc.insert([]string{"1"})
+		sc.insert([]string{"1", "--into", path})
+		sc.insert([]string{"1", "--into", path, "--at", "2", "--after", "x"})
+		sc.insert([]string{"1", "--into", path, "--at", "0"})
+		sc.insert([]string{"1", "--into", path, "--at", "9", "--yes"})
+		sc.insert([]string{"1", "--into", path, "--after", "nomatch", "--yes"})
+		sc.insert([]string{"1", "--into", path, "--after", "(", "--yes"})
+		sc.insert([]string{"1", "--into", path, "--at", "1", "--set", "novalue", "--yes"})
+		sc.insert([]string{"9", "--into", path, "--at", "1", "--yes"})
+		sc.insert([]string{"1", "--into", filepath.Join(t.TempDir(), "missing.go"), "--at", "1", "--yes"})
+
+		if data, _ := os.ReadFile(path); string(data) != source {
+			t.Errorf("expected the file to be unchanged, got %q", data)
+		}
+	})
+}
+
+func TestSplitLines(t *testing.T) {
+	lines, newline, trailing := splitLines("a\r\nb\r\n")
+	if !slices.Equal(lines, []string{"a", "b"}) || newline != "\r\n" || !trailing {
+		t.Errorf("unexpected split: %q %q %v", lines, newline, trailing)
+	}
+	if lines, _, trailing := splitLines("a\nb"); len(lines) != 2 || trailing {
+		t.Errorf("unexpected split: %q %v", lines, trailing)
+	}
+	if lines, _, _ := splitLines(""); len(lines) != 0 {
+		t.Errorf("expected no lines, got %q", lines)
+	}
+}
+
+func TestInsertionIndent(t *testing.T) {
+	lines := []string{"func main() {", "", "\tx := 1", "}", "", "func other() {}"}
+	for at, want := range map[int]string{1: "", 2: "\t", 4: "\t", 6: "", 7: ""} {
+		if got := insertionIndent(lines, at); got != want {
+			t.Errorf("line %d: expected %q, got %q", at, want, got)
+		}
+	}
+}
+
+func TestIndentCode(t *testing.T) {
+	got := indentCode("\n    if ok {\n        run()\n\n    }\n", "\t")
+	if want := []string{"\tif ok {", "\t    run()", "", "\t}"}; !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
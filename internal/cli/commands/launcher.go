@@ -0,0 +1,284 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/7-Dany/snip/internal/storage"
+	"github.com/atotto/clipboard"
+	"github.com/fatih/color"
+)
+
+const (
+	// launcherListUsage is shown when launcher list arguments are malformed.
+	launcherListUsage = "snip launcher list [--format dmenu|rofi|alfred-json] [--language <lang>]"
+
+	// launcherSelectUsage is shown when launcher select arguments are malformed.
+	launcherSelectUsage = "snip launcher select <entry> [--print]"
+)
+
+// launcherEntryID matches the snippet ID that starts a launcher entry.
+var launcherEntryID = regexp.MustCompile(`^\s*([0-9]+)(?::|\s|$)`)
+
+// LauncherCommand handles listing snippets for application launchers such
+// as dmenu, rofi and Alfred, and resolving the chosen entry.
+type LauncherCommand struct {
+	repos *storage.Repositories
+}
+
+// NewLauncherCommand creates a new LauncherCommand instance.
+func NewLauncherCommand(repos *storage.Repositories) *LauncherCommand {
+	return &LauncherCommand{repos: repos}
+}
+
+// manage routes launcher subcommands. Messages go to stderr, so launchers
+// reading stdout only ever see entries or code.
+func (lc *LauncherCommand) manage(args []string) {
+	defer func(output io.Writer) { color.Output = output }(color.Output)
+	color.Output = os.Stderr
+
+	if len(args) == 0 {
+		PrintError(fmt.Sprintf("Missing subcommand. Use '%s' or '%s'", launcherListUsage, launcherSelectUsage))
+		return
+	}
+
+	switch args[0] {
+	case "list":
+		lc.list(args[1:])
+	case "select":
+		lc.selectEntry(args[1:])
+	default:
+		PrintError(fmt.Sprintf("Unknown launcher command '%s'. Use list or select", args[0]))
+	}
+}
+
+// list prints one entry per snippet, pinned and recently used first, in
+// the format of a launcher: plain lines for dmenu, lines with row options
+// for rofi, or a script filter document for Alfred.
+func (lc *LauncherCommand) list(args []string) {
+	format, language := "dmenu", ""
+	for i := 0; i < len(args); i++ {
+		flag := args[i]
+		if flag != "--format" && flag != "--language" {
+			PrintError(fmt.Sprintf("Unknown argument '%s'. Use '%s'", flag, launcherListUsage))
+			return
+		}
+		if i+1 >= len(args) {
+			PrintError(fmt.Sprintf("Flag '%s' requires a value", flag))
+			return
+		}
+		if flag == "--format" {
+			format = args[i+1]
+		} else {
+			language = args[i+1]
+		}
+		i++
+	}
+	if format != "dmenu" && format != "rofi" && format != "alfred-json" {
+		PrintError(fmt.Sprintf("Unknown launcher format '%s'. Use dmenu, rofi or alfred-json", format))
+		return
+	}
+
+	snippets, err := lc.repos.Snippets.List()
+	if language != "" {
+		snippets, err = lc.repos.Snippets.FindByLanguage(language)
+	}
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to list snippets: %v", err))
+		return
+	}
+	tags, err := lc.repos.Tags.List()
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to list tags: %v", err))
+		return
+	}
+	tagMap := make(map[int]*domain.Tag, len(tags))
+	for _, tag := range tags {
+		tagMap[tag.ID()] = tag
+	}
+	sortByUsage(snippets)
+
+	switch format {
+	case "dmenu":
+		for _, snippet := range snippets {
+			fmt.Println(launcherEntry(snippet, tagMap))
+		}
+	case "rofi":
+		for _, snippet := range snippets {
+			fmt.Println(rofiEntry(snippet, tagMap))
+		}
+	case "alfred-json":
+		data, err := alfredItems(snippets, tagMap)
+		if err != nil {
+			PrintError(fmt.Sprintf("Failed to encode entries: %v", err))
+			return
+		}
+		os.Stdout.Write(data)
+	}
+}
+
+// selectEntry resolves an entry chosen in a launcher to its snippet and
+// copies its code to the clipboard, or prints it with --print. The entry
+// may be a listed line, a bare ID, an alias or an exact title. An empty
+// entry, as left by a cancelled launcher, does nothing.
+func (lc *LauncherCommand) selectEntry(args []string) {
+	printCode := false
+	var words []string
+	for _, arg := range args {
+		if arg == "--print" {
+			printCode = true
+			continue
+		}
+		words = append(words, arg)
+	}
+	if len(words) == 0 {
+		PrintError(fmt.Sprintf("Missing entry. Use '%s'", launcherSelectUsage))
+		return
+	}
+	entry := strings.TrimSpace(strings.Join(words, " "))
+	if entry == "" {
+		return
+	}
+
+	snippet := lc.resolveEntry(entry)
+	if snippet == nil {
+		return
+	}
+
+	if printCode {
+		fmt.Println(snippet.Code())
+	} else if err := clipboard.WriteAll(snippet.Code()); err != nil {
+		PrintError(fmt.Sprintf("Failed to copy to clipboard: %v", err))
+		return
+	}
+
+	snippet.RecordCopy()
+	if err := lc.repos.RecordUsage(snippet); err != nil {
+		PrintError(fmt.Sprintf("Failed to record usage: %v", err))
+		return
+	}
+	if !printCode {
+		PrintSuccess(fmt.Sprintf("Copied '%s' to clipboard", snippet.Title()))
+	}
+}
+
+// resolveEntry finds the snippet of a launcher entry: by the ID it starts
+// with, or else by alias or case-insensitive title. It prints an error and
+// returns nil if no snippet matches.
+func (lc *LauncherCommand) resolveEntry(entry string) *domain.Snippet {
+	if m := launcherEntryID.FindStringSubmatch(entry); m != nil {
+		id, _ := strconv.Atoi(m[1])
+		snippet, err := lc.repos.Snippets.FindByID(id)
+		if errors.Is(err, storage.ErrNotFound) {
+			PrintError(fmt.Sprintf("Snippet with ID %d not found", id))
+			return nil
+		}
+		if err != nil {
+			PrintError(fmt.Sprintf("Failed to find snippet: %v", err))
+			return nil
+		}
+		return snippet
+	}
+
+	snippets, err := lc.repos.Snippets.List()
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to list snippets: %v", err))
+		return nil
+	}
+	for _, snippet := range snippets {
+		if snippet.HasAlias(entry) || strings.EqualFold(snippet.Title(), entry) {
+			return snippet
+		}
+	}
+	PrintError(fmt.Sprintf("No snippet matches '%s'", entry))
+	return nil
+}
+
+// launcherEntry returns the line shown for a snippet, such as
+// "12: Download with curl [bash] #http #cli". It is a single line even if
+// the title is not.
+func launcherEntry(snippet *domain.Snippet, tagMap map[int]*domain.Tag) string {
+	entry := fmt.Sprintf("%d: %s [%s]", snippet.ID(), strings.Join(strings.Fields(snippet.Title()), " "), snippet.Language())
+	for _, name := range launcherTags(snippet, tagMap) {
+		entry += " #" + name
+	}
+	return entry
+}
+
+// rofiEntry returns a launcher entry with rofi row options: the ID as info
+// for script modes, and the aliases and description as hidden search text.
+func rofiEntry(snippet *domain.Snippet, tagMap map[int]*domain.Tag) string {
+	meta := strings.Join(append(snippet.Aliases(), strings.Fields(snippet.Description())...), " ")
+	return fmt.Sprintf("%s\x00info\x1f%d\x1fmeta\x1f%s", launcherEntry(snippet, tagMap), snippet.ID(), meta)
+}
+
+// launcherTags returns the names of a snippet's tags.
+func launcherTags(snippet *domain.Snippet, tagMap map[int]*domain.Tag) []string {
+	var names []string
+	for _, id := range snippet.Tags() {
+		if tag, ok := tagMap[id]; ok {
+			names = append(names, tag.Name())
+		}
+	}
+	return names
+}
+
+// alfredItem is an entry of an Alfred script filter document.
+type alfredItem struct {
+	UID          string     `json:"uid"`
+	Title        string     `json:"title"`
+	Subtitle     string     `json:"subtitle"`
+	Arg          string     `json:"arg"`
+	Autocomplete string     `json:"autocomplete"`
+	Match        string     `json:"match"`
+	Text         alfredText `json:"text"`
+}
+
+// alfredText is the text Alfred copies with ⌘C or shows as large type.
+type alfredText struct {
+	Copy      string `json:"copy"`
+	LargeType string `json:"largetype"`
+}
+
+// alfredItems returns an Alfred script filter document listing snippets.
+// Each item passes the snippet ID on as its argument, for launcher select.
+func alfredItems(snippets []*domain.Snippet, tagMap map[int]*domain.Tag) ([]byte, error) {
+	items := make([]alfredItem, len(snippets))
+	for i, snippet := range snippets {
+		tags := launcherTags(snippet, tagMap)
+		subtitle := snippet.Language()
+		for _, name := range tags {
+			subtitle += " #" + name
+		}
+		if snippet.Description() != "" {
+			subtitle += " · " + snippet.Description()
+		}
+		match := []string{snippet.Title(), snippet.Language()}
+		match = append(match, tags...)
+		match = append(match, snippet.Aliases()...)
+
+		id := strconv.Itoa(snippet.ID())
+		items[i] = alfredItem{
+			UID:          id,
+			Title:        snippet.Title(),
+			Subtitle:     subtitle,
+			Arg:          id,
+			Autocomplete: snippet.Title(),
+			Match:        strings.Join(match, " "),
+			Text:         alfredText{Copy: snippet.Code(), LargeType: snippet.Code()},
+		}
+	}
+	data, err := json.MarshalIndent(map[string][]alfredItem{"items": items}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
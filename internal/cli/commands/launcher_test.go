@@ -0,0 +1,112 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+func TestLauncherCommand_manage(t *testing.T) {
+	setup := func(t *testing.T) (*LauncherCommand, *domain.Snippet) {
+		repos := setupTestRepos(t)
+		tag, _ := domain.NewTag("http")
+		repos.Tags.Create(tag)
+
+		snippet, _ := domain.NewSnippet("Download with curl", "bash", "curl -fsSL {{url}}")
+		snippet.AddTag(tag.ID())
+		snippet.AddAlias("dl")
+		repos.Snippets.Create(snippet)
+		return NewLauncherCommand(repos), snippet
+	}
+
+	t.Run("lists entries in every format", func(t *testing.T) {
+		lc, _ := setup(t)
+		// Should not panic
+		lc.manage([]string{"list"})
+		lc.manage([]string{"list", "--format", "rofi"})
+		lc.manage([]string{"list", "--format", "alfred-json", "--language", "bash"})
+	})
+
+	t.Run("handles invalid arguments", func(t *testing.T) {
+		lc, _ := setup(t)
+		// Should show errors, not panic
+		lc.manage(nil)
+		lc.manage([]string{"open"})
+		lc.manage([]string{"list", "--format", "wofi"})
+		lc.manage([]string{"list", "--format"})
+		lc.manage([]string{"select"})
+		lc.manage([]string{"select", "99: Missing [bash]", "--print"})
+		lc.manage([]string{"select", "no such snippet", "--print"})
+	})
+
+	t.Run("selects entries by line, ID, alias or title", func(t *testing.T) {
+		lc, snippet := setup(t)
+		for _, entry := range []string{"1: Download with curl [bash] #http", "1", "dl", "download with curl"} {
+			if got := lc.resolveEntry(entry); got != snippet {
+				t.Errorf("expected entry %q to resolve to the snippet", entry)
+			}
+		}
+
+		lc.manage([]string{"select", "1: Download with curl [bash] #http", "--print"})
+		if snippet.CopyCount() != 1 {
+			t.Errorf("expected the selection to be recorded, got %d copies", snippet.CopyCount())
+		}
+
+		// A cancelled launcher passes an empty entry
+		lc.manage([]string{"select", "", "--print"})
+		if snippet.CopyCount() != 1 {
+			t.Errorf("expected an empty entry to do nothing, got %d copies", snippet.CopyCount())
+		}
+	})
+}
+
+func TestLauncherEntries(t *testing.T) {
+	tag, _ := domain.NewTag("http")
+	tag.SetID(3)
+	tagMap := map[int]*domain.Tag{3: tag}
+
+	snippet, _ := domain.NewSnippet("Download\nwith curl", "bash", "curl -fsSL {{url}}")
+	snippet.SetID(12)
+	snippet.AddTag(3)
+	snippet.AddAlias("dl")
+	snippet.SetDescription("Fetch a URL")
+
+	t.Run("dmenu", func(t *testing.T) {
+		if got, want := launcherEntry(snippet, tagMap), "12: Download with curl [bash] #http"; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("rofi", func(t *testing.T) {
+		want := "12: Download with curl [bash] #http\x00info\x1f12\x1fmeta\x1fdl Fetch a URL"
+		if got := rofiEntry(snippet, tagMap); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("alfred", func(t *testing.T) {
+		data, err := alfredItems([]*domain.Snippet{snippet}, tagMap)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var doc struct {
+			Items []alfredItem `json:"items"`
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if len(doc.Items) != 1 {
+			t.Fatalf("expected 1 item, got %d", len(doc.Items))
+		}
+		item := doc.Items[0]
+		if item.Arg != "12" || item.Subtitle != "bash #http · Fetch a URL" || item.Text.Copy != snippet.Code() {
+			t.Errorf("unexpected item: %+v", item)
+		}
+		if !strings.Contains(item.Match, "dl") {
+			t.Errorf("expected aliases in match, got %q", item.Match)
+		}
+	})
+}
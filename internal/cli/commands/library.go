@@ -0,0 +1,163 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/cli/config"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// LibraryCommand handles named snippet libraries.
+type LibraryCommand struct {
+	config *config.Config
+}
+
+// NewLibraryCommand creates a new LibraryCommand instance.
+func NewLibraryCommand(cfg *config.Config) *LibraryCommand {
+	return &LibraryCommand{config: cfg}
+}
+
+// manage routes library subcommands to the appropriate handler.
+func (lc *LibraryCommand) manage(args []string) {
+	if len(args) == 0 {
+		PrintError("No subcommand provided. Use 'snip help library' for available commands")
+		return
+	}
+
+	subcommand := strings.ToLower(args[0])
+	subcommandArgs := args[1:]
+
+	switch subcommand {
+	case "list":
+		lc.list()
+	case "add":
+		lc.add(subcommandArgs)
+	case "use":
+		lc.use(subcommandArgs)
+	case "remove":
+		lc.remove(subcommandArgs)
+	default:
+		PrintError(fmt.Sprintf("Unknown command '%s'. Use 'snip help library' for available commands", args[0]))
+	}
+}
+
+// list displays all libraries, marking the current one.
+func (lc *LibraryCommand) list() {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"", "Name", "Backend", "Storage Path"})
+
+	current := lc.config.CurrentLibraryName()
+	for _, name := range lc.config.LibraryNames() {
+		library, err := lc.config.Library(name)
+		if err != nil {
+			continue
+		}
+		marker := ""
+		if name == current {
+			marker = "*"
+		}
+		t.AppendRow(table.Row{marker, name, library.Backend, library.StoragePath})
+	}
+
+	t.SetStyle(table.StyleColoredBright)
+	t.Render()
+}
+
+// add registers a named library. Without a path, the library is stored
+// next to the default one in libraries/<name>.json.
+func (lc *LibraryCommand) add(args []string) {
+	var name, path, backend string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--backend":
+			if i+1 >= len(args) {
+				PrintError("Missing value for '--backend'")
+				return
+			}
+			backend = args[i+1]
+			i++
+		case name == "":
+			name = args[i]
+		case path == "":
+			path = args[i]
+		default:
+			PrintError(fmt.Sprintf("Unexpected argument '%s'. Use 'snip library add <name> [path] [--backend json]'", args[i]))
+			return
+		}
+	}
+	if name == "" {
+		PrintError("Missing required argument 'name'. Use 'snip library add <name> [path]'")
+		return
+	}
+
+	if path == "" {
+		path = filepath.Join(filepath.Dir(lc.config.StoragePath), "libraries", name+".json")
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		PrintError(fmt.Sprintf("invalid path: %v", err))
+		return
+	}
+
+	if err := lc.config.AddLibrary(name, config.Library{StoragePath: path, Backend: backend}); err != nil {
+		PrintError(err.Error())
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		PrintError(fmt.Sprintf("failed to create library directory: %v", err))
+		return
+	}
+	if err := lc.config.Save(); err != nil {
+		PrintError(fmt.Sprintf("failed to save config: %v", err))
+		return
+	}
+
+	PrintSuccess(fmt.Sprintf("Added library '%s' at %s. Switch to it with 'snip library use %s'", name, path, name))
+}
+
+// use makes a library the current one.
+func (lc *LibraryCommand) use(args []string) {
+	if len(args) == 0 {
+		PrintError("Missing required argument 'name'. Use 'snip library use <name>'")
+		return
+	}
+
+	if err := lc.config.UseLibrary(args[0]); err != nil {
+		PrintError(err.Error())
+		return
+	}
+	if err := lc.config.Save(); err != nil {
+		PrintError(fmt.Sprintf("failed to save config: %v", err))
+		return
+	}
+
+	PrintSuccess(fmt.Sprintf("Now using library '%s'", args[0]))
+}
+
+// remove forgets a named library; its snippets file is kept.
+func (lc *LibraryCommand) remove(args []string) {
+	if len(args) == 0 {
+		PrintError("Missing required argument 'name'. Use 'snip library remove <name>'")
+		return
+	}
+
+	library, err := lc.config.Library(args[0])
+	if err == nil {
+		err = lc.config.RemoveLibrary(args[0])
+	}
+	if err != nil {
+		PrintError(err.Error())
+		return
+	}
+	if err := lc.config.Save(); err != nil {
+		PrintError(fmt.Sprintf("failed to save config: %v", err))
+		return
+	}
+
+	PrintSuccess(fmt.Sprintf("Removed library '%s'. Its snippets are kept in %s", args[0], library.StoragePath))
+}
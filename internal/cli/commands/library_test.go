@@ -0,0 +1,83 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/cli/config"
+)
+
+// setupTestConfig loads a config from a temporary directory, so the
+// library and config commands can save it.
+func setupTestConfig(t *testing.T) *config.Config {
+	t.Helper()
+	cfg, err := config.LoadConfigFile(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	return cfg
+}
+
+func TestLibraryCommand_manage(t *testing.T) {
+	t.Run("adds, uses and removes a library", func(t *testing.T) {
+		cfg := setupTestConfig(t)
+		lc := NewLibraryCommand(cfg)
+
+		lc.manage([]string{"add", "work"})
+		library, err := cfg.Library("work")
+		if err != nil {
+			t.Fatalf("expected library 'work', got %v", err)
+		}
+		want := filepath.Join(filepath.Dir(cfg.StoragePath), "libraries", "work.json")
+		if library.StoragePath != want || library.Backend != config.BackendJSON {
+			t.Errorf("expected %s with the json backend, got %+v", want, library)
+		}
+
+		lc.manage([]string{"use", "work"})
+		reloaded, _ := config.LoadConfigFile(cfg.Path())
+		if reloaded.CurrentLibraryName() != "work" {
+			t.Errorf("expected saved current library 'work', got %q", reloaded.CurrentLibraryName())
+		}
+
+		lc.manage([]string{"list"})
+
+		lc.manage([]string{"remove", "work"})
+		reloaded, _ = config.LoadConfigFile(cfg.Path())
+		if _, err := reloaded.Library("work"); err == nil || reloaded.CurrentLibraryName() != config.DefaultLibrary {
+			t.Errorf("expected library removed and default current, got %q", reloaded.CurrentLibraryName())
+		}
+	})
+
+	t.Run("adds a library at a path with a backend", func(t *testing.T) {
+		cfg := setupTestConfig(t)
+		lc := NewLibraryCommand(cfg)
+		path := filepath.Join(t.TempDir(), "team.json")
+
+		lc.manage([]string{"add", "team", path, "--backend", "json"})
+		if library, err := cfg.Library("team"); err != nil || library.StoragePath != path {
+			t.Errorf("expected library at %s, got %+v (%v)", path, library, err)
+		}
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		cfg := setupTestConfig(t)
+		lc := NewLibraryCommand(cfg)
+
+		// Should print errors, not panic
+		lc.manage(nil)
+		lc.manage([]string{"unknown"})
+		lc.manage([]string{"add"})
+		lc.manage([]string{"add", "bad name"})
+		lc.manage([]string{"add", "x", "--backend", "sqlite"})
+		lc.manage([]string{"add", "x", "--backend"})
+		lc.manage([]string{"add", "default"})
+		lc.manage([]string{"use"})
+		lc.manage([]string{"use", "missing"})
+		lc.manage([]string{"remove", "default"})
+
+		if names := cfg.LibraryNames(); len(names) != 1 {
+			t.Errorf("expected only the default library, got %v", names)
+		}
+	})
+}
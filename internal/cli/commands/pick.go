@@ -0,0 +1,256 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/cli/components"
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/7-Dany/snip/internal/storage"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+)
+
+// pickUsage is shown when pick arguments are malformed.
+const pickUsage = "snip pick [--query <text>] [--language <lang>] [--id] | snip pick --init bash|zsh|fish"
+
+const (
+	pickTableHeight  = 8  // Rows of snippets shown by the picker
+	pickPreviewLines = 6  // Lines of code shown in the preview
+	pickWidth        = 80 // Width of the picker
+)
+
+// shellScripts holds the key bindings printed by pick --init.
+//
+//go:embed shell
+var shellScripts embed.FS
+
+// PickCommand handles choosing a snippet interactively and printing it,
+// for shell key bindings and launchers.
+type PickCommand struct {
+	repos *storage.Repositories
+}
+
+// NewPickCommand creates a new PickCommand instance.
+func NewPickCommand(repos *storage.Repositories) *PickCommand {
+	return &PickCommand{repos: repos}
+}
+
+// manage runs the picker and prints the chosen snippet's code, or its ID
+// with --id. The picker is drawn inline on stderr so stdout carries only
+// the result. --init prints the key binding script for a shell instead.
+func (pc *PickCommand) manage(args []string) {
+	// Messages go to stderr too, so they never end up on the command line.
+	defer func(output io.Writer) { color.Output = output }(color.Output)
+	color.Output = os.Stderr
+
+	query, language, printID := "", "", false
+	for i := 0; i < len(args); i++ {
+		flag := args[i]
+		if flag == "--id" {
+			printID = true
+			continue
+		}
+		if flag != "--query" && flag != "--language" && flag != "--init" {
+			PrintError(fmt.Sprintf("Unknown argument '%s'. Use '%s'", flag, pickUsage))
+			return
+		}
+		if i+1 >= len(args) {
+			PrintError(fmt.Sprintf("Flag '%s' requires a value", flag))
+			return
+		}
+		value := args[i+1]
+		i++
+		switch flag {
+		case "--query":
+			query = value
+		case "--language":
+			language = value
+		case "--init":
+			printShellScript(value)
+			return
+		}
+	}
+
+	snippets, err := pc.repos.Snippets.List()
+	if language != "" {
+		snippets, err = pc.repos.Snippets.FindByLanguage(language)
+	}
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to list snippets: %v", err))
+		return
+	}
+	if len(snippets) == 0 {
+		PrintInfo("No snippets to pick from")
+		return
+	}
+	sortByUsage(snippets)
+
+	// Draw on stderr and keep styles colored although stdout is captured.
+	lipgloss.SetDefaultRenderer(lipgloss.NewRenderer(os.Stderr))
+	p := tea.NewProgram(newPickModel(snippets, query), tea.WithOutput(os.Stderr))
+	finalModel, err := p.Run()
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to run picker: %v", err))
+		return
+	}
+
+	chosen := finalModel.(pickModel).chosen
+	if chosen == nil {
+		return
+	}
+	chosen.RecordCopy()
+	if err := pc.repos.RecordUsage(chosen); err != nil {
+		PrintError(fmt.Sprintf("Failed to record usage: %v", err))
+		return
+	}
+
+	if printID {
+		fmt.Println(chosen.ID())
+		return
+	}
+	fmt.Println(chosen.Code())
+}
+
+// printShellScript prints the pick key binding script for a shell.
+func printShellScript(shell string) {
+	data, err := shellScripts.ReadFile("shell/pick." + shell)
+	if err != nil {
+		PrintError(fmt.Sprintf("Unknown shell '%s'. Use bash, zsh or fish", shell))
+		return
+	}
+	os.Stdout.Write(data)
+}
+
+// sortByUsage orders snippets with pinned ones first, then by most recent use.
+func sortByUsage(snippets []*domain.Snippet) {
+	sort.SliceStable(snippets, func(i, j int) bool {
+		if snippets[i].IsPinned() != snippets[j].IsPinned() {
+			return snippets[i].IsPinned()
+		}
+		return snippets[i].LastUsedAt().After(snippets[j].LastUsedAt())
+	})
+}
+
+// pickModel is a compact Bubble Tea picker: a search-as-you-type snippet
+// table with a preview of the code under the cursor.
+type pickModel struct {
+	table    components.SearchableTableView
+	snippets map[string]*domain.Snippet // Keyed by ID, the table's first column
+	query    string
+	chosen   *domain.Snippet
+}
+
+// newPickModel creates a picker over snippets, in search mode with query.
+func newPickModel(snippets []*domain.Snippet, query string) pickModel {
+	columns := []table.Column{
+		{Title: "ID", Width: 5},
+		{Title: "Title", Width: 40},
+		{Title: "Language", Width: 12},
+		{Title: "Aliases", Width: 15},
+	}
+	stv := components.NewSearchableTableView(columns, "", "No snippets",
+		"↑/↓: move | Enter: pick | Esc: cancel", pickTableHeight)
+
+	byID := make(map[string]*domain.Snippet, len(snippets))
+	rows := make([]table.Row, len(snippets))
+	for i, snippet := range snippets {
+		id := strconv.Itoa(snippet.ID())
+		byID[id] = snippet
+		rows[i] = table.Row{id, snippet.Title(), snippet.Language(), strings.Join(snippet.Aliases(), ", ")}
+	}
+	stv.SetRows(rows)
+	stv.StartSearch(query)
+
+	return pickModel{table: stv, snippets: byID, query: query}
+}
+
+// Init initializes the picker.
+func (m pickModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update moves the cursor, picks or cancels, and passes typing to the search.
+func (m pickModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			m.chosen = m.current()
+			return m, tea.Quit
+		case "esc", "ctrl+c", "ctrl+g":
+			return m, tea.Quit
+		case "up", "ctrl+p", "ctrl+k":
+			m.table.MoveCursor(-1)
+			return m, nil
+		case "down", "ctrl+n", "ctrl+j", "tab":
+			m.table.MoveCursor(1)
+			return m, nil
+		case "pgup":
+			m.table.MoveCursor(-pickTableHeight)
+			return m, nil
+		case "pgdown":
+			m.table.MoveCursor(pickTableHeight)
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	if query := m.table.GetSearchQuery(); query != m.query {
+		// Start from the best match whenever the query changes.
+		m.query = query
+		m.table.MoveCursor(-len(m.snippets))
+	}
+	return m, cmd
+}
+
+// current returns the snippet under the cursor, or nil if nothing matches.
+func (m pickModel) current() *domain.Snippet {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return nil
+	}
+	return m.snippets[row[0]]
+}
+
+// View renders the table and the preview. The final render is cleared so
+// the picker leaves nothing behind in the terminal.
+func (m pickModel) View() string {
+	if m.chosen != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(m.table.View())
+	b.WriteString("\n")
+
+	snippet := m.current()
+	if snippet == nil {
+		return b.String()
+	}
+	lines := strings.Split(snippet.Code(), "\n")
+	if len(lines) > pickPreviewLines {
+		more := fmt.Sprintf("… %d more line(s)", len(lines)-pickPreviewLines)
+		lines = append(lines[:pickPreviewLines], more)
+	}
+	for i, line := range lines {
+		if len([]rune(line)) > pickWidth-4 {
+			lines[i] = string([]rune(line)[:pickWidth-5]) + "…"
+		}
+	}
+	preview := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(lipgloss.Color("13")).
+		Foreground(lipgloss.Color("250")).
+		PaddingLeft(1)
+	b.WriteString(preview.Render(strings.Join(lines, "\n")))
+	return b.String()
+}
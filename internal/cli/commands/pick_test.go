@@ -0,0 +1,117 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPickCommand_manage(t *testing.T) {
+	pc := NewPickCommand(setupTestRepos(t))
+
+	t.Run("prints shell key bindings", func(t *testing.T) {
+		for _, shell := range []string{"bash", "zsh", "fish"} {
+			data, err := shellScripts.ReadFile("shell/pick." + shell)
+			if err != nil || len(data) == 0 {
+				t.Errorf("expected a %s script, got error %v", shell, err)
+			}
+			// Should not panic
+			pc.manage([]string{"--init", shell})
+		}
+	})
+
+	t.Run("handles invalid arguments", func(t *testing.T) {
+		// Should show errors, not panic
+		pc.manage([]string{"--init", "powershell"})
+		pc.manage([]string{"--init"})
+		pc.manage([]string{"--bogus"})
+	})
+
+	t.Run("handles an empty library", func(t *testing.T) {
+		// Should show info, not panic
+		pc.manage(nil)
+	})
+}
+
+func TestSortByUsage(t *testing.T) {
+	plain, _ := domain.NewSnippet("Plain", "bash", "ls")
+	used, _ := domain.NewSnippet("Used", "bash", "pwd")
+	pinned, _ := domain.NewSnippet("Pinned", "bash", "whoami")
+	used.RecordCopy()
+	pinned.SetPinned(true)
+
+	snippets := []*domain.Snippet{plain, used, pinned}
+	sortByUsage(snippets)
+
+	if snippets[0] != pinned || snippets[1] != used || snippets[2] != plain {
+		t.Errorf("unexpected order: %s, %s, %s", snippets[0].Title(), snippets[1].Title(), snippets[2].Title())
+	}
+}
+
+func TestPickModel(t *testing.T) {
+	curl, _ := domain.NewSnippet("Download with curl", "bash", "curl -fsSL {{url}}")
+	tar, _ := domain.NewSnippet("Extract archive", "bash", "tar -xzf {{file}}")
+	curl.SetID(1)
+	tar.SetID(2)
+	snippets := []*domain.Snippet{curl, tar}
+
+	key := func(s string) tea.KeyMsg {
+		switch s {
+		case "enter":
+			return tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			return tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			return tea.KeyMsg{Type: tea.KeyDown}
+		}
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+	send := func(m pickModel, keys ...string) pickModel {
+		for _, k := range keys {
+			model, _ := m.Update(key(k))
+			m = model.(pickModel)
+		}
+		return m
+	}
+
+	t.Run("filters with the initial query", func(t *testing.T) {
+		m := newPickModel(snippets, "archive")
+		if m.current() != tar {
+			t.Errorf("expected the archive snippet under the cursor")
+		}
+		if m.View() == "" {
+			t.Error("expected the picker to render")
+		}
+	})
+
+	t.Run("moves and picks", func(t *testing.T) {
+		m := send(newPickModel(snippets, ""), "down", "enter")
+		if m.chosen != tar {
+			t.Errorf("expected the second snippet to be chosen")
+		}
+		if m.View() != "" {
+			t.Error("expected the picker to clear after picking")
+		}
+	})
+
+	t.Run("typing filters and resets the cursor", func(t *testing.T) {
+		m := send(newPickModel(snippets, ""), "down", "c", "u", "r", "l", "enter")
+		if m.chosen != curl {
+			t.Errorf("expected the curl snippet to be chosen")
+		}
+	})
+
+	t.Run("cancels", func(t *testing.T) {
+		m := send(newPickModel(snippets, ""), "esc")
+		if m.chosen != nil {
+			t.Error("expected nothing to be chosen")
+		}
+
+		m = send(newPickModel(snippets, "nothing matches"), "enter")
+		if m.chosen != nil {
+			t.Error("expected nothing to be chosen without matches")
+		}
+	})
+}
@@ -0,0 +1,104 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/7-Dany/snip/internal/runner"
+	"github.com/fatih/color"
+)
+
+// run executes a snippet with the runner configured for its language.
+// Arguments after "--" are passed to the snippet.
+func (sc *SnippetCommand) run(args []string) {
+	usage := "snip snippet run <id> [--timeout <seconds>] [-- args...]"
+
+	var snippetArgs []string
+	for i, arg := range args {
+		if arg == "--" {
+			snippetArgs = args[i+1:]
+			args = args[:i]
+			break
+		}
+	}
+
+	timeout := sc.config.RunTimeout()
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--timeout" {
+			rest = append(rest, args[i])
+			continue
+		}
+		if i+1 >= len(args) {
+			PrintError(fmt.Sprintf("Flag '--timeout' requires a value. Use '%s'", usage))
+			return
+		}
+		seconds, err := strconv.Atoi(args[i+1])
+		if err != nil || seconds <= 0 {
+			PrintError(fmt.Sprintf("Invalid timeout '%s'. Must be a positive number of seconds", args[i+1]))
+			return
+		}
+		timeout = time.Duration(seconds) * time.Second
+		i++
+	}
+
+	snippet := sc.snippetFromArgs(rest, usage)
+	if snippet == nil {
+		return
+	}
+
+	r, ok := sc.config.RunnerFor(snippet.Language())
+	if !ok {
+		PrintError(fmt.Sprintf("No runner configured for language '%s'. Add one under \"runners\" in the config file", snippet.Language()))
+		return
+	}
+
+	PrintInfo(fmt.Sprintf("Running '%s' with %s (timeout %s)", snippet.Title(), strings.Join(r.Command, " "), timeout))
+
+	result, err := runner.Run(r.Command, r.Extension, snippet.Code(), snippetArgs, timeout)
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to run snippet: %v", err))
+		return
+	}
+
+	printRunResult(result, timeout)
+}
+
+// printRunResult prints the output and exit status of a snippet run.
+func printRunResult(result *runner.Result, timeout time.Duration) {
+	gray := color.New(color.FgHiBlack)
+
+	if result.Stdout != "" {
+		gray.Println("── stdout ──")
+		fmt.Fprint(os.Stdout, ensureNewline(result.Stdout))
+	}
+	if result.Stderr != "" {
+		gray.Println("── stderr ──")
+		fmt.Fprint(os.Stdout, ensureNewline(result.Stderr))
+	}
+	if result.Truncated {
+		PrintInfo(fmt.Sprintf("Output truncated to %d bytes per stream", runner.MaxOutputBytes))
+	}
+
+	elapsed := result.Duration.Round(time.Millisecond)
+	switch {
+	case result.TimedOut:
+		PrintError(fmt.Sprintf("Timed out after %s", timeout))
+	case result.ExitCode != 0:
+		PrintError(fmt.Sprintf("Exited with code %d in %s", result.ExitCode, elapsed))
+	default:
+		PrintSuccess(fmt.Sprintf("Exited with code 0 in %s", elapsed))
+	}
+}
+
+// ensureNewline appends a newline to s if it does not end with one.
+func ensureNewline(s string) string {
+	if strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
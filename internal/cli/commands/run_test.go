@@ -0,0 +1,53 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/cli/config"
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+func TestSnippetCommand_run(t *testing.T) {
+	t.Run("runs a snippet with its language runner", func(t *testing.T) {
+		if _, err := exec.LookPath("sh"); err != nil {
+			t.Skip("sh not available")
+		}
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+
+		snip, _ := domain.NewSnippet("Echo", "sh", `echo "hello $1"; exit 2`)
+		repos.Snippets.Create(snip)
+
+		sc.run([]string{"1", "--timeout", "5", "--", "world"})
+	})
+
+	t.Run("uses configured runners", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+		sc.config = &config.Config{Runners: map[string]config.Runner{
+			"custom": {Command: []string{"snip-no-such-command"}},
+		}}
+
+		snip, _ := domain.NewSnippet("Custom", "custom", "code")
+		repos.Snippets.Create(snip)
+
+		sc.run([]string{"1"})
+	})
+
+	t.Run("validates arguments", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+
+		snip, _ := domain.NewSnippet("Query", "sql", "SELECT 1")
+		repos.Snippets.Create(snip)
+
+		sc.run([]string{})
+		sc.run([]string{"abc"})
+		sc.run([]string{"99"})
+		sc.run([]string{"1", "--timeout"})
+		sc.run([]string{"1", "--timeout", "0"})
+		sc.run([]string{"1"})
+	})
+}
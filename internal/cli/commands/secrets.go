@@ -0,0 +1,173 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// scanUsage is shown when snippet scan arguments are malformed.
+const scanUsage = "snip snippet scan [ids] [--redact] [--yes]"
+
+// scan audits snippets for credentials. With --redact, secrets are replaced
+// with placeholders after confirmation.
+func (sc *SnippetCommand) scan(args []string) {
+	redact, yes := false, false
+	var selection bulkSelection
+
+	for _, arg := range args {
+		switch arg {
+		case "--redact":
+			redact = true
+		case "--yes", "-y":
+			yes = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				PrintError(fmt.Sprintf("Unknown flag '%s'. Use '%s'", arg, scanUsage))
+				return
+			}
+			ids, ranges, err := parseIDList(arg)
+			if err != nil {
+				PrintError(err.Error())
+				return
+			}
+			selection.ids = append(selection.ids, ids...)
+			selection.ranges = append(selection.ranges, ranges...)
+		}
+	}
+
+	snippets, err := sc.repos.Snippets.List()
+	if len(selection.ids) > 0 || len(selection.ranges) > 0 {
+		snippets, err = sc.resolveBulkSelection(selection)
+	}
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to load snippets: %v", err))
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"ID", "Title", "Line", "Kind", "Value"})
+
+	var affected []int
+	var readOnly []string
+	total, redactable := 0, 0
+	for _, snippet := range snippets {
+		findings := domain.ScanSecrets(snippet.Code())
+		if len(findings) == 0 {
+			continue
+		}
+		total += len(findings)
+		// Read-only snippets would make the whole redaction fail
+		if sc.repos.ReadOnly(snippet.ID()) {
+			readOnly = append(readOnly, strconv.Itoa(snippet.ID()))
+		} else {
+			affected = append(affected, snippet.ID())
+			redactable += len(findings)
+		}
+		for _, f := range findings {
+			t.AppendRow(table.Row{snippet.ID(), snippet.Title(), f.Line, f.Kind, f.Masked()})
+		}
+	}
+
+	if total == 0 {
+		PrintSuccess(fmt.Sprintf("No secrets found in %d snippet(s)", len(snippets)))
+		return
+	}
+
+	t.SetStyle(table.StyleColoredBright)
+	t.Render()
+	PrintInfo(fmt.Sprintf("⚠ Found %d possible secret(s) in %d snippet(s)", total, len(affected)+len(readOnly)))
+
+	if !redact {
+		PrintInfo("Use 'snip snippet scan --redact' to replace them with placeholders")
+		return
+	}
+
+	if len(readOnly) > 0 {
+		PrintInfo(fmt.Sprintf("Read-only snippet(s) %s: fork to redact, with 'snip snippet fork <id>'", strings.Join(readOnly, ", ")))
+	}
+	if len(affected) == 0 {
+		return
+	}
+
+	if !yes {
+		fmt.Printf("Replace %d secret(s) in %d snippet(s) with placeholders? (y/n): ", redactable, len(affected))
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(strings.TrimSpace(response)) != "y" {
+			PrintInfo("Redact cancelled")
+			return
+		}
+	}
+
+	if err := sc.repos.Snippets.BulkUpdate(affected, domain.RedactSecretsEdit()); err != nil {
+		PrintError(fmt.Sprintf("Failed to redact secrets: %v", err))
+		return
+	}
+	PrintSuccess(fmt.Sprintf("Replaced %d secret(s) in %d snippet(s) with placeholders", redactable, len(affected)))
+}
+
+// checkSecrets warns about credentials in code before it is saved and lets
+// the user replace them with placeholders, keep them, or cancel. It returns
+// false if the user cancels.
+func checkSecrets(code string) (string, bool) {
+	findings := domain.ScanSecrets(code)
+	if len(findings) == 0 {
+		return code, true
+	}
+
+	PrintInfo(fmt.Sprintf("⚠ Found %d possible secret(s) in the code:", len(findings)))
+	for _, f := range findings {
+		fmt.Printf("    line %d: %s %s\n", f.Line, f.Kind, f.Masked())
+	}
+
+	fmt.Print("Replace with placeholders, save as is, or cancel? (r/s/c) [r]: ")
+	var response string
+	fmt.Scanln(&response)
+
+	switch strings.ToLower(strings.TrimSpace(response)) {
+	case "", "r":
+		PrintInfo("Secrets replaced with placeholders")
+		return domain.RedactSecrets(code, findings), true
+	case "s":
+		return code, true
+	default:
+		return code, false
+	}
+}
+
+// warnSecrets lists credentials in snippets that are saved without a
+// prompt, as by import and harvest, and how to redact them. It returns the
+// number of snippets with findings.
+func warnSecrets(snippets []*domain.Snippet) int {
+	found := 0
+	for _, snippet := range snippets {
+		findings := domain.ScanSecrets(snippet.Code())
+		if len(findings) == 0 {
+			continue
+		}
+		if found == 0 {
+			PrintInfo("⚠ Possible secrets:")
+		}
+		found++
+
+		name := "'" + snippet.Title() + "'"
+		if snippet.ID() > 0 {
+			name = fmt.Sprintf("%d %s", snippet.ID(), name)
+		}
+		fmt.Printf("  %s\n", name)
+		for _, f := range findings {
+			fmt.Printf("    line %d: %s %s\n", f.Line, f.Kind, f.Masked())
+		}
+	}
+	if found > 0 {
+		PrintInfo("Use 'snip snippet scan --redact' to replace them with placeholders")
+	}
+	return found
+}
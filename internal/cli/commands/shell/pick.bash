@@ -0,0 +1,18 @@
+# snip pick key binding for bash: Ctrl+S inserts a snippet at the cursor.
+# Load it from ~/.bashrc with: eval "$(snip pick --init bash)"
+
+__snip_pick_widget() {
+  local selected
+  selected="$(snip pick </dev/tty)"
+  if [[ -n $selected ]]; then
+    READLINE_LINE="${READLINE_LINE:0:READLINE_POINT}${selected}${READLINE_LINE:READLINE_POINT}"
+    READLINE_POINT=$((READLINE_POINT + ${#selected}))
+  fi
+}
+
+if [[ $- == *i* ]]; then
+  # Ctrl+S is the terminal's stop-output key unless flow control is off.
+  stty -ixon 2>/dev/null
+  bind -m emacs-standard -x '"\C-s": __snip_pick_widget'
+  bind -m vi-insert -x '"\C-s": __snip_pick_widget'
+fi
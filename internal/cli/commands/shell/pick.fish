@@ -0,0 +1,15 @@
+# snip pick key binding for fish: Ctrl+S inserts a snippet at the cursor.
+# Load it from ~/.config/fish/config.fish with: snip pick --init fish | source
+
+function __snip_pick_widget
+    set -l selected (snip pick </dev/tty | string collect)
+    if test -n "$selected"
+        commandline -i -- $selected
+    end
+    commandline -f repaint
+end
+
+if status is-interactive
+    bind \cs __snip_pick_widget
+    bind -M insert \cs __snip_pick_widget
+end
@@ -5,11 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/7-Dany/snip/internal/cli/config"
 	"github.com/7-Dany/snip/internal/domain"
 	"github.com/7-Dany/snip/internal/storage"
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,12 +23,14 @@ import (
 
 // SnippetCommand handles snippet-related operations.
 type SnippetCommand struct {
-	repos *storage.Repositories
+	repos  *storage.Repositories
+	config *config.Config
 }
 
 // NewSnippetCommand creates a new SnippetCommand instance.
+// It uses the default configuration until NewCLI provides the loaded one.
 func NewSnippetCommand(repos *storage.Repositories) *SnippetCommand {
-	return &SnippetCommand{repos: repos}
+	return &SnippetCommand{repos: repos, config: &config.Config{}}
 }
 
 // manage routes snippet subcommands to the appropriate handler.
@@ -41,14 +48,40 @@ func (sc *SnippetCommand) manage(args []string) {
 		sc.list(subcommandArgs)
 	case "show":
 		sc.show(subcommandArgs)
-	case "create":
-		sc.create()
+	case "create", "add":
+		sc.create(subcommandArgs)
 	case "update":
 		sc.update(subcommandArgs)
 	case "delete":
 		sc.delete(subcommandArgs)
 	case "search":
 		sc.search(subcommandArgs)
+	case "copy":
+		sc.copy(subcommandArgs)
+	case "insert":
+		sc.insert(subcommandArgs)
+	case "favorite":
+		sc.setFavorite(subcommandArgs, true)
+	case "unfavorite":
+		sc.setFavorite(subcommandArgs, false)
+	case "pin":
+		sc.setPinned(subcommandArgs, true)
+	case "unpin":
+		sc.setPinned(subcommandArgs, false)
+	case "recent":
+		sc.recent(subcommandArgs)
+	case "top":
+		sc.top(subcommandArgs)
+	case "dedupe":
+		sc.dedupe(subcommandArgs)
+	case "bulk":
+		sc.bulk(subcommandArgs)
+	case "run":
+		sc.run(subcommandArgs)
+	case "scan":
+		sc.scan(subcommandArgs)
+	case "fork":
+		sc.fork(subcommandArgs)
 	default:
 		PrintError(fmt.Sprintf("Unknown command '%s'. Use 'snip help snippet' for available commands", args[0]))
 	}
@@ -58,6 +91,7 @@ func (sc *SnippetCommand) manage(args []string) {
 func (sc *SnippetCommand) list(args []string) {
 	var categoryID, tagID int
 	var language string
+	var favorites, pinned bool
 
 	// Parse filter flags
 	for i := 0; i < len(args); i++ {
@@ -70,8 +104,12 @@ func (sc *SnippetCommand) list(args []string) {
 			var err error
 			categoryID, err = strconv.Atoi(args[i+1])
 			if err != nil {
-				PrintError(fmt.Sprintf("Invalid category ID '%s'. Must be a number", args[i+1]))
-				return
+				category, pathErr := sc.repos.Categories.FindByPath(args[i+1])
+				if pathErr != nil {
+					PrintError(fmt.Sprintf("Invalid category '%s'. Must be an ID or an existing category path", args[i+1]))
+					return
+				}
+				categoryID = category.ID()
 			}
 			i++
 		case "--tag":
@@ -93,6 +131,10 @@ func (sc *SnippetCommand) list(args []string) {
 			}
 			language = args[i+1]
 			i++
+		case "--favorites":
+			favorites = true
+		case "--pinned":
+			pinned = true
 		}
 	}
 
@@ -100,7 +142,11 @@ func (sc *SnippetCommand) list(args []string) {
 	var err error
 
 	// Apply filters or list all
-	if categoryID > 0 {
+	if favorites {
+		snippets, err = sc.repos.Snippets.FindFavorites()
+	} else if pinned {
+		snippets, err = sc.repos.Snippets.FindPinned()
+	} else if categoryID > 0 {
 		snippets, err = sc.repos.Snippets.FindByCategory(categoryID)
 	} else if tagID > 0 {
 		snippets, err = sc.repos.Snippets.FindByTag(tagID)
@@ -120,6 +166,11 @@ func (sc *SnippetCommand) list(args []string) {
 		return
 	}
 
+	// Pinned snippets always come first
+	sort.SliceStable(snippets, func(i, j int) bool {
+		return snippets[i].IsPinned() && !snippets[j].IsPinned()
+	})
+
 	sc.displaySnippets(snippets)
 }
 
@@ -163,24 +214,291 @@ func (sc *SnippetCommand) show(args []string) {
 
 	tagNames := resolveTagNames(snippet.Tags(), tagMap)
 
+	snippet.RecordView()
+	if err := sc.repos.RecordUsage(snippet); err != nil {
+		PrintError(fmt.Sprintf("Failed to record usage: %v", err))
+	}
+
 	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("📝 %s\n", snippet.Title())
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Printf("Language:    %s\n", snippet.Language())
 	fmt.Printf("Category:    %s\n", categoryName)
 	fmt.Printf("Tags:        %s\n", strings.Join(tagNames, ", "))
+	if aliases := snippet.Aliases(); len(aliases) > 0 {
+		fmt.Printf("Aliases:     %s\n", strings.Join(aliases, ", "))
+	}
+	if source := snippet.Source(); source != "" {
+		fmt.Printf("Source:      %s\n", source)
+	}
+	if sc.repos.Layered() {
+		fmt.Printf("Origin:      %s\n", sc.repos.Origin(snippet.ID()))
+	}
 	fmt.Printf("Description: %s\n", snippet.Description())
 	fmt.Println("\n--- Code ---")
 	fmt.Println(snippet.Code())
 	fmt.Println("--- End ---")
 	fmt.Printf("Created: %s\n", snippet.CreatedAt().Format("2006-01-02 15:04"))
 	fmt.Printf("Updated: %s\n", snippet.UpdatedAt().Format("2006-01-02 15:04"))
+	fmt.Printf("Usage:   %d view(s), %d copy(ies), last used %s\n",
+		snippet.ViewCount(), snippet.CopyCount(), formatLastUsed(snippet.LastUsedAt()))
+	if flags := snippetFlags(snippet); flags != "" {
+		fmt.Printf("Flags:   %s\n", flags)
+	}
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 }
 
-// create creates a new snippet using an interactive form.
-func (sc *SnippetCommand) create() {
-	formData := sc.promptForSnippet(nil)
+// copy copies a snippet's code to the system clipboard.
+func (sc *SnippetCommand) copy(args []string) {
+	snippet := sc.snippetFromArgs(args, "snip snippet copy <id>")
+	if snippet == nil {
+		return
+	}
+
+	if err := clipboard.WriteAll(snippet.Code()); err != nil {
+		PrintError(fmt.Sprintf("Failed to copy to clipboard: %v", err))
+		return
+	}
+
+	snippet.RecordCopy()
+	if err := sc.repos.RecordUsage(snippet); err != nil {
+		PrintError(fmt.Sprintf("Failed to record usage: %v", err))
+		return
+	}
+
+	PrintSuccess(fmt.Sprintf("Copied '%s' to clipboard", snippet.Title()))
+}
+
+// setFavorite marks or unmarks a snippet as a favorite.
+func (sc *SnippetCommand) setFavorite(args []string, favorite bool) {
+	usage := "snip snippet favorite <id>"
+	if !favorite {
+		usage = "snip snippet unfavorite <id>"
+	}
+
+	snippet := sc.snippetFromArgs(args, usage)
+	if snippet == nil || !sc.writable(snippet) {
+		return
+	}
+
+	snippet.SetFavorite(favorite)
+	if err := sc.repos.Snippets.Update(snippet); err != nil {
+		PrintError(fmt.Sprintf("Failed to update snippet: %v", err))
+		return
+	}
+
+	if favorite {
+		PrintSuccess(fmt.Sprintf("Added '%s' to favorites", snippet.Title()))
+	} else {
+		PrintSuccess(fmt.Sprintf("Removed '%s' from favorites", snippet.Title()))
+	}
+}
+
+// setPinned pins or unpins a snippet.
+func (sc *SnippetCommand) setPinned(args []string, pinned bool) {
+	usage := "snip snippet pin <id>"
+	if !pinned {
+		usage = "snip snippet unpin <id>"
+	}
+
+	snippet := sc.snippetFromArgs(args, usage)
+	if snippet == nil || !sc.writable(snippet) {
+		return
+	}
+
+	snippet.SetPinned(pinned)
+	if err := sc.repos.Snippets.Update(snippet); err != nil {
+		PrintError(fmt.Sprintf("Failed to update snippet: %v", err))
+		return
+	}
+
+	if pinned {
+		PrintSuccess(fmt.Sprintf("Pinned '%s'", snippet.Title()))
+	} else {
+		PrintSuccess(fmt.Sprintf("Unpinned '%s'", snippet.Title()))
+	}
+}
+
+// recent lists the most recently used snippets.
+func (sc *SnippetCommand) recent(args []string) {
+	limit, ok := parseLimit(args, defaultUsageLimit)
+	if !ok {
+		return
+	}
+
+	snippets, err := sc.repos.Snippets.FindRecent(limit)
+	if err != nil {
+		PrintError(fmt.Sprintf("failed to load recent snippets: %v", err))
+		return
+	}
+
+	if len(snippets) == 0 {
+		PrintInfo("no snippets used yet, view one with 'snip snippet show <id>'")
+		return
+	}
+
+	sc.displayUsage(snippets)
+}
+
+// top lists the most used snippets.
+func (sc *SnippetCommand) top(args []string) {
+	limit, ok := parseLimit(args, defaultUsageLimit)
+	if !ok {
+		return
+	}
+
+	snippets, err := sc.repos.Snippets.FindMostUsed(limit)
+	if err != nil {
+		PrintError(fmt.Sprintf("failed to load most used snippets: %v", err))
+		return
+	}
+
+	if len(snippets) == 0 {
+		PrintInfo("no snippets used yet, view one with 'snip snippet show <id>'")
+		return
+	}
+
+	sc.displayUsage(snippets)
+}
+
+// defaultUsageLimit is the number of snippets shown by 'recent' and 'top'.
+const defaultUsageLimit = 10
+
+// parseLimit parses an optional positive count argument.
+// It returns false after printing an error if the argument is invalid.
+func parseLimit(args []string, fallback int) (int, bool) {
+	if len(args) == 0 {
+		return fallback, true
+	}
+
+	limit, err := strconv.Atoi(args[0])
+	if err != nil || limit <= 0 {
+		PrintError(fmt.Sprintf("Invalid count '%s'. Must be a positive number", args[0]))
+		return 0, false
+	}
+	return limit, true
+}
+
+// snippetFromArgs parses the snippet ID from args[0] and loads the snippet.
+// It prints an error and returns nil if the ID is missing, invalid or unknown.
+func (sc *SnippetCommand) snippetFromArgs(args []string, usage string) *domain.Snippet {
+	if len(args) == 0 {
+		PrintError(fmt.Sprintf("Missing required argument 'id'. Use '%s'", usage))
+		return nil
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		PrintError(fmt.Sprintf("Invalid ID '%s'. ID must be a number", args[0]))
+		return nil
+	}
+
+	snippet, err := sc.repos.Snippets.FindByID(id)
+	if errors.Is(err, storage.ErrNotFound) {
+		PrintError(fmt.Sprintf("Snippet with ID %d not found", id))
+		return nil
+	}
+
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to find snippet: %v", err))
+		return nil
+	}
+
+	return snippet
+}
+
+// writable reports whether a snippet can be changed. For a snippet of a
+// read-only library it prints how to fork it instead.
+func (sc *SnippetCommand) writable(snippet *domain.Snippet) bool {
+	if !sc.repos.ReadOnly(snippet.ID()) {
+		return true
+	}
+	PrintError(fmt.Sprintf("Snippet %d is in the read-only %s library. Use 'snip snippet fork %d' to edit a personal copy",
+		snippet.ID(), sc.repos.Origin(snippet.ID()), snippet.ID()))
+	return false
+}
+
+// fork copies a snippet, usually one of a read-only library, into the
+// personal library where it can be edited.
+func (sc *SnippetCommand) fork(args []string) {
+	snippet := sc.snippetFromArgs(args, "snip snippet fork <id>")
+	if snippet == nil {
+		return
+	}
+
+	fork, err := sc.repos.Fork(snippet.ID())
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to fork snippet: %v", err))
+		return
+	}
+
+	PrintSuccess(fmt.Sprintf("Forked '%s' into your personal library (ID: %d)", fork.Title(), fork.ID()))
+}
+
+// create creates a new snippet using an interactive form. With --file the
+// form is pre-filled from the file and its language is detected. --from
+// does the same for a line range such as main.go:40-72 and records the
+// file and range as the snippet's source. --layer project saves the
+// snippet in the project library instead of the global one.
+func (sc *SnippetCommand) create(args []string) {
+	const usage = "snip snippet create [--file <path> | --from <path[:start-end]>] [--layer global|project]"
+	form := newSnippetFormModel(nil)
+	source, origin, path := "", storage.OriginGlobal, ""
+	fromRange := false
+
+	for i := 0; i < len(args); i++ {
+		flag := args[i]
+		if (flag != "--file" && flag != "--from" && flag != "--layer") || i+1 >= len(args) {
+			PrintError(fmt.Sprintf("Invalid arguments. Use '%s'", usage))
+			return
+		}
+		switch flag {
+		case "--layer":
+			origin = args[i+1]
+		default:
+			if path != "" {
+				PrintError(fmt.Sprintf("Use either --file or --from. Use '%s'", usage))
+				return
+			}
+			path, fromRange = args[i+1], flag == "--from"
+		}
+		i++
+	}
+
+	target := sc.repos.Layer(origin)
+	if target == nil {
+		if origin == storage.OriginProject {
+			PrintError("No project library found. Create a .snip directory in the project to start one")
+		} else {
+			PrintError(fmt.Sprintf("Unknown layer '%s'. Use global or project", origin))
+		}
+		return
+	}
+
+	if path != "" {
+		code := ""
+		if fromRange {
+			var err error
+			if path, code, source, err = readLineRange(path); err != nil {
+				PrintError(fmt.Sprintf("Failed to read code: %v", err))
+				return
+			}
+		} else {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				PrintError(fmt.Sprintf("Failed to read file: %v", err))
+				return
+			}
+			code = string(data)
+		}
+		if len(code) > snippetCodeLimit {
+			PrintError(fmt.Sprintf("Code is too large. Snippets are limited to %d characters", snippetCodeLimit))
+			return
+		}
+		form.prefillFromFile(path, code)
+	}
+
+	formData := sc.runSnippetForm(form)
 	if formData == nil {
 		PrintInfo("Create cancelled")
 		return
@@ -201,13 +519,37 @@ func (sc *SnippetCommand) create() {
 	if formData.description != "" {
 		snippet.SetDescription(formData.description)
 	}
+	if source != "" {
+		snippet.SetSource(source)
+	}
 
-	if err := sc.repos.Snippets.Create(snippet); err != nil {
+	duplicates, _ := sc.repos.Snippets.FindDuplicates(snippet.Code(), snippet.Language())
+
+	if err := target.Adopt(snippet, sc.repos); err != nil {
 		PrintError(fmt.Sprintf("failed to save snippet: %v", err))
 		return
 	}
 
-	PrintSuccess(fmt.Sprintf("Created snippet '%s' (ID: %d)", formData.title, snippet.ID()))
+	if sc.repos.Layered() {
+		PrintSuccess(fmt.Sprintf("Created snippet '%s' (ID: %d) in the %s library", formData.title, snippet.ID(), origin))
+	} else {
+		PrintSuccess(fmt.Sprintf("Created snippet '%s' (ID: %d)", formData.title, snippet.ID()))
+	}
+	warnDuplicates(duplicates)
+}
+
+// warnDuplicates prints a warning listing existing snippets with identical code.
+func warnDuplicates(duplicates []*domain.Snippet) {
+	if len(duplicates) == 0 {
+		return
+	}
+
+	refs := make([]string, 0, len(duplicates))
+	for _, d := range duplicates {
+		refs = append(refs, fmt.Sprintf("'%s' (ID: %d)", d.Title(), d.ID()))
+	}
+	PrintInfo(fmt.Sprintf("⚠ Identical code already exists in %s. Use 'snip snippet dedupe' to merge duplicates",
+		strings.Join(refs, ", ")))
 }
 
 // update updates an existing snippet using an interactive form.
@@ -234,6 +576,10 @@ func (sc *SnippetCommand) update(args []string) {
 		return
 	}
 
+	if !sc.writable(snippet) {
+		return
+	}
+
 	formData := sc.promptForSnippet(snippet)
 	if formData == nil {
 		PrintInfo("Update cancelled")
@@ -296,6 +642,10 @@ func (sc *SnippetCommand) delete(args []string) {
 		return
 	}
 
+	if !sc.writable(snippet) {
+		return
+	}
+
 	fmt.Printf("Are you sure you want to delete snippet '%s'? (y/n): ", snippet.Title())
 	var response string
 	fmt.Scanln(&response)
@@ -405,7 +755,7 @@ func (sc *SnippetCommand) displaySnippets(snippets []*domain.Snippet) {
 
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
-	t.AppendHeader(table.Row{"ID", "Title", "Language", "Category", "Tags", "Created"})
+	t.AppendHeader(sc.withOrigin(table.Row{"ID", "Title", "Language", "Category", "Tags", "Created"}, "Origin"))
 
 	for _, snippet := range snippets {
 		categoryName := "N/A"
@@ -417,20 +767,82 @@ func (sc *SnippetCommand) displaySnippets(snippets []*domain.Snippet) {
 
 		tagNames := resolveTagNames(snippet.Tags(), tagMap)
 
-		t.AppendRow(table.Row{
+		t.AppendRow(sc.withOrigin(table.Row{
 			snippet.ID(),
-			snippet.Title(),
+			decoratedTitle(snippet),
 			snippet.Language(),
 			categoryName,
 			strings.Join(tagNames, ", "),
 			snippet.CreatedAt().Format("2006-01-02 15:04"),
-		})
+		}, sc.repos.Origin(snippet.ID())))
+	}
+
+	t.SetStyle(table.StyleColoredBright)
+	t.Render()
+}
+
+// displayUsage displays snippets with their usage statistics in a formatted table.
+func (sc *SnippetCommand) displayUsage(snippets []*domain.Snippet) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(sc.withOrigin(table.Row{"ID", "Title", "Language", "Views", "Copies", "Last Used"}, "Origin"))
+
+	for _, snippet := range snippets {
+		t.AppendRow(sc.withOrigin(table.Row{
+			snippet.ID(),
+			decoratedTitle(snippet),
+			snippet.Language(),
+			snippet.ViewCount(),
+			snippet.CopyCount(),
+			formatLastUsed(snippet.LastUsedAt()),
+		}, sc.repos.Origin(snippet.ID())))
 	}
 
 	t.SetStyle(table.StyleColoredBright)
 	t.Render()
 }
 
+// withOrigin appends cell to row when libraries are stacked over the
+// global one, so tables show which library each snippet comes from.
+func (sc *SnippetCommand) withOrigin(row table.Row, cell string) table.Row {
+	if !sc.repos.Layered() {
+		return row
+	}
+	return append(row, cell)
+}
+
+// decoratedTitle prefixes a snippet's title with its pinned and favorite markers.
+func decoratedTitle(snippet *domain.Snippet) string {
+	title := snippet.Title()
+	if snippet.IsFavorite() {
+		title = "★ " + title
+	}
+	if snippet.IsPinned() {
+		title = "📌 " + title
+	}
+	return title
+}
+
+// snippetFlags returns a human-readable list of the snippet's flags.
+func snippetFlags(snippet *domain.Snippet) string {
+	var flags []string
+	if snippet.IsPinned() {
+		flags = append(flags, "📌 pinned")
+	}
+	if snippet.IsFavorite() {
+		flags = append(flags, "★ favorite")
+	}
+	return strings.Join(flags, ", ")
+}
+
+// formatLastUsed formats a last-used timestamp, or "never" for the zero time.
+func formatLastUsed(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
 // resolveTagNames converts tag IDs to names using a pre-loaded map.
 // This is a helper function to avoid N+1 queries.
 func resolveTagNames(tagIDs []int, tagMap map[int]*domain.Tag) []string {
@@ -454,7 +866,12 @@ func resolveTagNames(tagIDs []int, tagMap map[int]*domain.Tag) []string {
 
 // promptForSnippet displays an interactive form for snippet input.
 func (sc *SnippetCommand) promptForSnippet(existing *domain.Snippet) *snippetFormData {
-	p := tea.NewProgram(newSnippetFormModel(existing))
+	return sc.runSnippetForm(newSnippetFormModel(existing))
+}
+
+// runSnippetForm runs the snippet form and validates its values.
+func (sc *SnippetCommand) runSnippetForm(form snippetFormModel) *snippetFormData {
+	p := tea.NewProgram(form)
 	finalModel, err := p.Run()
 	if err != nil {
 		PrintError(fmt.Sprintf("Failed to run form: %v", err))
@@ -476,6 +893,18 @@ func (sc *SnippetCommand) promptForSnippet(existing *domain.Snippet) *snippetFor
 		return nil
 	}
 
+	if m.languageDetected() && !m.guess.Confident() {
+		language = confirmLanguage(m.guess)
+	}
+
+	code, ok := sc.formatCode(language, code)
+	if !ok {
+		return nil
+	}
+	if code, ok = checkSecrets(code); !ok {
+		return nil
+	}
+
 	categoryID := 0
 	if catStr := strings.TrimSpace(m.inputs[2].Value()); catStr != "" {
 		var err error
@@ -520,12 +949,20 @@ type snippetFormData struct {
 	code        string
 }
 
+// snippetCodeLimit is the maximum number of characters in the code field.
+const snippetCodeLimit = 10000
+
 // snippetFormModel is the Bubble Tea model for the snippet form.
 type snippetFormModel struct {
 	inputs    []textinput.Model
 	codeArea  textarea.Model
 	focusIdx  int
 	cancelled bool
+
+	// detect enables language detection while the language is not typed in.
+	detect   bool
+	filename string
+	guess    domain.LanguageGuess
 }
 
 // newSnippetFormModel creates a new snippet form model.
@@ -541,7 +978,7 @@ func newSnippetFormModel(existing *domain.Snippet) snippetFormModel {
 
 	codeArea := textarea.New()
 	codeArea.Placeholder = "Paste or type your code here..."
-	codeArea.CharLimit = 10000
+	codeArea.CharLimit = snippetCodeLimit
 	codeArea.SetWidth(80)
 	codeArea.SetHeight(10)
 
@@ -566,7 +1003,50 @@ func newSnippetFormModel(existing *domain.Snippet) snippetFormModel {
 		inputs:   inputs,
 		codeArea: codeArea,
 		focusIdx: 0,
+		detect:   existing == nil,
+	}
+}
+
+// prefillFromFile fills the title and code from a file and detects its language.
+func (m *snippetFormModel) prefillFromFile(path, code string) {
+	m.filename = path
+	m.inputs[0].SetValue(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	m.codeArea.SetValue(code)
+	m.detectLanguage()
+}
+
+// detectLanguage fills the language field with the detected language unless
+// the user has typed one.
+func (m *snippetFormModel) detectLanguage() {
+	if !m.detect {
+		return
 	}
+	current := strings.TrimSpace(m.inputs[1].Value())
+	if current != "" && !m.languageDetected() {
+		return
+	}
+
+	m.guess = domain.DetectLanguage(m.filename, m.codeArea.Value())
+	m.inputs[1].SetValue(m.guess.Language)
+}
+
+// languageDetected reports whether the language field holds the detected language.
+func (m snippetFormModel) languageDetected() bool {
+	return m.detect && m.guess.Language != "" &&
+		strings.TrimSpace(m.inputs[1].Value()) == m.guess.Language
+}
+
+// confirmLanguage asks the user to accept or replace a low-confidence guess.
+func confirmLanguage(guess domain.LanguageGuess) string {
+	fmt.Printf("Detected language '%s' with low confidence (%.0f%%). Press Enter to accept or type the language: ",
+		guess.Language, guess.Confidence*100)
+
+	var answer string
+	fmt.Scanln(&answer)
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer
+	}
+	return guess.Language
 }
 
 // Init initializes the snippet form model.
@@ -625,6 +1105,7 @@ func (m snippetFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.inputs[m.focusIdx], cmd = m.inputs[m.focusIdx].Update(msg)
 	} else {
 		m.codeArea, cmd = m.codeArea.Update(msg)
+		m.detectLanguage()
 	}
 
 	return m, cmd
@@ -635,7 +1116,7 @@ func (m snippetFormModel) View() string {
 	return fmt.Sprintf(
 		"\n📝 Snippet Form\n\n"+
 			"Title:       %s\n"+
-			"Language:    %s\n"+
+			"Language:    %s%s\n"+
 			"Category ID: %s\n"+
 			"Tag IDs:     %s\n"+
 			"Description: %s\n\n"+
@@ -643,9 +1124,22 @@ func (m snippetFormModel) View() string {
 			"(Tab/Shift+Tab to navigate, Enter to submit, Esc to cancel)\n",
 		m.inputs[0].View(),
 		m.inputs[1].View(),
+		m.detectionHint(),
 		m.inputs[2].View(),
 		m.inputs[3].View(),
 		m.inputs[4].View(),
 		m.codeArea.View(),
 	)
 }
+
+// detectionHint describes the detected language shown next to the language field.
+func (m snippetFormModel) detectionHint() string {
+	if !m.languageDetected() {
+		return ""
+	}
+	hint := fmt.Sprintf("  (detected from %s, %.0f%% confidence)", m.guess.Source, m.guess.Confidence*100)
+	if !m.guess.Confident() {
+		hint += " – will ask to confirm"
+	}
+	return hint
+}
@@ -2,9 +2,11 @@
 package commands
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/7-Dany/snip/internal/domain"
+	"github.com/7-Dany/snip/internal/storage"
 )
 
 func TestNewSnippetCommand(t *testing.T) {
@@ -215,6 +217,111 @@ func TestSnippetCommand_search(t *testing.T) {
 	})
 }
 
+func TestSnippetCommand_setFavorite(t *testing.T) {
+	t.Run("validates ID is required", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+		sc.setFavorite([]string{}, true)
+	})
+
+	t.Run("shows error when snippet not found", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+		sc.setFavorite([]string{"999"}, true)
+	})
+
+	t.Run("marks and unmarks favorite", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+
+		snip, _ := domain.NewSnippet("Test Snippet", "go", "func test() {}")
+		repos.Snippets.Create(snip)
+
+		sc.setFavorite([]string{"1"}, true)
+		if favorites, _ := repos.Snippets.FindFavorites(); len(favorites) != 1 {
+			t.Errorf("expected 1 favorite, got %d", len(favorites))
+		}
+
+		sc.setFavorite([]string{"1"}, false)
+		if favorites, _ := repos.Snippets.FindFavorites(); len(favorites) != 0 {
+			t.Errorf("expected 0 favorites, got %d", len(favorites))
+		}
+	})
+}
+
+func TestSnippetCommand_setPinned(t *testing.T) {
+	t.Run("pins and unpins snippet", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+
+		snip, _ := domain.NewSnippet("Test Snippet", "go", "func test() {}")
+		repos.Snippets.Create(snip)
+
+		sc.setPinned([]string{"1"}, true)
+		if pinned, _ := repos.Snippets.FindPinned(); len(pinned) != 1 {
+			t.Errorf("expected 1 pinned snippet, got %d", len(pinned))
+		}
+
+		sc.list([]string{"--pinned"})
+		sc.list([]string{"--favorites"})
+
+		sc.setPinned([]string{"1"}, false)
+		if pinned, _ := repos.Snippets.FindPinned(); len(pinned) != 0 {
+			t.Errorf("expected 0 pinned snippets, got %d", len(pinned))
+		}
+	})
+}
+
+func TestSnippetCommand_usage(t *testing.T) {
+	t.Run("show records a view", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+
+		snip, _ := domain.NewSnippet("Test Snippet", "go", "func test() {}")
+		repos.Snippets.Create(snip)
+
+		sc.show([]string{"1"})
+
+		found, _ := repos.Snippets.FindByID(1)
+		if found.ViewCount() != 1 {
+			t.Errorf("expected 1 view, got %d", found.ViewCount())
+		}
+	})
+
+	t.Run("copy does not panic without clipboard", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+
+		snip, _ := domain.NewSnippet("Test Snippet", "go", "func test() {}")
+		repos.Snippets.Create(snip)
+
+		sc.copy([]string{"1"})
+		sc.copy([]string{})
+	})
+
+	t.Run("lists recent and top snippets", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+
+		sc.recent([]string{})
+		sc.top([]string{})
+
+		snip, _ := domain.NewSnippet("Test Snippet", "go", "func test() {}")
+		repos.Snippets.Create(snip)
+		sc.show([]string{"1"})
+
+		sc.recent([]string{})
+		sc.top([]string{"5"})
+	})
+
+	t.Run("validates limit", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		sc := NewSnippetCommand(repos)
+		sc.recent([]string{"abc"})
+		sc.top([]string{"-1"})
+	})
+}
+
 func TestSnippetCommand_manage(t *testing.T) {
 	repos := setupTestRepos(t)
 	sc := NewSnippetCommand(repos)
@@ -282,3 +389,129 @@ func TestSnippetCommand_getTagNames(t *testing.T) {
 		}
 	})
 }
+
+func TestSnippetCommand_create(t *testing.T) {
+	repos := setupTestRepos(t)
+	sc := NewSnippetCommand(repos)
+
+	t.Run("validates arguments", func(t *testing.T) {
+		sc.create([]string{"--file"})
+		sc.create([]string{"--other", "x"})
+		sc.create([]string{"--file", "/nonexistent/snippet.go"})
+		sc.create([]string{"--from", "/nonexistent/snippet.go:1-2"})
+		sc.create([]string{"--file", "a.go", "--from", "b.go:1-2"})
+		sc.manage([]string{"add", "--from"})
+	})
+
+	t.Run("validates the layer", func(t *testing.T) {
+		// Should show errors, not panic
+		sc.create([]string{"--layer", "project"})
+		sc.create([]string{"--layer", "team"})
+		sc.create([]string{"--layer"})
+	})
+}
+
+func TestSnippetFormModel_detectLanguage(t *testing.T) {
+	t.Run("prefills from a file", func(t *testing.T) {
+		m := newSnippetFormModel(nil)
+		m.prefillFromFile("/tmp/retry.py", "print('hi')")
+
+		if got := m.inputs[0].Value(); got != "retry" {
+			t.Errorf("expected title 'retry', got %q", got)
+		}
+		if got := m.inputs[1].Value(); got != "python" {
+			t.Errorf("expected language 'python', got %q", got)
+		}
+		if !m.languageDetected() {
+			t.Error("expected language to be marked as detected")
+		}
+	})
+
+	t.Run("keeps a typed language", func(t *testing.T) {
+		m := newSnippetFormModel(nil)
+		m.inputs[1].SetValue("text")
+		m.codeArea.SetValue("#!/bin/bash\necho hi")
+		m.detectLanguage()
+
+		if got := m.inputs[1].Value(); got != "text" {
+			t.Errorf("expected typed language to be kept, got %q", got)
+		}
+	})
+
+	t.Run("does not detect when editing", func(t *testing.T) {
+		snippet, _ := domain.NewSnippet("Script", "text", "#!/bin/bash\necho hi")
+		m := newSnippetFormModel(snippet)
+		m.detectLanguage()
+
+		if got := m.inputs[1].Value(); got != "text" {
+			t.Errorf("expected existing language to be kept, got %q", got)
+		}
+	})
+}
+
+func TestSnippetCommand_layers(t *testing.T) {
+	repos := setupTestRepos(t)
+	repos.AddLayer(storage.OriginProject, storage.NewLayer(t.TempDir()+"/project.json", storage.ProjectIDBase))
+	sc := NewSnippetCommand(repos)
+
+	global, _ := domain.NewSnippet("Global", "go", "fmt.Println()")
+	repos.Snippets.Create(global)
+	project, _ := domain.NewSnippet("Project", "bash", "make build")
+	repos.Layer(storage.OriginProject).Snippets.Create(project)
+
+	t.Run("shows origins", func(t *testing.T) {
+		// Should not panic
+		sc.list(nil)
+		sc.show([]string{strconv.Itoa(project.ID())})
+		sc.recent(nil)
+	})
+}
+
+func TestSnippetCommand_readOnly(t *testing.T) {
+	teamFile := t.TempDir() + "/team.json"
+	team := storage.New(teamFile)
+	shared, _ := domain.NewSnippet("Shared", "bash", "kubectl get pods")
+	team.Snippets.Create(shared)
+	if err := team.Save(); err != nil {
+		t.Fatalf("failed to save team library: %v", err)
+	}
+
+	repos := setupTestRepos(t)
+	repos.AddLayer(storage.OriginTeam, storage.NewReadOnlyLayer(teamFile, storage.TeamIDBase))
+	if err := repos.Load(); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	sc := NewSnippetCommand(repos)
+	id := strconv.Itoa(storage.TeamIDBase + 1)
+
+	t.Run("refuses to change read-only snippets", func(t *testing.T) {
+		// Should print errors before prompting, not panic
+		sc.manage([]string{"favorite", id})
+		sc.manage([]string{"pin", id})
+		sc.manage([]string{"update", id})
+		sc.manage([]string{"delete", id})
+		sc.manage([]string{"show", id})
+
+		snippet, err := repos.Snippets.FindByID(storage.TeamIDBase + 1)
+		if err != nil || snippet.IsFavorite() || snippet.IsPinned() {
+			t.Errorf("expected the team snippet unchanged, got %v (%v)", snippet, err)
+		}
+	})
+
+	t.Run("forks a read-only snippet", func(t *testing.T) {
+		sc.manage([]string{"fork", id})
+
+		personal, _ := repos.Layer(storage.OriginGlobal).Snippets.List()
+		if len(personal) != 1 || personal[0].Code() != "kubectl get pods" {
+			t.Fatalf("expected a personal copy, got %v", personal)
+		}
+		sc.manage([]string{"pin", strconv.Itoa(personal[0].ID())})
+		if !personal[0].IsPinned() {
+			t.Error("expected the fork to be editable")
+		}
+
+		// Should print an error, not panic
+		sc.manage([]string{"fork"})
+		sc.manage([]string{"fork", "999"})
+	})
+}
@@ -0,0 +1,176 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/7-Dany/snip/internal/storage"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// defaultStatsRecentLimit is the number of recently added/updated snippets reported.
+const defaultStatsRecentLimit = 5
+
+// StatsCommand handles library statistics reporting.
+type StatsCommand struct {
+	repos *storage.Repositories
+}
+
+// NewStatsCommand creates a new StatsCommand instance.
+func NewStatsCommand(repos *storage.Repositories) *StatsCommand {
+	return &StatsCommand{repos: repos}
+}
+
+// manage parses stats flags and prints the report.
+func (stc *StatsCommand) manage(args []string) {
+	asJSON := false
+	limit := defaultStatsRecentLimit
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--json":
+			asJSON = true
+		case "--recent":
+			if i+1 >= len(args) {
+				PrintError("Flag '--recent' requires a value. Use 'snip stats --recent <n>'")
+				return
+			}
+			n, ok := parseLimit(args[i+1:i+2], defaultStatsRecentLimit)
+			if !ok {
+				return
+			}
+			limit = n
+			i++
+		default:
+			PrintError(fmt.Sprintf("Unknown flag '%s'. Use 'snip help stats' for usage", args[i]))
+			return
+		}
+	}
+
+	stats, err := stc.repos.Stats(limit)
+	if err != nil {
+		PrintError(fmt.Sprintf("Failed to compute statistics: %v", err))
+		return
+	}
+
+	if asJSON {
+		if err := writeStatsJSON(os.Stdout, stats); err != nil {
+			PrintError(fmt.Sprintf("Failed to encode statistics: %v", err))
+		}
+		return
+	}
+
+	stc.display(stats)
+}
+
+// writeStatsJSON writes the statistics report as indented JSON.
+func writeStatsJSON(w io.Writer, stats *domain.LibraryStats) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(stats)
+}
+
+// display prints the statistics report as a series of tables.
+func (stc *StatsCommand) display(stats *domain.LibraryStats) {
+	heading := color.New(color.FgCyan, color.Bold)
+
+	heading.Println("\nOVERVIEW")
+	renderTable(
+		table.Row{"Snippets", "Categories", "Tags", "Lines of Code"},
+		[]table.Row{{stats.TotalSnippets, stats.TotalCategories, stats.TotalTags, stats.TotalLines}},
+	)
+
+	if stats.TotalSnippets == 0 {
+		PrintInfo("no snippets found, create one with 'snip snippet create'")
+		return
+	}
+
+	heading.Println("\nBY LANGUAGE")
+	renderTable(table.Row{"Language", "Snippets"}, statCountRows(stats.ByLanguage))
+
+	if len(stats.ByCategory) > 0 {
+		heading.Println("\nBY CATEGORY")
+		renderTable(table.Row{"Category", "Snippets"}, statCountRows(stats.ByCategory))
+	}
+
+	if len(stats.ByTag) > 0 {
+		heading.Println("\nBY TAG")
+		renderTable(table.Row{"Tag", "Snippets"}, statCountRows(stats.ByTag))
+	}
+
+	heading.Println("\nRECENTLY ADDED")
+	renderTable(table.Row{"ID", "Title", "Language", "Created"}, summaryRows(stats.RecentlyAdded, false))
+
+	heading.Println("\nRECENTLY UPDATED")
+	renderTable(table.Row{"ID", "Title", "Language", "Updated"}, summaryRows(stats.RecentlyUpdated, true))
+
+	heading.Println("\nHOUSEKEEPING")
+	fmt.Printf("  Untagged:      %d %s\n", len(stats.Untagged), summaryIDs(stats.Untagged))
+	fmt.Printf("  Uncategorized: %d %s\n", len(stats.Uncategorized), summaryIDs(stats.Uncategorized))
+
+	if len(stats.Orphaned) == 0 {
+		fmt.Println("  Orphaned references: 0")
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("  Orphaned references: %d\n", len(stats.Orphaned))
+	rows := make([]table.Row, 0, len(stats.Orphaned))
+	for _, o := range stats.Orphaned {
+		rows = append(rows, table.Row{o.SnippetID, o.SnippetTitle, o.Kind, o.RefID})
+	}
+	renderTable(table.Row{"Snippet ID", "Title", "Missing", "Ref ID"}, rows)
+	fmt.Println()
+}
+
+// renderTable prints rows under the given header using the standard style.
+func renderTable(header table.Row, rows []table.Row) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(header)
+	t.AppendRows(rows)
+	t.SetStyle(table.StyleColoredBright)
+	t.Render()
+}
+
+// statCountRows converts stat counts into table rows.
+func statCountRows(counts []domain.StatCount) []table.Row {
+	rows := make([]table.Row, 0, len(counts))
+	for _, c := range counts {
+		rows = append(rows, table.Row{c.Name, c.Count})
+	}
+	return rows
+}
+
+// summaryRows converts snippet summaries into table rows, showing either
+// the created or the updated timestamp.
+func summaryRows(summaries []domain.SnippetSummary, updated bool) []table.Row {
+	rows := make([]table.Row, 0, len(summaries))
+	for _, s := range summaries {
+		at := s.CreatedAt
+		if updated {
+			at = s.UpdatedAt
+		}
+		rows = append(rows, table.Row{s.ID, s.Title, s.Language, at.Format("2006-01-02 15:04")})
+	}
+	return rows
+}
+
+// summaryIDs formats snippet IDs as "(#1, #2)", or an empty string if there are none.
+func summaryIDs(summaries []domain.SnippetSummary) string {
+	if len(summaries) == 0 {
+		return ""
+	}
+
+	ids := make([]string, 0, len(summaries))
+	for _, s := range summaries {
+		ids = append(ids, fmt.Sprintf("#%d", s.ID))
+	}
+	return "(" + strings.Join(ids, ", ") + ")"
+}
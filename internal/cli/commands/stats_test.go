@@ -0,0 +1,96 @@
+// Package commands provides the CLI command handlers for SNIP.
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+func TestNewStatsCommand(t *testing.T) {
+	t.Run("creates stats command with repos", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		stc := NewStatsCommand(repos)
+
+		if stc == nil {
+			t.Fatal("NewStatsCommand returned nil")
+		}
+		if stc.repos != repos {
+			t.Error("repos not set correctly")
+		}
+	})
+}
+
+func TestStatsCommand_manage(t *testing.T) {
+	t.Run("shows stats for empty library", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		stc := NewStatsCommand(repos)
+		stc.manage([]string{})
+	})
+
+	t.Run("shows stats as table and JSON", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		stc := NewStatsCommand(repos)
+
+		cat, _ := domain.NewCategory("algorithms")
+		repos.Categories.Create(cat)
+
+		tag, _ := domain.NewTag("sorting")
+		repos.Tags.Create(tag)
+
+		snip1, _ := domain.NewSnippet("Quicksort", "go", "func quicksort() {}")
+		snip1.SetCategory(cat.ID())
+		snip1.AddTag(tag.ID())
+		repos.Snippets.Create(snip1)
+
+		snip2, _ := domain.NewSnippet("Orphan", "python", "pass")
+		snip2.SetCategory(99)
+		repos.Snippets.Create(snip2)
+
+		stc.manage([]string{})
+		stc.manage([]string{"--json"})
+		stc.manage([]string{"--recent", "1"})
+	})
+
+	t.Run("validates flags", func(t *testing.T) {
+		repos := setupTestRepos(t)
+		stc := NewStatsCommand(repos)
+
+		stc.manage([]string{"--recent"})
+		stc.manage([]string{"--recent", "zero"})
+		stc.manage([]string{"--unknown"})
+	})
+}
+
+func TestWriteStatsJSON(t *testing.T) {
+	t.Run("encodes stats report", func(t *testing.T) {
+		repos := setupTestRepos(t)
+
+		snip, _ := domain.NewSnippet("Quicksort", "go", "line1\nline2")
+		repos.Snippets.Create(snip)
+
+		stats, err := repos.Stats(defaultStatsRecentLimit)
+		if err != nil {
+			t.Fatalf("Stats failed: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := writeStatsJSON(&buf, stats); err != nil {
+			t.Fatalf("writeStatsJSON failed: %v", err)
+		}
+
+		var decoded domain.LibraryStats
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("output is not valid JSON: %v", err)
+		}
+
+		if decoded.TotalSnippets != 1 || decoded.TotalLines != 2 {
+			t.Errorf("unexpected totals: %+v", decoded)
+		}
+		if len(decoded.ByLanguage) != 1 || decoded.ByLanguage[0].Name != "go" {
+			t.Errorf("unexpected languages: %+v", decoded.ByLanguage)
+		}
+	})
+}
@@ -1,8 +1,10 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -15,6 +17,10 @@ type FocusChangedMsg struct {
 	FieldLine    int // Approximate line number of the field
 }
 
+// LanguageDetector guesses the language of code. confident reports whether
+// the guess is reliable enough to use without asking the user.
+type LanguageDetector func(code string) (language string, confidence float64, confident bool)
+
 // CodeEditor provides a multi-input form for creating/editing code snippets.
 type CodeEditor struct {
 	titleInput       textinput.Model
@@ -30,6 +36,21 @@ type CodeEditor struct {
 	categoryName string
 	tagIDs       []int
 	tagNames     []string
+
+	// Language detection
+	detector          LanguageDetector
+	guess             string
+	guessConfidence   float64
+	guessConfident    bool
+	languageConfirmed bool
+
+	// Code check error shown below the code, and the code it applies to
+	codeError     string
+	codeErrorCode string
+
+	// Code warning shown below the code, and the code it applies to
+	codeWarning     string
+	codeWarningCode string
 }
 
 // NewCodeEditor creates a new code editor form.
@@ -95,6 +116,94 @@ func (ce *CodeEditor) SetValues(title, language, description, code string) {
 	ce.codeArea.SetValue(code)
 }
 
+// SetLanguageSuggestions enables autocompletion of the language field.
+// The suggestion matching the typed prefix is accepted with → and cycled
+// with ↑/↓.
+func (ce *CodeEditor) SetLanguageSuggestions(languages []string) {
+	ce.languageInput.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("right"))
+	ce.languageInput.ShowSuggestions = true
+	ce.languageInput.SetSuggestions(languages)
+}
+
+// SetLanguageDetector enables language detection. While the language field
+// is empty or holds the last guess, it is filled from the code as it changes.
+func (ce *CodeEditor) SetLanguageDetector(detector LanguageDetector) {
+	ce.detector = detector
+	ce.detectLanguage()
+}
+
+// detectLanguage fills the language field with the detected language unless
+// the user has typed one.
+func (ce *CodeEditor) detectLanguage() {
+	if ce.detector == nil {
+		return
+	}
+	current := strings.TrimSpace(ce.languageInput.Value())
+	if current != "" && !ce.languageDetected() {
+		return
+	}
+
+	language, confidence, confident := ce.detector(ce.codeArea.Value())
+	if language != ce.guess {
+		ce.languageConfirmed = false
+	}
+	ce.guess = language
+	ce.guessConfidence = confidence
+	ce.guessConfident = confident
+	ce.languageInput.SetValue(language)
+}
+
+// languageDetected reports whether the language field holds the detected language.
+func (ce CodeEditor) languageDetected() bool {
+	return ce.detector != nil && ce.guess != "" &&
+		strings.TrimSpace(ce.languageInput.Value()) == ce.guess
+}
+
+// DetectedLanguage returns the detected language and its confidence (0 to 1).
+func (ce CodeEditor) DetectedLanguage() (string, float64) {
+	return ce.guess, ce.guessConfidence
+}
+
+// NeedsLanguageConfirmation returns true if the language field holds a
+// low-confidence guess the user has not confirmed yet.
+func (ce CodeEditor) NeedsLanguageConfirmation() bool {
+	return ce.languageDetected() && !ce.guessConfident && !ce.languageConfirmed
+}
+
+// ConfirmLanguage marks the current guess as confirmed by the user.
+func (ce *CodeEditor) ConfirmLanguage() {
+	ce.languageConfirmed = true
+}
+
+// SetCodeError shows a code check error below the code area. It is
+// cleared as soon as the code changes.
+func (ce *CodeEditor) SetCodeError(message string) {
+	ce.codeError = message
+	ce.codeErrorCode = ce.codeArea.Value()
+}
+
+// HasCodeError returns true if a code check error is shown for the current code.
+func (ce CodeEditor) HasCodeError() bool {
+	return ce.codeError != "" && ce.codeErrorCode == ce.codeArea.Value()
+}
+
+// SetCodeWarning shows a warning below the code area. Like a code error,
+// it is cleared as soon as the code changes.
+func (ce *CodeEditor) SetCodeWarning(message string) {
+	ce.codeWarning = message
+	ce.codeWarningCode = ce.codeArea.Value()
+}
+
+// HasCodeWarning returns true if a warning is shown for the current code.
+func (ce CodeEditor) HasCodeWarning() bool {
+	return ce.codeWarning != "" && ce.codeWarningCode == ce.codeArea.Value()
+}
+
+// SetCode replaces the code, keeping the other fields.
+func (ce *CodeEditor) SetCode(code string) {
+	ce.codeArea.SetValue(code)
+}
+
 // SetCategory sets the category for the snippet.
 func (ce *CodeEditor) SetCategory(id int, name string) {
 	ce.categoryID = id
@@ -227,7 +336,7 @@ func (ce *CodeEditor) Update(msg tea.Msg) (CodeEditor, tea.Cmd) {
 		// If on buttons, handle button-specific keys
 		if ce.focusedField >= 4 {
 			switch msg.String() {
-			case "enter", "esc", "ctrl+s", "alt+s", "alt+c", "alt+t":
+			case "enter", "esc", "ctrl+s", "alt+s", "alt+c", "alt+t", "alt+r":
 				// Let parent handle these when on buttons
 				return *ce, nil
 			}
@@ -236,7 +345,7 @@ func (ce *CodeEditor) Update(msg tea.Msg) (CodeEditor, tea.Cmd) {
 		// If on text input fields, only let parent handle specific control keys
 		if ce.focusedField < 4 {
 			switch msg.String() {
-			case "ctrl+s", "alt+s", "esc", "alt+c", "alt+t":
+			case "ctrl+s", "alt+s", "esc", "alt+c", "alt+t", "alt+r":
 				// These are always handled by parent
 				return *ce, nil
 			case "enter":
@@ -265,6 +374,13 @@ func (ce *CodeEditor) Update(msg tea.Msg) (CodeEditor, tea.Cmd) {
 		case 3:
 			ce.codeArea, cmd = ce.codeArea.Update(msg)
 			cmds = append(cmds, cmd)
+			ce.detectLanguage()
+			if ce.codeError != "" && !ce.HasCodeError() {
+				ce.codeError = ""
+			}
+			if ce.codeWarning != "" && !ce.HasCodeWarning() {
+				ce.codeWarning = ""
+			}
 		}
 	}
 
@@ -321,6 +437,14 @@ func (ce CodeEditor) View() string {
 	} else {
 		b.WriteString(fieldStyle.Render(ce.languageInput.View()))
 	}
+	if ce.languageDetected() {
+		b.WriteString("\n")
+		hint := fmt.Sprintf("  Detected from code (%.0f%% confidence)", ce.guessConfidence*100)
+		if !ce.guessConfident && !ce.languageConfirmed {
+			hint += " – you will be asked to confirm"
+		}
+		b.WriteString(metaStyle.Render(hint))
+	}
 	b.WriteString("\n\n")
 
 	// Description field
@@ -374,7 +498,20 @@ func (ce CodeEditor) View() string {
 		codeBox = focusedFieldStyle
 	}
 	b.WriteString(codeBox.Render(ce.codeArea.View()))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if ce.HasCodeError() {
+		b.WriteString(lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9")).
+			Render("  ✗ " + ce.codeError))
+		b.WriteString("\n")
+	}
+	if ce.HasCodeWarning() {
+		b.WriteString(lipgloss.NewStyle().
+			Foreground(lipgloss.Color("11")).
+			Render("  ⚠ " + ce.codeWarning))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Buttons
 	cancelButton := lipgloss.NewStyle().
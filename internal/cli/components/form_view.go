@@ -10,6 +10,7 @@ type FormView struct {
 	title      string
 	subtitle   string
 	input      textinput.Model
+	label      string
 	actionHint string
 }
 
@@ -24,6 +25,7 @@ func NewFormView(title, subtitle, placeholder string) FormView {
 		title:      title,
 		subtitle:   subtitle,
 		input:      ti,
+		label:      "Name",
 		actionHint: "Enter: save | Esc: cancel",
 	}
 }
@@ -32,6 +34,11 @@ func (f *FormView) SetValue(value string) {
 	f.input.SetValue(value)
 }
 
+// SetLabel sets the label shown before the input. It defaults to "Name".
+func (f *FormView) SetLabel(label string) {
+	f.label = label
+}
+
 func (f FormView) Value() string {
 	return f.input.Value()
 }
@@ -57,7 +64,7 @@ func (f FormView) View() string {
 		b += f.subtitle + "\n\n"
 	}
 
-	b += "Name: " + f.input.View() + "\n\n"
+	b += f.label + ": " + f.input.View() + "\n\n"
 
 	b += lipgloss.NewStyle().
 		Foreground(lipgloss.Color("240")).
@@ -10,12 +10,14 @@ import (
 )
 
 // SearchableTableView is a table component with integrated search functionality.
-// It provides real-time filtering as the user types.
+// It provides real-time filtering as the user types, and multi-row selection
+// keyed by the first column of each row.
 type SearchableTableView struct {
 	table        table.Model
 	searchInput  textinput.Model
-	allRows      []table.Row // Store all rows for filtering
-	filteredRows []table.Row // Currently displayed rows
+	allRows      []table.Row     // Store all rows for filtering
+	filteredRows []table.Row     // Currently displayed rows
+	selected     map[string]bool // Selected rows, keyed by their first column
 	title        string
 	emptyMsg     string
 	actionHint   string
@@ -55,6 +57,7 @@ func NewSearchableTableView(columns []table.Column, title, emptyMsg, actionHint
 		searchInput:  si,
 		allRows:      []table.Row{},
 		filteredRows: []table.Row{},
+		selected:     make(map[string]bool),
 		title:        title,
 		emptyMsg:     emptyMsg,
 		actionHint:   actionHint,
@@ -64,15 +67,112 @@ func NewSearchableTableView(columns []table.Column, title, emptyMsg, actionHint
 }
 
 // SetRows sets all rows and resets search filter.
+// Selected rows that no longer exist are deselected.
 func (stv *SearchableTableView) SetRows(rows []table.Row) {
 	stv.allRows = rows
 	stv.filteredRows = rows
-	stv.table.SetRows(rows)
+
+	existing := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		if len(row) > 0 {
+			existing[row[0]] = true
+		}
+	}
+	for key := range stv.selected {
+		if !existing[key] {
+			delete(stv.selected, key)
+		}
+	}
+
+	stv.renderRows()
 }
 
-// SelectedRow returns the currently selected row.
+// SelectedRow returns the row under the cursor.
 func (stv SearchableTableView) SelectedRow() table.Row {
-	return stv.table.SelectedRow()
+	cursor := stv.table.Cursor()
+	if cursor < 0 || cursor >= len(stv.filteredRows) {
+		return nil
+	}
+	return stv.filteredRows[cursor]
+}
+
+// ToggleSelected toggles the selection of the row under the cursor
+// and moves the cursor to the next row.
+func (stv *SearchableTableView) ToggleSelected() {
+	row := stv.SelectedRow()
+	if len(row) == 0 {
+		return
+	}
+
+	if stv.selected[row[0]] {
+		delete(stv.selected, row[0])
+	} else {
+		stv.selected[row[0]] = true
+	}
+	stv.renderRows()
+	stv.table.MoveDown(1)
+}
+
+// SelectAllVisible selects every row matching the current filter.
+// If all of them are already selected, they are deselected instead.
+func (stv *SearchableTableView) SelectAllVisible() {
+	allSelected := true
+	for _, row := range stv.filteredRows {
+		if len(row) > 0 && !stv.selected[row[0]] {
+			allSelected = false
+			break
+		}
+	}
+
+	for _, row := range stv.filteredRows {
+		if len(row) == 0 {
+			continue
+		}
+		if allSelected {
+			delete(stv.selected, row[0])
+		} else {
+			stv.selected[row[0]] = true
+		}
+	}
+	stv.renderRows()
+}
+
+// ClearSelection deselects all rows.
+func (stv *SearchableTableView) ClearSelection() {
+	stv.selected = make(map[string]bool)
+	stv.renderRows()
+}
+
+// SelectedKeys returns the first column of every selected row, in row order.
+func (stv SearchableTableView) SelectedKeys() []string {
+	keys := make([]string, 0, len(stv.selected))
+	for _, row := range stv.allRows {
+		if len(row) > 0 && stv.selected[row[0]] {
+			keys = append(keys, row[0])
+		}
+	}
+	return keys
+}
+
+// SelectionCount returns the number of selected rows.
+func (stv SearchableTableView) SelectionCount() int {
+	return len(stv.selected)
+}
+
+// renderRows shows the filtered rows in the table, marking selected ones.
+func (stv *SearchableTableView) renderRows() {
+	rows := make([]table.Row, len(stv.filteredRows))
+	for i, row := range stv.filteredRows {
+		if len(row) == 0 || !stv.selected[row[0]] {
+			rows[i] = row
+			continue
+		}
+		marked := make(table.Row, len(row))
+		copy(marked, row)
+		marked[0] = "✓ " + row[0]
+		rows[i] = marked
+	}
+	stv.table.SetRows(rows)
 }
 
 // ToggleSearch enables/disables search mode.
@@ -89,6 +189,27 @@ func (stv *SearchableTableView) ToggleSearch() {
 	}
 }
 
+// StartSearch enables search mode with an initial query and filters the rows.
+func (stv *SearchableTableView) StartSearch(query string) {
+	stv.searchActive = true
+	stv.searchInput.Focus()
+	stv.searchInput.SetValue(query)
+	stv.searchInput.CursorEnd()
+	stv.table.Blur()
+	stv.filterRows()
+}
+
+// MoveCursor moves the table cursor down by n rows, or up if n is negative.
+// It works while search mode is active, so a picker can be navigated while
+// typing.
+func (stv *SearchableTableView) MoveCursor(n int) {
+	if n < 0 {
+		stv.table.MoveUp(-n)
+	} else {
+		stv.table.MoveDown(n)
+	}
+}
+
 // IsSearchActive returns whether search mode is active.
 func (stv SearchableTableView) IsSearchActive() bool {
 	return stv.searchActive
@@ -105,7 +226,7 @@ func (stv *SearchableTableView) filterRows() {
 
 	if query == "" {
 		stv.filteredRows = stv.allRows
-		stv.table.SetRows(stv.allRows)
+		stv.renderRows()
 		return
 	}
 
@@ -125,7 +246,7 @@ func (stv *SearchableTableView) filterRows() {
 	}
 
 	stv.filteredRows = filtered
-	stv.table.SetRows(filtered)
+	stv.renderRows()
 }
 
 // Update handles messages and updates state.
@@ -210,6 +331,9 @@ func (stv SearchableTableView) View() string {
 		// Show that filter is active
 		actionHint = "Filtered: \"" + stv.searchInput.Value() + "\" | " + stv.actionHint
 	}
+	if !stv.searchActive && len(stv.selected) > 0 {
+		actionHint = formatInt(len(stv.selected)) + " selected | " + actionHint
+	}
 
 	b.WriteString(lipgloss.NewStyle().
 		Foreground(lipgloss.Color("240")).
@@ -13,6 +13,10 @@ type TabModel interface {
 	tea.Model
 }
 
+// TabShownMsg is sent to a tab when it becomes the active tab, so it can
+// refresh content that other tabs may have changed.
+type TabShownMsg struct{}
+
 // Tabs represents the main tabbed interface component.
 type Tabs struct {
 	labels     []string
@@ -26,6 +30,12 @@ type Tabs struct {
 	styles     *TabStyles
 	focusMode  bool
 	xOffset    int
+
+	// Switcher shown after the tabs, such as the snippet library
+	switcherName    string
+	switcherOptions []string
+	switcherCurrent int
+	switched        string
 }
 
 // TabStyles contains all styling configuration for the tabs component.
@@ -125,6 +135,26 @@ func (t *Tabs) EnableDebug(enabled bool) {
 	t.debug = enabled
 }
 
+// SetSwitcher shows the current option, such as the active library, after
+// the tab headers. Ctrl+L picks the next option and quits the program, so
+// the caller can reload its content with it; see Switched.
+func (t *Tabs) SetSwitcher(name string, options []string, current string) {
+	t.switcherName = name
+	t.switcherOptions = options
+	t.switcherCurrent = 0
+	for i, option := range options {
+		if option == current {
+			t.switcherCurrent = i
+		}
+	}
+}
+
+// Switched returns the option picked with Ctrl+L, or "" if the program
+// quit otherwise.
+func (t Tabs) Switched() string {
+	return t.switched
+}
+
 func (t Tabs) Init() tea.Cmd {
 	cmds := make([]tea.Cmd, len(t.contents))
 	for i, content := range t.contents {
@@ -150,6 +180,12 @@ func (t Tabs) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+f":
 			t.focusMode = !t.focusMode
 			return t, nil
+		case "ctrl+l":
+			if len(t.switcherOptions) > 1 {
+				next := (t.switcherCurrent + 1) % len(t.switcherOptions)
+				t.switched = t.switcherOptions[next]
+				return t, tea.Quit
+			}
 		}
 
 		// In focus mode, prioritize tab content for most keys
@@ -213,24 +249,16 @@ func (t Tabs) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 func (t Tabs) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "right":
-		t.activeTab = min(t.activeTab+1, len(t.labels)-1)
-		t.xOffset = 0
-		return t, nil
+		return t.selectTab(min(t.activeTab+1, len(t.labels)-1))
 
 	case "left":
-		t.activeTab = max(t.activeTab-1, 0)
-		t.xOffset = 0
-		return t, nil
+		return t.selectTab(max(t.activeTab-1, 0))
 
 	case "tab":
-		t.activeTab = min(t.activeTab+1, len(t.labels)-1)
-		t.xOffset = 0
-		return t, nil
+		return t.selectTab(min(t.activeTab+1, len(t.labels)-1))
 
 	case "shift+tab":
-		t.activeTab = max(t.activeTab-1, 0)
-		t.xOffset = 0
-		return t, nil
+		return t.selectTab(max(t.activeTab-1, 0))
 
 	case "ctrl+right":
 		t.xOffset += 5
@@ -248,6 +276,19 @@ func (t Tabs) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// selectTab makes tab i the active tab and sends it a TabShownMsg if it
+// was not active before.
+func (t Tabs) selectTab(i int) (tea.Model, tea.Cmd) {
+	t.xOffset = 0
+	if i == t.activeTab {
+		return t, nil
+	}
+	t.activeTab = i
+	updatedContent, cmd := t.contents[i].Update(TabShownMsg{})
+	t.contents[i] = updatedContent
+	return t, cmd
+}
+
 type dimensions struct {
 	tabWidth       int
 	viewportWidth  int
@@ -259,13 +300,7 @@ func (t Tabs) calculateDimensions() dimensions {
 	tabHeaders := t.renderTabHeaders()
 	tabHeaderLines := strings.Count(tabHeaders, "\n") + 1
 
-	var footerText string
-	if t.debug {
-		footerText = "\n\nCtrl+F: toggle mode | Ctrl+D: debug | Ctrl+C: quit"
-	} else {
-		footerText = "\n\nCtrl+F: toggle mode | Ctrl+C: quit"
-	}
-	footerLines := strings.Count(footerText, "\n") + 1
+	footerLines := strings.Count(t.footer(), "\n") + 1
 
 	docVPadding := t.styles.Doc.GetVerticalFrameSize()
 	windowVPadding := t.styles.Window.GetVerticalFrameSize()
@@ -351,13 +386,30 @@ func (t Tabs) renderTabHeaders() string {
 		renderedTab := t.renderSingleTab(i, label)
 		renderedTabs = append(renderedTabs, renderedTab)
 	}
+	if len(t.switcherOptions) > 0 {
+		renderedTabs = append(renderedTabs, t.renderSwitcher())
+	}
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, renderedTabs...)
 }
 
+// renderSwitcher renders the switcher as the last tab header.
+func (t Tabs) renderSwitcher() string {
+	label := fmt.Sprintf("%s: %s", t.switcherName, t.switcherOptions[t.switcherCurrent])
+	border, _, _, _, _ := t.styles.InactiveTab.GetBorder()
+	border.BottomRight = "┤"
+
+	return t.styles.InactiveTab.
+		Border(border).
+		Foreground(t.styles.HighlightColor).
+		Width(max(t.labelWidth, lipgloss.Width(label)+2)).
+		Align(lipgloss.Center).
+		Render(label)
+}
+
 func (t Tabs) renderSingleTab(index int, label string) string {
 	isFirst := index == 0
-	isLast := index == len(t.labels)-1
+	isLast := index == len(t.labels)-1 && len(t.switcherOptions) == 0
 	isActive := index == t.activeTab
 
 	var style lipgloss.Style
@@ -418,15 +470,23 @@ func (t Tabs) View() string {
 	content := t.renderContent(tabHeaders)
 	doc.WriteString(content)
 
-	if t.debug {
-		doc.WriteString("\n\nCtrl+F: toggle mode | Ctrl+D: debug | Ctrl+C: quit")
-	} else {
-		doc.WriteString("\n\nCtrl+F: toggle mode | Ctrl+C: quit")
-	}
+	doc.WriteString(t.footer())
 
 	return t.styles.Doc.Render(doc.String())
 }
 
+// footer returns the key hints shown below the window.
+func (t Tabs) footer() string {
+	footer := "\n\nCtrl+F: toggle mode"
+	if t.debug {
+		footer += " | Ctrl+D: debug"
+	}
+	if len(t.switcherOptions) > 1 {
+		footer += " | Ctrl+L: next " + t.switcherName
+	}
+	return footer + " | Ctrl+C: quit"
+}
+
 func (t Tabs) renderContent(tabHeaders string) string {
 	tabWidth := lipgloss.Width(tabHeaders)
 	contentWidth := tabWidth - t.styles.Window.GetHorizontalFrameSize()
@@ -6,50 +6,173 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+)
+
+// Environment variables that override the configuration.
+const (
+	// ConfigEnv names the config file to use instead of the default one.
+	ConfigEnv = "SNIP_CONFIG"
+
+	// StoragePathEnv overrides storage_path without changing the file.
+	StoragePathEnv = "SNIP_STORAGE_PATH"
 )
 
 // Config holds the application configuration.
 type Config struct {
 	StoragePath string `json:"storage_path"`
+
+	// Runners maps a snippet language to the command that executes it.
+	// Languages missing here fall back to DefaultRunners.
+	Runners map[string]Runner `json:"runners,omitempty"`
+
+	// RunTimeoutSeconds limits how long a snippet may run. 0 uses DefaultRunTimeout.
+	RunTimeoutSeconds int `json:"run_timeout_seconds,omitempty"`
+
+	// FormatOnSave validates and formats snippet code before it is saved.
+	FormatOnSave bool `json:"format_on_save,omitempty"`
+
+	// Formatters maps a snippet language to an external formatter that
+	// replaces the built-in check for that language.
+	Formatters map[string]Formatter `json:"formatters,omitempty"`
+
+	// Libraries maps a name to a library besides the default one, which is
+	// stored at StoragePath.
+	Libraries map[string]Library `json:"libraries,omitempty"`
+
+	// CurrentLibrary is the library used unless --library names another.
+	// Empty means the default library.
+	CurrentLibrary string `json:"current_library,omitempty"`
+
+	// TeamLibrary is a shared snippets file, such as one on a team drive,
+	// stacked read-only over the current library. Empty means none.
+	TeamLibrary string `json:"team_library,omitempty"`
+
+	path string // File the config was loaded from, for Save
+
+	// StoragePath as saved in the file, while StoragePathEnv overrides it
+	savedStoragePath  string
+	storageOverridden bool
 }
 
-// LoadConfig loads configuration from ~/.snip/config.json.
-// Creates the file with defaults if it doesn't exist.
+// LoadConfig loads configuration from the default file, see ConfigPath,
+// and applies StoragePathEnv. Creates the file with defaults if it doesn't
+// exist. If the file is invalid, the error comes with a config that only
+// knows its Path, so that the file can still be found and edited.
 func LoadConfig() (*Config, error) {
-	dir, err := os.UserHomeDir()
+	configPath, dataDir, err := ConfigPath()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
+		return nil, err
 	}
-	return loadConfigFromDir(dir)
+	return loadConfigAt(configPath, dataDir)
 }
 
-// loadConfigFromDir loads configuration from a specific directory.
-// Used by LoadConfig and for testing.
-func loadConfigFromDir(homeDir string) (*Config, error) {
+// LoadConfigFile loads configuration from a given file, such as one named
+// with --config, and applies StoragePathEnv. Creates the file with defaults
+// if it doesn't exist, storing snippets next to it. Invalid files are
+// reported as by LoadConfig.
+func LoadConfigFile(configPath string) (*Config, error) {
+	configPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config path: %w", err)
+	}
+	return loadConfigAt(configPath, filepath.Dir(configPath))
+}
+
+// ConfigPath returns the default config file and the directory for the
+// snippets file of a new config. The file is ConfigEnv if set, else
+// ~/.snip/config.json if it exists. On Linux, new configs follow the XDG
+// base directories: $XDG_CONFIG_HOME/snip/config.json (~/.config) and
+// snippets in $XDG_DATA_HOME/snip (~/.local/share). Elsewhere they stay in
+// ~/.snip.
+func ConfigPath() (string, string, error) {
+	if path := os.Getenv(ConfigEnv); path != "" {
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid %s: %w", ConfigEnv, err)
+		}
+		return path, filepath.Dir(path), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	configPath, dataDir := resolvePaths(home, runtime.GOOS, os.Getenv)
+	return configPath, dataDir, nil
+}
+
+// resolvePaths returns the default config file and snippets directory for
+// a home directory and OS. Used by ConfigPath and for testing.
+func resolvePaths(homeDir, goos string, getenv func(string) string) (string, string) {
 	snipPath := filepath.Join(homeDir, ".snip")
+	legacy := filepath.Join(snipPath, "config.json")
+	if goos != "linux" {
+		return legacy, snipPath
+	}
+	if _, err := os.Stat(legacy); err == nil {
+		return legacy, snipPath
+	}
 
-	// Note: MkdirAll error is not tested - would require simulating permission failures
-	if err := os.MkdirAll(snipPath, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create .snip directory: %w", err)
+	// Relative XDG paths are invalid and must be ignored
+	configHome := getenv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(configHome) {
+		configHome = filepath.Join(homeDir, ".config")
+	}
+	dataHome := getenv("XDG_DATA_HOME")
+	if !filepath.IsAbs(dataHome) {
+		dataHome = filepath.Join(homeDir, ".local", "share")
 	}
+	return filepath.Join(configHome, "snip", "config.json"), filepath.Join(dataHome, "snip")
+}
+
+// loadConfigFromDir loads configuration from the .snip directory of a
+// specific home directory. Used for testing.
+func loadConfigFromDir(homeDir string) (*Config, error) {
+	snipPath := filepath.Join(homeDir, ".snip")
+	return loadConfigAt(filepath.Join(snipPath, "config.json"), snipPath)
+}
 
-	configPath := filepath.Join(snipPath, "config.json")
+// loadConfigAt loads configuration from configPath, or creates it with the
+// snippets file in dataDir, and applies StoragePathEnv.
+func loadConfigAt(configPath, dataDir string) (*Config, error) {
+	// Note: MkdirAll errors are not tested - would require simulating permission failures
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
 
+	var config *Config
 	_, err := os.Stat(configPath)
 	if os.IsNotExist(err) {
-		return createDefaultConfig(configPath, snipPath)
+		config, err = createDefaultConfig(configPath, dataDir)
 	} else if err != nil {
 		// Note: Stat errors other than NotExist are not tested - extremely rare
 		return nil, fmt.Errorf("failed to check config file: %w", err)
+	} else {
+		config, err = loadExistingConfig(configPath)
+	}
+	if err != nil {
+		return config, err
 	}
 
-	return loadExistingConfig(configPath)
+	if path := os.Getenv(StoragePathEnv); path != "" {
+		config.savedStoragePath = config.StoragePath
+		config.storageOverridden = true
+		config.StoragePath = path
+	}
+	return config, nil
 }
 
 // createDefaultConfig creates a new config file with default values.
+// Runners and the run timeout are left out, so that they follow
+// DefaultRunners and DefaultRunTimeout as those change.
 func createDefaultConfig(configPath, snipPath string) (*Config, error) {
 	config := &Config{
 		StoragePath: filepath.Join(snipPath, "snippets.json"),
+		path:        configPath,
 	}
 
 	// Note: Marshal error not tested - won't fail with our simple struct
@@ -66,17 +189,77 @@ func createDefaultConfig(configPath, snipPath string) (*Config, error) {
 	return config, nil
 }
 
-// loadExistingConfig reads and parses an existing config file.
+// loadExistingConfig reads and parses an existing config file. If the file
+// is invalid, it returns the error with a config that only knows its path.
 func loadExistingConfig(configPath string) (*Config, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	config, err := Parse(data)
+	if err != nil {
+		return &Config{path: configPath}, fmt.Errorf("invalid config %s: %w", configPath, err)
+	}
+	config.path = configPath
+
+	return config, nil
+}
+
+// Parse reads configuration from config file data, rejecting unknown keys
+// and invalid values.
+func Parse(data []byte) (*Config, error) {
 	var config Config
 	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
-
+	if err := checkKeys(data); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
 	return &config, nil
 }
+
+// Path returns the file the configuration was loaded from.
+func (c *Config) Path() string {
+	return c.path
+}
+
+// StoragePathOverridden reports whether StoragePathEnv overrides
+// storage_path.
+func (c *Config) StoragePathOverridden() bool {
+	return c.storageOverridden
+}
+
+// setStoragePath changes storage_path. While StoragePathEnv overrides it,
+// only the saved value changes.
+func (c *Config) setStoragePath(path string) {
+	if c.storageOverridden {
+		c.savedStoragePath = path
+		return
+	}
+	c.StoragePath = path
+}
+
+// Save writes the configuration back to the file it was loaded from.
+func (c *Config) Save() error {
+	if c.path == "" {
+		return fmt.Errorf("config was not loaded from a file")
+	}
+
+	saved := *c
+	if c.storageOverridden {
+		saved.StoragePath = c.savedStoragePath
+	}
+
+	data, err := json.MarshalIndent(&saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
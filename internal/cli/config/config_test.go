@@ -40,6 +40,20 @@ func TestLoadConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("leaves built-in defaults out of a new config", func(t *testing.T) {
+		config, err := loadConfigFromDir(t.TempDir())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if config.FormatOnSave || config.Runners != nil || config.RunTimeoutSeconds != 0 {
+			t.Errorf("expected no saved defaults, got %+v", config)
+		}
+		if _, ok := config.RunnerFor("python"); !ok || config.RunTimeout() != DefaultRunTimeout {
+			t.Error("expected the built-in runners and timeout")
+		}
+	})
+
 	t.Run("loads existing config", func(t *testing.T) {
 		tempHome := t.TempDir()
 
@@ -96,11 +110,14 @@ func TestLoadConfig(t *testing.T) {
 		configPath := filepath.Join(snipPath, "config.json")
 		os.WriteFile(configPath, []byte("invalid json {{{"), 0644)
 
-		_, err := loadConfigFromDir(tempHome)
+		config, err := loadConfigFromDir(tempHome)
 
 		if err == nil {
 			t.Fatal("expected error for invalid JSON")
 		}
+		if config == nil || config.Path() != configPath {
+			t.Errorf("expected a config that knows its path %q, got %v", configPath, config)
+		}
 	})
 
 	t.Run("config file is pretty-printed JSON", func(t *testing.T) {
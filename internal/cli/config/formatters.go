@@ -0,0 +1,23 @@
+package config
+
+import "github.com/7-Dany/snip/internal/domain"
+
+// Formatter describes an external code formatter for one language.
+type Formatter struct {
+	// Command is the program and its arguments, e.g. ["black", "-q", "-"].
+	// It receives the code on stdin and writes the formatted code to stdout.
+	Command []string `json:"command"`
+}
+
+// FormatterFor returns the external formatter configured for a snippet
+// language. Languages are matched by canonical name.
+func (c *Config) FormatterFor(language string) (Formatter, bool) {
+	language = domain.CanonicalLanguage(language)
+
+	for name, formatter := range c.Formatters {
+		if domain.CanonicalLanguage(name) == language && len(formatter.Command) > 0 {
+			return formatter, true
+		}
+	}
+	return Formatter{}, false
+}
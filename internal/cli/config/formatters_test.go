@@ -0,0 +1,30 @@
+package config
+
+import "testing"
+
+func TestConfig_FormatterFor(t *testing.T) {
+	t.Run("matches language aliases", func(t *testing.T) {
+		config := &Config{Formatters: map[string]Formatter{
+			"py": {Command: []string{"black", "-q", "-"}},
+		}}
+
+		formatter, ok := config.FormatterFor("Python")
+		if !ok || formatter.Command[0] != "black" {
+			t.Errorf("expected black formatter, got %+v", formatter)
+		}
+	})
+
+	t.Run("ignores formatters without a command", func(t *testing.T) {
+		config := &Config{Formatters: map[string]Formatter{"go": {}}}
+
+		if _, ok := config.FormatterFor("go"); ok {
+			t.Error("expected no formatter")
+		}
+	})
+
+	t.Run("reports unconfigured languages", func(t *testing.T) {
+		if _, ok := (&Config{}).FormatterFor("go"); ok {
+			t.Error("expected no formatter")
+		}
+	})
+}
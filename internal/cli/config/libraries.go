@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// DefaultLibrary names the library stored at StoragePath.
+const DefaultLibrary = "default"
+
+// BackendJSON stores a library in a JSON file, encrypted or not. It is the
+// default and, so far, the only backend.
+const BackendJSON = "json"
+
+// libraryName matches valid library names.
+var libraryName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Library is a named snippet library.
+type Library struct {
+	StoragePath string `json:"storage_path"`
+	Backend     string `json:"backend,omitempty"` // BackendJSON if empty
+}
+
+// LibraryNames returns the names of all libraries: the default library
+// first, then the others sorted.
+func (c *Config) LibraryNames() []string {
+	names := make([]string, 0, len(c.Libraries)+1)
+	for name := range c.Libraries {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return append([]string{DefaultLibrary}, names...)
+}
+
+// CurrentLibraryName returns the name of the library used unless another
+// is named.
+func (c *Config) CurrentLibraryName() string {
+	if c.CurrentLibrary == "" {
+		return DefaultLibrary
+	}
+	return c.CurrentLibrary
+}
+
+// Library returns the library with the given name. An empty name means
+// the current library, and the default library is stored at StoragePath.
+func (c *Config) Library(name string) (Library, error) {
+	if name == "" {
+		name = c.CurrentLibraryName()
+	}
+	if name == DefaultLibrary {
+		return Library{StoragePath: c.StoragePath, Backend: BackendJSON}, nil
+	}
+	library, ok := c.Libraries[name]
+	if !ok {
+		return Library{}, fmt.Errorf("unknown library %q", name)
+	}
+	if library.Backend == "" {
+		library.Backend = BackendJSON
+	}
+	return library, nil
+}
+
+// AddLibrary adds a named library. The name must be new and made of
+// letters, digits, dots, dashes and underscores, and the backend must be
+// known; an empty backend means BackendJSON.
+func (c *Config) AddLibrary(name string, library Library) error {
+	if err := checkLibrary(name, library); err != nil {
+		return err
+	}
+	if _, ok := c.Libraries[name]; ok || name == DefaultLibrary {
+		return fmt.Errorf("library %q already exists", name)
+	}
+
+	if c.Libraries == nil {
+		c.Libraries = make(map[string]Library)
+	}
+	c.Libraries[name] = library
+	return nil
+}
+
+// checkLibrary reports an invalid library name, a missing storage path or
+// an unknown backend.
+func checkLibrary(name string, library Library) error {
+	if !libraryName.MatchString(name) {
+		return fmt.Errorf("invalid library name %q: use letters, digits, '.', '-' and '_'", name)
+	}
+	if library.StoragePath == "" {
+		return fmt.Errorf("library %q needs a storage path", name)
+	}
+	if library.Backend != "" && library.Backend != BackendJSON {
+		return fmt.Errorf("unknown backend %q: use %s", library.Backend, BackendJSON)
+	}
+	return nil
+}
+
+// RemoveLibrary removes a named library from the configuration; its file
+// is left in place. The default library cannot be removed. If the library
+// was the current one, the default library becomes current.
+func (c *Config) RemoveLibrary(name string) error {
+	if name == DefaultLibrary {
+		return fmt.Errorf("the default library cannot be removed")
+	}
+	if _, ok := c.Libraries[name]; !ok {
+		return fmt.Errorf("unknown library %q", name)
+	}
+	delete(c.Libraries, name)
+	if c.CurrentLibrary == name {
+		c.CurrentLibrary = ""
+	}
+	return nil
+}
+
+// UseLibrary makes a library the current one.
+func (c *Config) UseLibrary(name string) error {
+	if _, err := c.Library(name); err != nil {
+		return err
+	}
+	c.CurrentLibrary = name
+	if name == DefaultLibrary {
+		c.CurrentLibrary = ""
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestConfig_Libraries(t *testing.T) {
+	t.Run("the default library uses the storage path", func(t *testing.T) {
+		c := &Config{StoragePath: "/data/snippets.json"}
+
+		library, err := c.Library("")
+		if err != nil || library.StoragePath != "/data/snippets.json" || library.Backend != BackendJSON {
+			t.Errorf("unexpected default library %+v (%v)", library, err)
+		}
+		if names := c.LibraryNames(); !slices.Equal(names, []string{"default"}) {
+			t.Errorf("expected only the default library, got %v", names)
+		}
+	})
+
+	t.Run("adds, uses and removes libraries", func(t *testing.T) {
+		c := &Config{StoragePath: "/data/snippets.json"}
+		if err := c.AddLibrary("work", Library{StoragePath: "/data/work.json"}); err != nil {
+			t.Fatalf("failed to add: %v", err)
+		}
+		c.AddLibrary("interview-prep", Library{StoragePath: "/data/prep.json", Backend: "json"})
+
+		if names := c.LibraryNames(); !slices.Equal(names, []string{"default", "interview-prep", "work"}) {
+			t.Errorf("unexpected names %v", names)
+		}
+
+		if err := c.UseLibrary("work"); err != nil || c.CurrentLibraryName() != "work" {
+			t.Fatalf("failed to use work: %v", err)
+		}
+		library, _ := c.Library("")
+		if library.StoragePath != "/data/work.json" || library.Backend != BackendJSON {
+			t.Errorf("unexpected current library %+v", library)
+		}
+
+		if err := c.RemoveLibrary("work"); err != nil {
+			t.Fatalf("failed to remove: %v", err)
+		}
+		if c.CurrentLibraryName() != DefaultLibrary {
+			t.Errorf("expected the default library to become current, got %q", c.CurrentLibraryName())
+		}
+
+		if err := c.UseLibrary("default"); err != nil || c.CurrentLibrary != "" {
+			t.Errorf("expected default to clear the current library, got %q (%v)", c.CurrentLibrary, err)
+		}
+	})
+
+	t.Run("rejects invalid libraries", func(t *testing.T) {
+		c := &Config{StoragePath: "/data/snippets.json"}
+		c.AddLibrary("work", Library{StoragePath: "/data/work.json"})
+
+		invalid := map[string]Library{
+			"default":  {StoragePath: "/x.json"},
+			"work":     {StoragePath: "/x.json"},
+			"my lib":   {StoragePath: "/x.json"},
+			"":         {StoragePath: "/x.json"},
+			"sqlite":   {StoragePath: "/x.db", Backend: "sqlite"},
+			"no-place": {},
+		}
+		for name, library := range invalid {
+			if err := c.AddLibrary(name, library); err == nil {
+				t.Errorf("expected an error adding %q", name)
+			}
+		}
+
+		if err := c.RemoveLibrary("default"); err == nil {
+			t.Error("expected an error removing the default library")
+		}
+		if err := c.RemoveLibrary("missing"); err == nil {
+			t.Error("expected an error removing an unknown library")
+		}
+		if err := c.UseLibrary("missing"); err == nil {
+			t.Error("expected an error using an unknown library")
+		}
+		if _, err := c.Library("missing"); err == nil {
+			t.Error("expected an error for an unknown library")
+		}
+	})
+
+	t.Run("saves to the loaded file", func(t *testing.T) {
+		home := t.TempDir()
+		c, err := loadConfigFromDir(home)
+		if err != nil {
+			t.Fatalf("failed to load: %v", err)
+		}
+		c.AddLibrary("work", Library{StoragePath: "/data/work.json"})
+		c.UseLibrary("work")
+		if err := c.Save(); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+
+		reloaded, err := loadExistingConfig(filepath.Join(home, ".snip", "config.json"))
+		if err != nil {
+			t.Fatalf("failed to reload: %v", err)
+		}
+		if reloaded.CurrentLibrary != "work" || reloaded.Libraries["work"].StoragePath != "/data/work.json" {
+			t.Errorf("unexpected reloaded config %+v", reloaded)
+		}
+
+		if err := (&Config{}).Save(); err == nil {
+			t.Error("expected an error saving a config without a file")
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// ProjectDir is the directory holding a project library, found in the
+// working directory or one of its parents.
+const ProjectDir = ".snip"
+
+// ProjectFile is the snippets file of a project library.
+const ProjectFile = "snippets.json"
+
+// FindProjectLibrary walks up from dir looking for a .snip directory and
+// returns the path of its snippets file, or "" if there is none. The global
+// ~/.snip directory is not a project library.
+func FindProjectLibrary(dir string) string {
+	// A missing home directory only means no directory is skipped
+	home, _ := os.UserHomeDir()
+	return findProjectLibrary(dir, home)
+}
+
+// findProjectLibrary walks up from dir, skipping homeDir's .snip directory.
+// Used by FindProjectLibrary and for testing.
+func findProjectLibrary(dir, homeDir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	global := ""
+	if homeDir != "" {
+		global = filepath.Join(homeDir, ProjectDir)
+	}
+
+	for {
+		candidate := filepath.Join(dir, ProjectDir)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() && candidate != global {
+			return filepath.Join(candidate, ProjectFile)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindProjectLibrary(t *testing.T) {
+	t.Run("finds .snip in a parent directory", func(t *testing.T) {
+		root := t.TempDir()
+		nested := filepath.Join(root, "src", "pkg")
+		os.MkdirAll(nested, 0755)
+		os.Mkdir(filepath.Join(root, ".snip"), 0755)
+
+		got := findProjectLibrary(nested, "")
+		want := filepath.Join(root, ".snip", "snippets.json")
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("prefers the nearest .snip", func(t *testing.T) {
+		root := t.TempDir()
+		nested := filepath.Join(root, "service")
+		os.MkdirAll(filepath.Join(nested, ".snip"), 0755)
+		os.Mkdir(filepath.Join(root, ".snip"), 0755)
+
+		want := filepath.Join(nested, ".snip", "snippets.json")
+		if got := findProjectLibrary(nested, ""); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("skips the global .snip directory", func(t *testing.T) {
+		home := t.TempDir()
+		project := filepath.Join(home, "project")
+		os.MkdirAll(project, 0755)
+		os.Mkdir(filepath.Join(home, ".snip"), 0755)
+
+		if got := findProjectLibrary(project, home); got != "" {
+			t.Errorf("expected no project library, got %q", got)
+		}
+	})
+
+	t.Run("ignores a .snip file", func(t *testing.T) {
+		root := t.TempDir()
+		os.WriteFile(filepath.Join(root, ".snip"), []byte("not a directory"), 0644)
+
+		if got := findProjectLibrary(root, ""); got != "" {
+			t.Errorf("expected no project library, got %q", got)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"time"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// DefaultRunTimeout is how long a snippet may run when no timeout is configured.
+const DefaultRunTimeout = 10 * time.Second
+
+// Runner describes how to execute snippets of one language.
+type Runner struct {
+	// Command is the program and its arguments, e.g. ["go", "run"].
+	// Any "{file}" argument is replaced by the path of the file holding
+	// the snippet code; without one, the path is appended.
+	Command []string `json:"command"`
+
+	// Extension is the extension of the snippet file, e.g. ".py".
+	Extension string `json:"extension,omitempty"`
+}
+
+// DefaultRunners returns the built-in runners, keyed by canonical language.
+func DefaultRunners() map[string]Runner {
+	return map[string]Runner{
+		"bash":       {Command: []string{"bash"}, Extension: ".sh"},
+		"zsh":        {Command: []string{"zsh"}, Extension: ".zsh"},
+		"python":     {Command: []string{"python3"}, Extension: ".py"},
+		"go":         {Command: []string{"go", "run"}, Extension: ".go"},
+		"javascript": {Command: []string{"node"}, Extension: ".js"},
+		"ruby":       {Command: []string{"ruby"}, Extension: ".rb"},
+		"perl":       {Command: []string{"perl"}, Extension: ".pl"},
+		"php":        {Command: []string{"php"}, Extension: ".php"},
+		"lua":        {Command: []string{"lua"}, Extension: ".lua"},
+	}
+}
+
+// RunnerFor returns the runner for a snippet language. Configured runners
+// take precedence over DefaultRunners. Languages are matched by canonical
+// name, so a runner configured for "js" also runs "javascript" snippets.
+func (c *Config) RunnerFor(language string) (Runner, bool) {
+	language = domain.CanonicalLanguage(language)
+
+	for name, runner := range c.Runners {
+		if domain.CanonicalLanguage(name) == language && len(runner.Command) > 0 {
+			return runner, true
+		}
+	}
+
+	runner, ok := DefaultRunners()[language]
+	return runner, ok
+}
+
+// RunTimeout returns the configured snippet run timeout.
+func (c *Config) RunTimeout() time.Duration {
+	if c.RunTimeoutSeconds <= 0 {
+		return DefaultRunTimeout
+	}
+	return time.Duration(c.RunTimeoutSeconds) * time.Second
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfig_RunnerFor(t *testing.T) {
+	t.Run("falls back to default runners", func(t *testing.T) {
+		config := &Config{}
+
+		runner, ok := config.RunnerFor("Python")
+		if !ok {
+			t.Fatal("expected default python runner")
+		}
+		if !reflect.DeepEqual(runner.Command, []string{"python3"}) || runner.Extension != ".py" {
+			t.Errorf("unexpected python runner: %+v", runner)
+		}
+	})
+
+	t.Run("prefers configured runners", func(t *testing.T) {
+		config := &Config{Runners: map[string]Runner{
+			"Go": {Command: []string{"gotip", "run"}, Extension: ".go"},
+		}}
+
+		runner, ok := config.RunnerFor("go")
+		if !ok {
+			t.Fatal("expected configured go runner")
+		}
+		if runner.Command[0] != "gotip" {
+			t.Errorf("expected configured runner, got %+v", runner)
+		}
+	})
+
+	t.Run("matches language aliases", func(t *testing.T) {
+		config := &Config{Runners: map[string]Runner{
+			"js": {Command: []string{"deno", "run"}, Extension: ".js"},
+		}}
+
+		runner, ok := config.RunnerFor("JavaScript")
+		if !ok || runner.Command[0] != "deno" {
+			t.Errorf("expected configured js runner, got %+v", runner)
+		}
+
+		runner, ok = config.RunnerFor("sh")
+		if !ok || runner.Command[0] != "bash" {
+			t.Errorf("expected default bash runner for sh, got %+v", runner)
+		}
+	})
+
+	t.Run("ignores configured runners without a command", func(t *testing.T) {
+		config := &Config{Runners: map[string]Runner{"bash": {}}}
+
+		runner, ok := config.RunnerFor("bash")
+		if !ok || len(runner.Command) == 0 {
+			t.Errorf("expected default bash runner, got %+v", runner)
+		}
+	})
+
+	t.Run("reports unknown languages", func(t *testing.T) {
+		if _, ok := (&Config{}).RunnerFor("cobol"); ok {
+			t.Error("expected no runner for cobol")
+		}
+	})
+}
+
+func TestConfig_RunTimeout(t *testing.T) {
+	if got := (&Config{}).RunTimeout(); got != DefaultRunTimeout {
+		t.Errorf("expected default timeout %v, got %v", DefaultRunTimeout, got)
+	}
+	if got := (&Config{RunTimeoutSeconds: 3}).RunTimeout(); got != 3*time.Second {
+		t.Errorf("expected 3s, got %v", got)
+	}
+}
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// setting is a top-level configuration key that can be read and, unless
+// set is nil, changed with Get and Set.
+type setting struct {
+	key         string
+	description string
+	get         func(c *Config) string
+	set         func(c *Config, value string) error
+}
+
+// settings lists every key of the config file, in file order.
+var settings = []setting{
+	{
+		key:         "storage_path",
+		description: "Snippets file of the default library",
+		get:         func(c *Config) string { return c.StoragePath },
+		set: func(c *Config, value string) error {
+			if value == "" {
+				return fmt.Errorf("storage_path cannot be empty")
+			}
+			c.setStoragePath(value)
+			return nil
+		},
+	},
+	{
+		key:         "runners",
+		description: "Commands that run snippets, by language, over the defaults",
+		get:         func(c *Config) string { return compactJSON(c.Runners) },
+	},
+	{
+		key:         "run_timeout_seconds",
+		description: "How long a snippet may run; 0 means 10 seconds",
+		get:         func(c *Config) string { return strconv.Itoa(c.RunTimeoutSeconds) },
+		set: func(c *Config, value string) error {
+			seconds, err := strconv.Atoi(value)
+			if err != nil || seconds < 0 {
+				return fmt.Errorf("run_timeout_seconds must be a number of seconds, 0 or more, got %q", value)
+			}
+			c.RunTimeoutSeconds = seconds
+			return nil
+		},
+	},
+	{
+		key:         "format_on_save",
+		description: "Check and format code before saving",
+		get:         func(c *Config) string { return strconv.FormatBool(c.FormatOnSave) },
+		set: func(c *Config, value string) error {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("format_on_save must be true or false, got %q", value)
+			}
+			c.FormatOnSave = enabled
+			return nil
+		},
+	},
+	{
+		key:         "formatters",
+		description: "External formatters, by language",
+		get:         func(c *Config) string { return compactJSON(c.Formatters) },
+	},
+	{
+		key:         "libraries",
+		description: "Named libraries besides the default one",
+		get:         func(c *Config) string { return compactJSON(c.Libraries) },
+	},
+	{
+		key:         "current_library",
+		description: "Library used unless --library names another",
+		get:         func(c *Config) string { return c.CurrentLibraryName() },
+		set:         func(c *Config, value string) error { return c.UseLibrary(value) },
+	},
+	{
+		key:         "team_library",
+		description: "Shared snippets file stacked read-only; empty for none",
+		get:         func(c *Config) string { return c.TeamLibrary },
+		set: func(c *Config, value string) error {
+			c.TeamLibrary = value
+			return nil
+		},
+	},
+}
+
+// Keys returns the configuration keys in file order.
+func Keys() []string {
+	keys := make([]string, len(settings))
+	for i, s := range settings {
+		keys[i] = s.key
+	}
+	return keys
+}
+
+// Describe returns a one-line description of a configuration key.
+func Describe(key string) string {
+	if s, err := lookupSetting(key); err == nil {
+		return s.description
+	}
+	return ""
+}
+
+// Get returns the value of a configuration key as text. Maps such as
+// runners are returned as JSON.
+func (c *Config) Get(key string) (string, error) {
+	s, err := lookupSetting(key)
+	if err != nil {
+		return "", err
+	}
+	return s.get(c), nil
+}
+
+// Set changes a configuration key from its text value. Maps can't be set
+// this way.
+func (c *Config) Set(key, value string) error {
+	s, err := lookupSetting(key)
+	if err != nil {
+		return err
+	}
+	if s.set == nil {
+		if key == "libraries" {
+			return fmt.Errorf("libraries can't be set directly; use 'snip library add|remove'")
+		}
+		return fmt.Errorf("%s is a map and can't be set directly; use 'snip config edit'", key)
+	}
+	return s.set(c, strings.TrimSpace(value))
+}
+
+// Validate reports the first invalid value of the configuration.
+func (c *Config) Validate() error {
+	if c.StoragePath == "" {
+		return fmt.Errorf("storage_path cannot be empty")
+	}
+	if c.RunTimeoutSeconds < 0 {
+		return fmt.Errorf("run_timeout_seconds must be 0 or more, got %d", c.RunTimeoutSeconds)
+	}
+	for language, runner := range c.Runners {
+		if len(runner.Command) == 0 {
+			return fmt.Errorf("runners.%s: command cannot be empty", language)
+		}
+	}
+	for language, formatter := range c.Formatters {
+		if len(formatter.Command) == 0 {
+			return fmt.Errorf("formatters.%s: command cannot be empty", language)
+		}
+	}
+	for name, library := range c.Libraries {
+		if err := checkLibrary(name, library); err != nil {
+			return fmt.Errorf("libraries.%s: %w", name, err)
+		}
+	}
+	if c.CurrentLibrary != "" {
+		if _, err := c.Library(c.CurrentLibrary); err != nil {
+			return fmt.Errorf("current_library: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkKeys reports keys of config file data that are not configuration keys.
+func checkKeys(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for key := range raw {
+		if !slices.Contains(Keys(), key) {
+			return unknownKey(key)
+		}
+	}
+	return nil
+}
+
+// lookupSetting returns the setting for a key.
+func lookupSetting(key string) (setting, error) {
+	for _, s := range settings {
+		if s.key == key {
+			return s, nil
+		}
+	}
+	return setting{}, unknownKey(key)
+}
+
+// unknownKey returns the error for a key that is not a configuration key.
+func unknownKey(key string) error {
+	return fmt.Errorf("unknown key %q (known keys: %s)", key, strings.Join(Keys(), ", "))
+}
+
+// compactJSON returns a map as one line of JSON, or "" if it is empty.
+func compactJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil || string(data) == "null" || string(data) == "{}" {
+		return ""
+	}
+	return string(data)
+}
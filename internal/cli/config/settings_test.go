@@ -0,0 +1,219 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfig_GetSet(t *testing.T) {
+	t.Run("sets and gets values", func(t *testing.T) {
+		config := &Config{StoragePath: "/tmp/snippets.json"}
+
+		if err := config.Set("run_timeout_seconds", "30"); err != nil {
+			t.Fatalf("failed to set: %v", err)
+		}
+		if err := config.Set("format_on_save", "false"); err != nil {
+			t.Fatalf("failed to set: %v", err)
+		}
+		if err := config.Set("team_library", "/mnt/team/snippets.json"); err != nil {
+			t.Fatalf("failed to set: %v", err)
+		}
+
+		for key, want := range map[string]string{
+			"run_timeout_seconds": "30",
+			"format_on_save":      "false",
+			"team_library":        "/mnt/team/snippets.json",
+			"current_library":     DefaultLibrary,
+			"runners":             "",
+		} {
+			if got, err := config.Get(key); err != nil || got != want {
+				t.Errorf("%s: expected %q, got %q (%v)", key, want, got, err)
+			}
+		}
+	})
+
+	t.Run("returns maps as JSON", func(t *testing.T) {
+		config := &Config{Runners: map[string]Runner{"go": {Command: []string{"go", "run"}}}}
+
+		got, _ := config.Get("runners")
+		if got != `{"go":{"command":["go","run"]}}` {
+			t.Errorf("unexpected runners %q", got)
+		}
+	})
+
+	t.Run("rejects unknown keys and bad values", func(t *testing.T) {
+		config := &Config{StoragePath: "/tmp/snippets.json"}
+
+		tests := []struct {
+			key, value, message string
+		}{
+			{"storage_pth", "x", `unknown key "storage_pth"`},
+			{"run_timeout_seconds", "-1", "0 or more"},
+			{"run_timeout_seconds", "soon", "number of seconds"},
+			{"format_on_save", "maybe", "true or false"},
+			{"storage_path", "", "cannot be empty"},
+			{"current_library", "missing", `unknown library "missing"`},
+			{"runners", "{}", "snip config edit"},
+			{"libraries", "{}", "snip library"},
+		}
+		for _, tt := range tests {
+			err := config.Set(tt.key, tt.value)
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Set(%q, %q): expected error containing %q, got %v", tt.key, tt.value, tt.message, err)
+			}
+		}
+		if _, err := config.Get("nope"); err == nil {
+			t.Error("expected error for unknown key")
+		}
+	})
+
+	t.Run("lists every key", func(t *testing.T) {
+		for _, key := range Keys() {
+			if Describe(key) == "" {
+				t.Errorf("expected a description for %s", key)
+			}
+			if _, err := (&Config{}).Get(key); err != nil {
+				t.Errorf("expected %s to be readable, got %v", key, err)
+			}
+		}
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("parses a valid config", func(t *testing.T) {
+		config, err := Parse([]byte(`{"storage_path": "/tmp/s.json", "run_timeout_seconds": 5}`))
+		if err != nil || config.RunTimeoutSeconds != 5 {
+			t.Errorf("expected a parsed config, got %v (%v)", config, err)
+		}
+	})
+
+	t.Run("rejects unknown keys and invalid values", func(t *testing.T) {
+		tests := map[string]string{
+			`{"storage_path": "/tmp/s.json", "format_on_sav": true}`: `unknown key "format_on_sav"`,
+			`{"storage_path": ""}`: "storage_path cannot be empty",
+			`{"storage_path": "/tmp/s.json", "run_timeout_seconds": -5}`:                "run_timeout_seconds",
+			`{"storage_path": "/tmp/s.json", "runners": {"go": {"command": []}}}`:       "runners.go",
+			`{"storage_path": "/tmp/s.json", "current_library": "work"}`:                "current_library",
+			`{"storage_path": "/tmp/s.json", "libraries": {"w": {"storage_path": ""}}}`: "libraries.w",
+			`{"storage_path": "/tmp/s.json", "run_timeout_seconds": "ten"}`:             "unmarshal",
+		}
+		for data, message := range tests {
+			_, err := Parse([]byte(data))
+			if err == nil || !strings.Contains(err.Error(), message) {
+				t.Errorf("Parse(%s): expected error containing %q, got %v", data, message, err)
+			}
+		}
+	})
+}
+
+func TestResolvePaths(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	t.Run("uses XDG directories on Linux", func(t *testing.T) {
+		home := t.TempDir()
+		configPath, dataDir := resolvePaths(home, "linux", env(nil))
+
+		if configPath != filepath.Join(home, ".config", "snip", "config.json") {
+			t.Errorf("unexpected config path %q", configPath)
+		}
+		if dataDir != filepath.Join(home, ".local", "share", "snip") {
+			t.Errorf("unexpected data directory %q", dataDir)
+		}
+	})
+
+	t.Run("honors XDG variables", func(t *testing.T) {
+		home := t.TempDir()
+		configPath, dataDir := resolvePaths(home, "linux", env(map[string]string{
+			"XDG_CONFIG_HOME": "/xdg/config",
+			"XDG_DATA_HOME":   "relative/is/ignored",
+		}))
+
+		if configPath != "/xdg/config/snip/config.json" {
+			t.Errorf("unexpected config path %q", configPath)
+		}
+		if dataDir != filepath.Join(home, ".local", "share", "snip") {
+			t.Errorf("unexpected data directory %q", dataDir)
+		}
+	})
+
+	t.Run("keeps an existing ~/.snip config", func(t *testing.T) {
+		home := t.TempDir()
+		os.Mkdir(filepath.Join(home, ".snip"), 0755)
+		os.WriteFile(filepath.Join(home, ".snip", "config.json"), []byte("{}"), 0644)
+
+		configPath, dataDir := resolvePaths(home, "linux", env(map[string]string{"XDG_CONFIG_HOME": "/xdg/config"}))
+		if configPath != filepath.Join(home, ".snip", "config.json") || dataDir != filepath.Join(home, ".snip") {
+			t.Errorf("expected the ~/.snip config, got %q and %q", configPath, dataDir)
+		}
+	})
+
+	t.Run("uses ~/.snip on other systems", func(t *testing.T) {
+		home := t.TempDir()
+		configPath, _ := resolvePaths(home, "darwin", env(map[string]string{"XDG_CONFIG_HOME": "/xdg/config"}))
+		if configPath != filepath.Join(home, ".snip", "config.json") {
+			t.Errorf("unexpected config path %q", configPath)
+		}
+	})
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	t.Run("creates a config with snippets next to it", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "alt", "config.json")
+
+		config, err := LoadConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if config.Path() != configPath || config.StoragePath != filepath.Join(filepath.Dir(configPath), "snippets.json") {
+			t.Errorf("unexpected paths %q and %q", config.Path(), config.StoragePath)
+		}
+	})
+
+	t.Run("SNIP_CONFIG names the default file", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "env.json")
+		t.Setenv(ConfigEnv, configPath)
+
+		config, err := LoadConfig()
+		if err != nil || config.Path() != configPath {
+			t.Errorf("expected config at %q, got %v (%v)", configPath, config, err)
+		}
+	})
+
+	t.Run("SNIP_STORAGE_PATH overrides the storage path without saving it", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "config.json")
+		saved := filepath.Join(filepath.Dir(configPath), "snippets.json")
+		t.Setenv(StoragePathEnv, "/tmp/override.json")
+
+		config, err := LoadConfigFile(configPath)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if config.StoragePath != "/tmp/override.json" || !config.StoragePathOverridden() {
+			t.Errorf("expected the override, got %q", config.StoragePath)
+		}
+
+		config.Set("run_timeout_seconds", "20")
+		if err := config.Save(); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+		data, _ := os.ReadFile(configPath)
+		reloaded, err := Parse(data)
+		if err != nil || reloaded.StoragePath != saved || reloaded.RunTimeoutSeconds != 20 {
+			t.Errorf("expected the saved storage path %q, got %v (%v)", saved, reloaded, err)
+		}
+	})
+
+	t.Run("reports unknown keys with the file", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "config.json")
+		os.WriteFile(configPath, []byte(`{"storage_path": "/tmp/s.json", "colour": "red"}`), 0644)
+
+		_, err := LoadConfigFile(configPath)
+		if err == nil || !strings.Contains(err.Error(), configPath) || !strings.Contains(err.Error(), `"colour"`) {
+			t.Errorf("expected an unknown key error naming the file, got %v", err)
+		}
+	})
+}
@@ -38,6 +38,8 @@ type CategoriesTab struct {
 
 	// State
 	selectedCat *domain.Category
+	addParentID int          // parent for the category being added, 0 for top-level
+	collapsed   map[int]bool // categories whose subcategories are hidden
 }
 
 // Return pointer to match tea.Model interface
@@ -45,13 +47,13 @@ func NewCategoriesTab(repos *storage.Repositories) *CategoriesTab {
 	tableView := components.NewSearchableTableView(
 		[]table.Column{
 			{Title: "ID", Width: 8},
-			{Title: "Name", Width: 30},
+			{Title: "Name", Width: 36},
 			{Title: "Created", Width: 20},
 			{Title: "Snippets", Width: 10},
 		},
 		"Categories",
 		"No categories found.\n\nPress 'a' to add your first category.\nPress '?' for help.",
-		"Enter: menu | a: add | /: search | r: refresh | ?: help",
+		"Enter: menu | a: add | Space: expand/collapse | /: search | r: refresh | ?: help",
 		10,
 	)
 
@@ -76,6 +78,9 @@ func NewCategoriesTab(repos *storage.Repositories) *CategoriesTab {
 				Items: []components.HelpItem{
 					{Action: "Open category menu", Key: "Enter"},
 					{Action: "Add new category", Key: "a"},
+					{Action: "Expand/collapse category", Key: "Space"},
+					{Action: "Expand category", Key: "→ / l"},
+					{Action: "Collapse category", Key: "← / h"},
 					{Action: "Search categories", Key: "/"},
 					{Action: "Refresh list", Key: "r"},
 					{Action: "Show this help", Key: "?"},
@@ -107,6 +112,7 @@ func NewCategoriesTab(repos *storage.Repositories) *CategoriesTab {
 		tableView:     tableView,
 		snippetsTable: snippetsTable,
 		helpView:      helpView,
+		collapsed:     make(map[int]bool),
 	}
 
 	tab.refreshTable()
@@ -192,7 +198,7 @@ func (c *CategoriesTab) updateViewportContent() {
 	case viewModeDelete:
 		b.WriteString(c.confirmDialog.View())
 	case viewModeSnippets:
-		header := fmt.Sprintf("Category: %s\n\n", c.selectedCat.Name())
+		header := fmt.Sprintf("Category: %s\n\n", c.categoryPath(c.selectedCat.ID()))
 		b.WriteString(header)
 		b.WriteString(c.snippetsTable.View())
 	case viewModeHelp:
@@ -209,20 +215,73 @@ func (c *CategoriesTab) refreshTable() {
 		return
 	}
 
+	tree := domain.NewCategoryTree(categories)
+
 	var rows []table.Row
-	for _, cat := range categories {
-		snippets, _ := c.repos.Snippets.FindByCategory(cat.ID())
-		rows = append(rows, table.Row{
-			fmt.Sprintf("%d", cat.ID()),
-			cat.Name(),
-			cat.CreatedAt().Format("2006-01-02 15:04"),
-			fmt.Sprintf("%d", len(snippets)),
-		})
+	var addRows func(cats []*domain.Category, depth int)
+	addRows = func(cats []*domain.Category, depth int) {
+		for _, cat := range cats {
+			children := tree.Children(cat.ID())
+
+			marker := "  "
+			if len(children) > 0 {
+				marker = "▾ "
+				if c.collapsed[cat.ID()] {
+					marker = "▸ "
+				}
+			}
+
+			snippets, _ := c.repos.Snippets.FindByCategory(cat.ID())
+			rows = append(rows, table.Row{
+				fmt.Sprintf("%d", cat.ID()),
+				strings.Repeat("  ", depth) + marker + cat.Name(),
+				cat.CreatedAt().Format("2006-01-02 15:04"),
+				fmt.Sprintf("%d", len(snippets)),
+			})
+
+			if !c.collapsed[cat.ID()] {
+				addRows(children, depth+1)
+			}
+		}
 	}
+	addRows(tree.Roots(), 0)
 
 	c.tableView.SetRows(rows)
 }
 
+// setSelectedCollapsed expands, collapses or toggles the selected category.
+// Categories without subcategories are left unchanged.
+func (c *CategoriesTab) setSelectedCollapsed(collapse, toggle bool) {
+	cat := c.selectedRowCategory()
+	if cat == nil {
+		return
+	}
+
+	categories, _ := c.repos.Categories.List()
+	if len(domain.NewCategoryTree(categories).Children(cat.ID())) == 0 {
+		return
+	}
+
+	if toggle {
+		collapse = !c.collapsed[cat.ID()]
+	}
+	if collapse {
+		c.collapsed[cat.ID()] = true
+	} else {
+		delete(c.collapsed, cat.ID())
+	}
+	c.refreshTable()
+}
+
+// selectedRowCategory returns the category for the highlighted table row.
+func (c *CategoriesTab) selectedRowCategory() *domain.Category {
+	selected := c.tableView.SelectedRow()
+	if len(selected) == 0 {
+		return nil
+	}
+	return c.findCategoryByID(selected[0])
+}
+
 func (c *CategoriesTab) updateList(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
 
@@ -238,18 +297,25 @@ func (c *CategoriesTab) updateList(msg tea.Msg) tea.Cmd {
 			c.tableView.ToggleSearch()
 			return nil
 		case "enter":
-			selected := c.tableView.SelectedRow()
-			if len(selected) > 0 {
-				if cat := c.findCategoryByName(selected[1]); cat != nil {
-					c.selectedCat = cat
-					c.mode = viewModeMenu
-					c.createCategoryMenu()
-					c.GotoTop()
-					return nil
-				}
+			if cat := c.selectedRowCategory(); cat != nil {
+				c.selectedCat = cat
+				c.mode = viewModeMenu
+				c.createCategoryMenu()
+				c.GotoTop()
+				return nil
 			}
+		case " ":
+			c.setSelectedCollapsed(false, true)
+			return nil
+		case "right", "l":
+			c.setSelectedCollapsed(false, false)
+			return nil
+		case "left", "h":
+			c.setSelectedCollapsed(true, false)
+			return nil
 		case "a":
 			c.mode = viewModeAdd
+			c.addParentID = 0
 			c.formView = components.NewFormView("Add New Category", "", "Category name")
 			c.GotoTop()
 			return c.formView.Focus()
@@ -301,6 +367,8 @@ func (c *CategoriesTab) updateMenu(msg tea.Msg) tea.Cmd {
 			c.mode = viewModeSnippets
 			c.GotoTop()
 			return nil
+		case "s":
+			return c.startAddSubcategory()
 		case "e":
 			c.mode = viewModeEdit
 			c.formView = components.NewFormView(
@@ -405,25 +473,58 @@ func (c *CategoriesTab) updateSnippets(msg tea.Msg) tea.Cmd {
 	return cmd
 }
 
-func (c *CategoriesTab) findCategoryByName(name string) *domain.Category {
+func (c *CategoriesTab) findCategoryByID(idStr string) *domain.Category {
+	var id int
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return nil
+	}
+	cat, err := c.repos.Categories.FindByID(id)
+	if err != nil {
+		return nil
+	}
+	return cat
+}
+
+// categoryPath returns the full path of a category, e.g. "backend/db/postgres".
+func (c *CategoriesTab) categoryPath(id int) string {
+	categories, _ := c.repos.Categories.List()
+	return domain.NewCategoryTree(categories).Path(id)
+}
+
+// siblingExists reports whether a category named name already exists under
+// parentID, ignoring the category with excludeID.
+func (c *CategoriesTab) siblingExists(parentID int, name string, excludeID int) bool {
 	categories, _ := c.repos.Categories.List()
 	for _, cat := range categories {
-		if cat.Name() == name {
-			return cat
+		if cat.ParentID() == parentID && cat.Name() == name && cat.ID() != excludeID {
+			return true
 		}
 	}
-	return nil
+	return false
+}
+
+func (c *CategoriesTab) startAddSubcategory() tea.Cmd {
+	c.mode = viewModeAdd
+	c.addParentID = c.selectedCat.ID()
+	c.formView = components.NewFormView(
+		"Add Subcategory",
+		fmt.Sprintf("Parent: %s", c.categoryPath(c.selectedCat.ID())),
+		"Category name",
+	)
+	c.GotoTop()
+	return c.formView.Focus()
 }
 
 func (c *CategoriesTab) createCategoryMenu() {
 	snippets, _ := c.repos.Snippets.FindByCategory(c.selectedCat.ID())
-	subtitle := fmt.Sprintf("Snippets: %d", len(snippets))
+	subtitle := fmt.Sprintf("Snippets: %d (including subcategories)", len(snippets))
 
 	c.menuView = components.NewMenuView(
-		fmt.Sprintf("Category: %s", c.selectedCat.Name()),
+		fmt.Sprintf("Category: %s", c.categoryPath(c.selectedCat.ID())),
 		subtitle,
 		[]components.MenuItem{
 			{Label: "View Snippets", Shortcut: "v"},
+			{Label: "Add Subcategory", Shortcut: "s"},
 			{Label: "Edit Category", Shortcut: "e"},
 			{Label: "Delete Category", Shortcut: "x"},
 		},
@@ -434,7 +535,7 @@ func (c *CategoriesTab) createDeleteDialog() {
 	snippets, _ := c.repos.Snippets.FindByCategory(c.selectedCat.ID())
 	snippetCount := len(snippets)
 
-	message := fmt.Sprintf("Category: %s\n\nAre you sure you want to delete this category?", c.selectedCat.Name())
+	message := fmt.Sprintf("Category: %s\n\nAre you sure you want to delete this category?", c.categoryPath(c.selectedCat.ID()))
 	warning := ""
 
 	if snippetCount > 0 {
@@ -444,6 +545,14 @@ func (c *CategoriesTab) createDeleteDialog() {
 		)
 	}
 
+	categories, _ := c.repos.Categories.List()
+	if children := domain.NewCategoryTree(categories).Children(c.selectedCat.ID()); len(children) > 0 {
+		if warning != "" {
+			warning += "\n"
+		}
+		warning += fmt.Sprintf("Its %d subcategory(ies) will be moved up one level.", len(children))
+	}
+
 	c.confirmDialog = components.NewConfirmDialog(
 		"Delete Category",
 		message,
@@ -466,7 +575,9 @@ func (c *CategoriesTab) executeMenuAction() tea.Cmd {
 		c.mode = viewModeSnippets
 		c.GotoTop()
 		return nil
-	case 1: // Edit
+	case 1: // Add Subcategory
+		return c.startAddSubcategory()
+	case 2: // Edit
 		c.mode = viewModeEdit
 		c.formView = components.NewFormView(
 			fmt.Sprintf("Edit Category: %s", c.selectedCat.Name()),
@@ -476,7 +587,7 @@ func (c *CategoriesTab) executeMenuAction() tea.Cmd {
 		c.formView.SetValue(c.selectedCat.Name())
 		c.GotoTop()
 		return c.formView.Focus()
-	case 2: // Delete
+	case 3: // Delete
 		c.mode = viewModeDelete
 		c.createDeleteDialog()
 		c.GotoTop()
@@ -489,8 +600,7 @@ func (c *CategoriesTab) executeMenuAction() tea.Cmd {
 }
 
 func (c *CategoriesTab) handleAddCategory(name string) tea.Cmd {
-	existing, _ := c.repos.Categories.FindByName(name)
-	if existing != nil {
+	if c.siblingExists(c.addParentID, name, 0) {
 		c.SetError("Category already exists")
 		return nil
 	}
@@ -501,6 +611,11 @@ func (c *CategoriesTab) handleAddCategory(name string) tea.Cmd {
 		return nil
 	}
 
+	if err := cat.SetParent(c.addParentID); err != nil {
+		c.SetError(fmt.Sprintf("Error: %v", err))
+		return nil
+	}
+
 	err = c.repos.Categories.Create(cat)
 	if err != nil {
 		c.SetError(fmt.Sprintf("Error creating category: %v", err))
@@ -521,12 +636,12 @@ func (c *CategoriesTab) handleEditCategory(name string) tea.Cmd {
 		return nil
 	}
 
-	existing, _ := c.repos.Categories.FindByName(name)
-	if existing != nil && existing.ID() != c.selectedCat.ID() {
+	if c.siblingExists(c.selectedCat.ParentID(), name, c.selectedCat.ID()) {
 		c.SetError("Category name already exists")
 		return nil
 	}
 
+	oldName := c.selectedCat.Name()
 	err := c.selectedCat.SetName(name)
 	if err != nil {
 		c.SetError(fmt.Sprintf("Error: %v", err))
@@ -535,6 +650,7 @@ func (c *CategoriesTab) handleEditCategory(name string) tea.Cmd {
 
 	err = c.repos.Categories.Update(c.selectedCat)
 	if err != nil {
+		c.selectedCat.SetName(oldName)
 		c.SetError(fmt.Sprintf("Error updating category: %v", err))
 		return nil
 	}
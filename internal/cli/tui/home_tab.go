@@ -1,33 +1,57 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/7-Dany/snip/internal/cli/components"
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/7-Dany/snip/internal/storage"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
-// HomeTab displays welcome message and navigation instructions.
+// homeSectionLimit is the maximum number of snippets listed per home section.
+const homeSectionLimit = 5
+
+// homeStatsLimit is the maximum number of entries listed per statistics breakdown.
+const homeStatsLimit = 5
+
+// HomeTab displays library statistics and pinned, favorite and recently used snippets.
 type HomeTab struct {
 	components.ViewportTab
+	repos *storage.Repositories
+	stats *domain.LibraryStats // computed when the tab is shown
 }
 
 // NewHomeTab creates a new home tab instance.
-func NewHomeTab() HomeTab {
+func NewHomeTab(repos *storage.Repositories) HomeTab {
 	return HomeTab{
 		ViewportTab: components.NewViewportTab(),
+		repos:       repos,
 	}
 }
 
 func (h HomeTab) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
-	// Handle viewport resize
 	switch msg := msg.(type) {
 	case components.ViewportResizeMsg:
 		h.ViewportTab, cmd = h.ViewportTab.HandleResize(msg)
+		if h.stats == nil {
+			h.stats, _ = h.repos.Stats(homeSectionLimit)
+		}
 		if h.IsReady() {
 			h.SetContent(h.getContent())
 		}
 		return h, cmd
+	case components.TabShownMsg:
+		// Refresh sections since usage changes while other tabs are active
+		h.stats, _ = h.repos.Stats(homeSectionLimit)
+		if h.IsReady() {
+			h.SetContent(h.getContent())
+		}
+		return h, nil
 	}
 
 	// Update viewport for scrolling
@@ -36,66 +60,138 @@ func (h HomeTab) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (h HomeTab) getContent() string {
-	return `
-  ████████
-██        ██
-██  ██  ██  ██
-██  ██  ██  ██
-██          ██
-██  ████████ ██
-████      ████
-
-SNIP - Code Snippet Manager
-
-Welcome! Use the tabs above to navigate:
-- Home - You are here
-- Categories - Manage snippet categories
-- Tags - Manage snippet tags
-- Snippets - Manage code snippets
-
-Navigation:
-  Ctrl+F      - Toggle between Interactive and Navigation mode
-  Tab         - Next tab (in Navigation mode)
-  Shift+Tab   - Previous tab (in Navigation mode)
-  ←/→         - Switch tabs (in Navigation mode)
-  PgUp/PgDn   - Scroll content (in Interactive mode)
-  Ctrl+←/→    - Scroll horizontally
-  Ctrl+C      - Quit
-
-Mode Indicators:
-  ⌨ (green)   - Interactive mode: Components receive keyboard input
-  ⇄ (blue)    - Navigation mode: Tab switching and scrolling enabled
-
-The mode indicator appears in the active tab header.
-
----
-
-Additional Information:
-This is a terminal-based code snippet manager that helps you organize
-and quickly access your code snippets. You can categorize snippets,
-add tags for better organization, and search through your collection.
-
-Features:
-- Category management
-- Tag-based organization
-- Full-text search
-- Syntax highlighting
-- Export/Import functionality
-- Quick snippet insertion
-
-Getting Started:
-1. Create categories to organize your snippets
-2. Add tags for flexible organization
-3. Start adding your code snippets
-4. Use search to quickly find what you need
-
-Tips:
-- Use descriptive names for categories
-- Tag snippets with multiple relevant tags
-- Include context in snippet descriptions
-- Regularly review and update your snippets
-
-This content is scrollable when it exceeds the viewport height.
-Try resizing your terminal to see the viewport adjust automatically!
-`
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("13"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	b.WriteString(titleStyle.Render("SNIP - Code Snippet Manager"))
+	b.WriteString("\n\n")
+
+	if h.stats != nil {
+		h.writeStats(&b, h.stats)
+	}
+
+	pinned, _ := h.repos.Snippets.FindPinned()
+	h.writeSection(&b, "📌 Pinned", pinned, "No pinned snippets. Press 'p' on a snippet in the Snippets tab.", nil)
+
+	favorites, _ := h.repos.Snippets.FindFavorites()
+	h.writeSection(&b, "★ Favorites", favorites, "No favorites yet. Press 'f' on a snippet in the Snippets tab.", nil)
+
+	recent, _ := h.repos.Snippets.FindRecent(homeSectionLimit)
+	h.writeSection(&b, "🕘 Recently Used", recent, "Nothing used yet. View or copy a snippet to see it here.",
+		func(s *domain.Snippet) string { return s.LastUsedAt().Format("2006-01-02 15:04") })
+
+	top, _ := h.repos.Snippets.FindMostUsed(homeSectionLimit)
+	h.writeSection(&b, "🔥 Most Used", top, "Nothing used yet.",
+		func(s *domain.Snippet) string { return fmt.Sprintf("%d use(s)", s.UseCount()) })
+
+	b.WriteString(hintStyle.Render(
+		"Ctrl+F: toggle Interactive/Navigation mode | Tab/←→: switch tabs (Navigation mode)\n" +
+			"PgUp/PgDn: scroll | Ctrl+←/→: scroll horizontally | Ctrl+C: quit"))
+
+	return b.String()
+}
+
+// writeSection renders a titled list of snippets, truncated to homeSectionLimit.
+// detail, if non-nil, returns extra text shown after each snippet.
+func (h HomeTab) writeSection(b *strings.Builder, title string, snippets []*domain.Snippet, emptyMsg string, detail func(*domain.Snippet) string) {
+	sectionStyle := lipgloss.NewStyle().Bold(true)
+	metaStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
+
+	b.WriteString(sectionStyle.Render(title))
+	b.WriteString("\n")
+
+	if len(snippets) == 0 {
+		b.WriteString("  " + metaStyle.Render(emptyMsg) + "\n\n")
+		return
+	}
+
+	if len(snippets) > homeSectionLimit {
+		snippets = snippets[:homeSectionLimit]
+	}
+
+	for _, s := range snippets {
+		line := fmt.Sprintf("  #%-4d %s", s.ID(), truncate(s.Title(), 40))
+		b.WriteString(line)
+		b.WriteString(" ")
+		b.WriteString(metaStyle.Render(s.Language()))
+		if detail != nil {
+			b.WriteString(metaStyle.Render(" · " + detail(s)))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+// writeStats renders the library statistics overview.
+func (h HomeTab) writeStats(b *strings.Builder, stats *domain.LibraryStats) {
+	sectionStyle := lipgloss.NewStyle().Bold(true)
+	metaStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+
+	b.WriteString(sectionStyle.Render("📊 Library"))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("  %d snippet(s) · %d categor(ies) · %d tag(s) · %d line(s) of code\n",
+		stats.TotalSnippets, stats.TotalCategories, stats.TotalTags, stats.TotalLines))
+
+	if stats.TotalSnippets == 0 {
+		b.WriteString("  " + metaStyle.Render("No snippets yet. Press 'a' in the Snippets tab to add one.") + "\n\n")
+		return
+	}
+
+	b.WriteString(fmt.Sprintf("  %-12s %s\n", "Languages:", formatStatCounts(stats.ByLanguage)))
+	b.WriteString(fmt.Sprintf("  %-12s %s\n", "Categories:", formatStatCounts(stats.ByCategory)))
+	b.WriteString(fmt.Sprintf("  %-12s %s\n", "Tags:", formatStatCounts(stats.ByTag)))
+
+	if len(stats.RecentlyAdded) > 0 {
+		latest := stats.RecentlyAdded[0]
+		b.WriteString(fmt.Sprintf("  %-12s #%d %s %s\n", "Added:", latest.ID, truncate(latest.Title, 40),
+			metaStyle.Render(latest.CreatedAt.Format("2006-01-02 15:04"))))
+	}
+	if len(stats.RecentlyUpdated) > 0 {
+		latest := stats.RecentlyUpdated[0]
+		b.WriteString(fmt.Sprintf("  %-12s #%d %s %s\n", "Updated:", latest.ID, truncate(latest.Title, 40),
+			metaStyle.Render(latest.UpdatedAt.Format("2006-01-02 15:04"))))
+	}
+
+	var warnings []string
+	if n := len(stats.Untagged); n > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d untagged", n))
+	}
+	if n := len(stats.Uncategorized); n > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d uncategorized", n))
+	}
+	if n := len(stats.Orphaned); n > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d orphaned reference(s)", n))
+	}
+	if len(warnings) > 0 {
+		b.WriteString("  " + warnStyle.Render("⚠ "+strings.Join(warnings, " · ")) + "\n")
+	}
+	b.WriteString("\n")
+}
+
+// formatStatCounts formats the top homeStatsLimit counts as "go (3), sql (1)",
+// omitting entries without snippets.
+func formatStatCounts(counts []domain.StatCount) string {
+	parts := make([]string, 0, homeStatsLimit)
+	for _, c := range counts {
+		if c.Count == 0 || len(parts) == homeStatsLimit {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (%d)", c.Name, c.Count))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	remaining := 0
+	for _, c := range counts[len(parts):] {
+		if c.Count > 0 {
+			remaining++
+		}
+	}
+	if remaining > 0 {
+		parts = append(parts, fmt.Sprintf("+%d more", remaining))
+	}
+	return strings.Join(parts, ", ")
 }
@@ -2,11 +2,17 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/7-Dany/snip/internal/cli/components"
+	"github.com/7-Dany/snip/internal/cli/config"
 	"github.com/7-Dany/snip/internal/domain"
+	"github.com/7-Dany/snip/internal/formatter"
+	"github.com/7-Dany/snip/internal/runner"
 	"github.com/7-Dany/snip/internal/storage"
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -24,11 +30,25 @@ const (
 	snippetViewHelp
 	snippetViewSelectCategory
 	snippetViewSelectTags
+	snippetViewBulkMenu
+	snippetViewBulkTag
+	snippetViewBulkCategory
+	snippetViewBulkLanguage
+	snippetViewBulkDelete
+	snippetViewRun
 )
 
+// snippetRunMsg carries the outcome of running a snippet in the background.
+type snippetRunMsg struct {
+	snippetID int
+	result    *runner.Result
+	err       error
+}
+
 type SnippetsTab struct {
 	components.ViewportTab // Embed base viewport functionality
 	repos                  *storage.Repositories
+	config                 *config.Config
 	mode                   snippetViewMode
 
 	// Components
@@ -40,26 +60,36 @@ type SnippetsTab struct {
 	confirmDialog    components.ConfirmDialog
 	categorySelector components.SelectorView
 	tagSelector      components.SelectorView
+	formView         components.FormView
 
 	// State
 	selectedSnippet *domain.Snippet
+	bulkRemoveTag   bool // Whether the bulk tag selector removes instead of adds
+	runResult       *runner.Result
+	runErr          error
+	running         bool
 	width           int
 	height          int
 }
 
-func NewSnippetsTab(repos *storage.Repositories) *SnippetsTab {
+func NewSnippetsTab(repos *storage.Repositories, cfg *config.Config) *SnippetsTab {
+	columns := []table.Column{
+		{Title: "ID", Width: 6},
+		{Title: "Title", Width: 25},
+		{Title: "Language", Width: 12},
+		{Title: "Category", Width: 12},
+		{Title: "Tags", Width: 20},
+		{Title: "Created", Width: 18},
+	}
+	if repos.Layered() {
+		// Show which library each snippet comes from
+		columns = append(columns, table.Column{Title: "Origin", Width: 8})
+	}
 	tableView := components.NewSearchableTableView(
-		[]table.Column{
-			{Title: "ID", Width: 6},
-			{Title: "Title", Width: 25},
-			{Title: "Language", Width: 12},
-			{Title: "Category", Width: 12},
-			{Title: "Tags", Width: 20},
-			{Title: "Created", Width: 18},
-		},
+		columns,
 		"Snippets",
 		"No snippets found.\n\nPress 'a' to add your first snippet.\nPress '?' for help.",
-		"Enter: menu | a: add | /: search | r: refresh | ?: help",
+		"Enter: menu | a: add | c: copy | f: favorite | p: pin | Space: select | b: bulk | /: search | ?: help",
 		10,
 	)
 
@@ -71,18 +101,32 @@ func NewSnippetsTab(repos *storage.Repositories) *SnippetsTab {
 				Items: []components.HelpItem{
 					{Action: "Open snippet menu", Key: "Enter"},
 					{Action: "Add new snippet", Key: "a"},
+					{Action: "Copy code to clipboard", Key: "c"},
+					{Action: "Toggle favorite", Key: "f"},
+					{Action: "Toggle pin", Key: "p"},
 					{Action: "Search snippets", Key: "/"},
 					{Action: "Refresh list", Key: "r"},
 					{Action: "Show this help", Key: "?"},
 				},
 			},
+			{
+				Title: "Bulk Actions",
+				Items: []components.HelpItem{
+					{Action: "Select/deselect snippet", Key: "Space"},
+					{Action: "Select all visible", Key: "A"},
+					{Action: "Clear selection", Key: "Esc"},
+					{Action: "Open bulk menu", Key: "b"},
+				},
+			},
 			{
 				Title: "Editor Navigation",
 				Items: []components.HelpItem{
 					{Action: "Next field", Key: "Tab"},
 					{Action: "Previous field", Key: "Shift+Tab"},
+					{Action: "Accept language suggestion", Key: "→"},
 					{Action: "Select category", Key: "Alt+C"},
 					{Action: "Manage tags", Key: "Alt+T"},
+					{Action: "Replace secrets with placeholders", Key: "Alt+R"},
 					{Action: "Save snippet", Key: "Ctrl+S"},
 					{Action: "Cancel editing", Key: "Esc"},
 				},
@@ -94,12 +138,22 @@ func NewSnippetsTab(repos *storage.Repositories) *SnippetsTab {
 					{Action: "Back to list", Key: "Esc / q"},
 				},
 			},
+			{
+				Title: "Run Output",
+				Items: []components.HelpItem{
+					{Action: "Run snippet from its menu", Key: "r"},
+					{Action: "Scroll output", Key: "↑↓ / PgUp/PgDn"},
+					{Action: "Run again", Key: "r"},
+					{Action: "Back to list", Key: "Esc / q"},
+				},
+			},
 		},
 	)
 
 	tab := &SnippetsTab{
 		ViewportTab: components.NewViewportTab(),
 		repos:       repos,
+		config:      cfg,
 		mode:        snippetViewList,
 		tableView:   tableView,
 		helpView:    helpView,
@@ -124,11 +178,14 @@ func (s *SnippetsTab) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Update code editor size if in editor mode
 		if s.mode == snippetViewAdd || s.mode == snippetViewEdit {
-			s.codeEditor = components.NewCodeEditor(s.width, s.height)
+			s.codeEditor = s.newCodeEditor()
 			// Restore values if in edit mode
 			if s.mode == snippetViewEdit && s.selectedSnippet != nil {
 				s.restoreEditorValues()
 			}
+			if s.mode == snippetViewAdd {
+				s.codeEditor.SetLanguageDetector(detectLanguage)
+			}
 		}
 
 		s.updateViewportContent()
@@ -144,6 +201,15 @@ func (s *SnippetsTab) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		s.scrollToFocusedField(msg.FieldLine)
 		return s, nil
 
+	case snippetRunMsg:
+		if s.mode == snippetViewRun && s.selectedSnippet != nil && s.selectedSnippet.ID() == msg.snippetID {
+			s.running = false
+			s.runResult = msg.result
+			s.runErr = msg.err
+			s.updateViewportContent()
+		}
+		return s, nil
+
 	case tea.KeyMsg:
 		s.ClearMessages()
 	}
@@ -177,6 +243,24 @@ func (s *SnippetsTab) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case snippetViewSelectTags:
 		cmd = s.updateTagSelector(msg)
 		cmds = append(cmds, cmd)
+	case snippetViewBulkMenu:
+		cmd = s.updateBulkMenu(msg)
+		cmds = append(cmds, cmd)
+	case snippetViewBulkTag:
+		cmd = s.updateBulkTagSelector(msg)
+		cmds = append(cmds, cmd)
+	case snippetViewBulkCategory:
+		cmd = s.updateBulkCategorySelector(msg)
+		cmds = append(cmds, cmd)
+	case snippetViewBulkLanguage:
+		cmd = s.updateBulkLanguage(msg)
+		cmds = append(cmds, cmd)
+	case snippetViewBulkDelete:
+		cmd = s.updateBulkDelete(msg)
+		cmds = append(cmds, cmd)
+	case snippetViewRun:
+		cmd = s.updateRunOutput(msg)
+		cmds = append(cmds, cmd)
 	}
 
 	// Only update viewport for scrolling if NOT in editor mode or if on buttons
@@ -221,7 +305,7 @@ func (s *SnippetsTab) updateViewportContent() {
 	switch s.mode {
 	case snippetViewList:
 		b.WriteString(s.tableView.View())
-	case snippetViewMenu:
+	case snippetViewMenu, snippetViewBulkMenu:
 		b.WriteString(s.menuView.View())
 	case snippetViewAdd, snippetViewEdit:
 		header := "Add New Snippet"
@@ -233,8 +317,8 @@ func (s *SnippetsTab) updateViewportContent() {
 		b.WriteString(s.codeEditor.View())
 		b.WriteString("\n\n")
 		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("240")).
-			Render("Tab/Shift+Tab: navigate | Alt+C: category | Alt+T: tags | Ctrl+S: save | Esc: cancel"))
-	case snippetViewDelete:
+			Render("Tab/Shift+Tab: navigate | Alt+C: category | Alt+T: tags | Alt+R: redact secrets | Ctrl+S: save | Esc: cancel"))
+	case snippetViewDelete, snippetViewBulkDelete:
 		b.WriteString(s.confirmDialog.View())
 	case snippetViewCode:
 		b.WriteString(s.codeViewer.View())
@@ -243,10 +327,14 @@ func (s *SnippetsTab) updateViewportContent() {
 			Render("Esc/q: back to list"))
 	case snippetViewHelp:
 		b.WriteString(s.helpView.View())
-	case snippetViewSelectCategory:
+	case snippetViewSelectCategory, snippetViewBulkCategory:
 		b.WriteString(s.categorySelector.View())
-	case snippetViewSelectTags:
+	case snippetViewSelectTags, snippetViewBulkTag:
 		b.WriteString(s.tagSelector.View())
+	case snippetViewBulkLanguage:
+		b.WriteString(s.formView.View())
+	case snippetViewRun:
+		b.WriteString(s.renderRunOutput())
 	}
 
 	s.SetContent(b.String())
@@ -282,7 +370,7 @@ func (s *SnippetsTab) restoreEditorValues() {
 	if s.selectedSnippet.CategoryID() != 0 {
 		cat, err := s.repos.Categories.FindByID(s.selectedSnippet.CategoryID())
 		if err == nil {
-			s.codeEditor.SetCategory(cat.ID(), cat.Name())
+			s.codeEditor.SetCategory(cat.ID(), s.categoryPath(cat.ID()))
 		}
 	}
 	tagIDs := s.selectedSnippet.Tags()
@@ -303,6 +391,11 @@ func (s *SnippetsTab) refreshTable() {
 		return
 	}
 
+	// Pinned snippets always come first
+	sort.SliceStable(snippets, func(i, j int) bool {
+		return snippets[i].IsPinned() && !snippets[j].IsPinned()
+	})
+
 	var rows []table.Row
 	for _, snip := range snippets {
 		categoryName := "None"
@@ -325,19 +418,37 @@ func (s *SnippetsTab) refreshTable() {
 			tagsStr = strings.Join(tagNames, ", ")
 		}
 
-		rows = append(rows, table.Row{
+		title := snip.Title()
+		if snip.IsFavorite() {
+			title = "★ " + title
+		}
+		if snip.IsPinned() {
+			title = "📌 " + title
+		}
+
+		row := table.Row{
 			fmt.Sprintf("%d", snip.ID()),
-			truncate(snip.Title(), 25),
+			truncate(title, 25),
 			snip.Language(),
 			truncate(categoryName, 12),
 			truncate(tagsStr, 20),
 			snip.CreatedAt().Format("2006-01-02 15:04"),
-		})
+		}
+		if s.repos.Layered() {
+			row = append(row, s.repos.Origin(snip.ID()))
+		}
+		rows = append(rows, row)
 	}
 
 	s.tableView.SetRows(rows)
 }
 
+// categoryPath returns the full path of a category, e.g. "backend/db/postgres".
+func (s *SnippetsTab) categoryPath(id int) string {
+	categories, _ := s.repos.Categories.List()
+	return domain.NewCategoryTree(categories).Path(id)
+}
+
 func truncate(str string, max int) string {
 	if len(str) <= max {
 		return str
@@ -347,13 +458,14 @@ func truncate(str string, max int) string {
 
 func (s *SnippetsTab) loadCategoriesIntoSelector() {
 	categories, _ := s.repos.Categories.List()
+	tree := domain.NewCategoryTree(categories)
 
 	var rows []table.Row
 	for _, cat := range categories {
 		snippets, _ := s.repos.Snippets.FindByCategory(cat.ID())
 		rows = append(rows, table.Row{
 			fmt.Sprintf("%d", cat.ID()),
-			cat.Name(),
+			tree.Path(cat.ID()),
 			fmt.Sprintf("%d", len(snippets)),
 		})
 	}
@@ -406,19 +518,50 @@ func (s *SnippetsTab) updateList(msg tea.Msg) tea.Cmd {
 			s.tableView.ToggleSearch()
 			return nil
 		case "enter":
-			selected := s.tableView.SelectedRow()
-			if len(selected) > 0 {
-				if snip := s.findSnippetByTitle(selected[1]); snip != nil {
-					s.selectedSnippet = snip
-					s.mode = snippetViewMenu
-					s.createSnippetMenu()
-					s.GotoTop()
-					return nil
-				}
+			if snip := s.selectedRowSnippet(); snip != nil {
+				s.selectedSnippet = snip
+				s.mode = snippetViewMenu
+				s.createSnippetMenu()
+				s.GotoTop()
+				return nil
+			}
+		case "c":
+			if snip := s.selectedRowSnippet(); snip != nil {
+				s.copySnippet(snip)
+			}
+			return nil
+		case "f":
+			if snip := s.selectedRowSnippet(); snip != nil {
+				s.toggleFavorite(snip)
+			}
+			return nil
+		case "p":
+			if snip := s.selectedRowSnippet(); snip != nil {
+				s.togglePinned(snip)
 			}
+			return nil
 		case "a":
 			s.mode = snippetViewAdd
-			s.codeEditor = components.NewCodeEditor(s.width, s.height)
+			s.codeEditor = s.newCodeEditor()
+			s.codeEditor.SetLanguageDetector(detectLanguage)
+			s.GotoTop()
+			return nil
+		case " ":
+			s.tableView.ToggleSelected()
+			return nil
+		case "A":
+			s.tableView.SelectAllVisible()
+			return nil
+		case "esc":
+			s.tableView.ClearSelection()
+			return nil
+		case "b":
+			if s.tableView.SelectionCount() == 0 {
+				s.SetError("No snippets selected. Press Space to select snippets")
+				return nil
+			}
+			s.mode = snippetViewBulkMenu
+			s.createBulkMenu()
 			s.GotoTop()
 			return nil
 		case "r":
@@ -465,27 +608,47 @@ func (s *SnippetsTab) updateMenu(msg tea.Msg) tea.Cmd {
 			s.GotoTop()
 			return nil
 		case "v":
-			s.mode = snippetViewCode
-			s.codeViewer = components.NewCodeViewer(
-				s.selectedSnippet.Title(),
-				s.selectedSnippet.Language(),
-				s.selectedSnippet.Description(),
-				s.selectedSnippet.Code(),
-				s.width,
-			)
+			s.openCodeViewer()
+			return nil
+		case "c":
+			s.copySnippet(s.selectedSnippet)
+			s.mode = snippetViewList
 			s.GotoTop()
 			return nil
+		case "r":
+			return s.runSelectedSnippet()
 		case "e":
+			if !s.writable(s.selectedSnippet) {
+				return nil
+			}
 			s.mode = snippetViewEdit
-			s.codeEditor = components.NewCodeEditor(s.width, s.height)
+			s.codeEditor = s.newCodeEditor()
 			s.restoreEditorValues()
 			s.GotoTop()
 			return nil
+		case "f":
+			s.toggleFavorite(s.selectedSnippet)
+			s.mode = snippetViewList
+			s.GotoTop()
+			return nil
+		case "p":
+			s.togglePinned(s.selectedSnippet)
+			s.mode = snippetViewList
+			s.GotoTop()
+			return nil
 		case "x":
+			if !s.writable(s.selectedSnippet) {
+				return nil
+			}
 			s.mode = snippetViewDelete
 			s.createDeleteDialog()
 			s.GotoTop()
 			return nil
+		case "o":
+			if s.repos.ReadOnly(s.selectedSnippet.ID()) {
+				s.forkSnippet(s.selectedSnippet)
+			}
+			return nil
 		}
 	}
 	return nil
@@ -530,6 +693,9 @@ func (s *SnippetsTab) updateEditor(msg tea.Msg, isAdd bool) tea.Cmd {
 			s.loadTagsIntoSelector()
 			s.GotoTop()
 			return nil
+		case "alt+r":
+			s.redactSecrets()
+			return nil
 		case "enter":
 			// Handle enter on buttons
 			if s.codeEditor.IsCancelFocused() {
@@ -548,6 +714,19 @@ func (s *SnippetsTab) updateEditor(msg tea.Msg, isAdd bool) tea.Cmd {
 	return cmd
 }
 
+// newCodeEditor creates a code editor that autocompletes known languages.
+func (s *SnippetsTab) newCodeEditor() components.CodeEditor {
+	editor := components.NewCodeEditor(s.width, s.height)
+	editor.SetLanguageSuggestions(domain.LanguageNames())
+	return editor
+}
+
+// detectLanguage adapts domain.DetectLanguage to the code editor.
+func detectLanguage(code string) (string, float64, bool) {
+	guess := domain.DetectLanguage("", code)
+	return guess.Language, guess.Confidence, guess.Confident()
+}
+
 func (s *SnippetsTab) handleSaveSnippet(isAdd bool) tea.Cmd {
 	title, language, description, code := s.codeEditor.GetValues()
 
@@ -563,6 +742,33 @@ func (s *SnippetsTab) handleSaveSnippet(isAdd bool) tea.Cmd {
 		s.SetError("Code cannot be empty")
 		return nil
 	}
+	if s.codeEditor.NeedsLanguageConfirmation() {
+		_, confidence := s.codeEditor.DetectedLanguage()
+		s.codeEditor.ConfirmLanguage()
+		s.SetError(fmt.Sprintf("Language '%s' was guessed with low confidence (%.0f%%). Save again to confirm or edit the language",
+			language, confidence*100))
+		return nil
+	}
+
+	// A failed check is shown once; saving the same code again keeps it as is.
+	if s.config.FormatOnSave && !s.codeEditor.HasCodeError() {
+		external, _ := s.config.FormatterFor(language)
+		formatted, err := formatter.Format(language, code, external.Command, formatter.DefaultTimeout)
+		if err != nil {
+			s.codeEditor.SetCodeError(err.Error())
+			s.SetError("Code check failed. Fix the code or save again to keep it as is")
+			return nil
+		}
+		code = formatted
+	}
+
+	// Secrets are also reported once; saving again keeps them.
+	if findings := domain.ScanSecrets(code); len(findings) > 0 && !s.codeEditor.HasCodeWarning() {
+		s.codeEditor.SetCode(code)
+		s.codeEditor.SetCodeWarning(secretsWarning(findings))
+		s.SetError("Possible secrets found. Press Alt+R to replace them with placeholders or save again to keep them")
+		return nil
+	}
 
 	if isAdd {
 		return s.handleAddSnippet(title, language, description, code)
@@ -570,6 +776,27 @@ func (s *SnippetsTab) handleSaveSnippet(isAdd bool) tea.Cmd {
 	return s.handleEditSnippet(title, language, description, code)
 }
 
+// redactSecrets replaces secrets in the edited code with placeholders.
+func (s *SnippetsTab) redactSecrets() {
+	_, _, _, code := s.codeEditor.GetValues()
+	findings := domain.ScanSecrets(code)
+	if len(findings) == 0 {
+		s.SetSuccess("No secrets found")
+		return
+	}
+	s.codeEditor.SetCode(domain.RedactSecrets(code, findings))
+	s.SetSuccess(fmt.Sprintf("Replaced %d secret(s) with placeholders", len(findings)))
+}
+
+// secretsWarning describes secret findings for the code editor.
+func secretsWarning(findings []domain.SecretFinding) string {
+	parts := make([]string, len(findings))
+	for i, f := range findings {
+		parts[i] = fmt.Sprintf("line %d: %s %s", f.Line, f.Kind, f.Masked())
+	}
+	return "Possible secrets: " + strings.Join(parts, "; ")
+}
+
 func (s *SnippetsTab) updateDelete(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -626,7 +853,7 @@ func (s *SnippetsTab) updateCategorySelector(msg tea.Msg) tea.Cmd {
 			if categoryID != 0 {
 				cat, err := s.repos.Categories.FindByID(categoryID)
 				if err == nil {
-					s.codeEditor.SetCategory(cat.ID(), cat.Name())
+					s.codeEditor.SetCategory(cat.ID(), s.categoryPath(cat.ID()))
 				}
 			}
 			if s.selectedSnippet != nil {
@@ -715,18 +942,203 @@ func (s *SnippetsTab) updateTagSelector(msg tea.Msg) tea.Cmd {
 	return cmd
 }
 
-func (s *SnippetsTab) findSnippetByTitle(title string) *domain.Snippet {
-	title = strings.TrimSuffix(title, "...")
+// selectedRowSnippet returns the snippet for the highlighted table row.
+func (s *SnippetsTab) selectedRowSnippet() *domain.Snippet {
+	selected := s.tableView.SelectedRow()
+	if len(selected) == 0 {
+		return nil
+	}
 
-	snippets, _ := s.repos.Snippets.List()
-	for _, snip := range snippets {
-		if strings.HasPrefix(snip.Title(), title) {
-			return snip
+	var id int
+	if _, err := fmt.Sscanf(selected[0], "%d", &id); err != nil {
+		return nil
+	}
+
+	snip, err := s.repos.Snippets.FindByID(id)
+	if err != nil {
+		return nil
+	}
+	return snip
+}
+
+// openCodeViewer shows the selected snippet's code and records the view.
+func (s *SnippetsTab) openCodeViewer() {
+	s.selectedSnippet.RecordView()
+	if err := s.repos.RecordUsage(s.selectedSnippet); err != nil {
+		s.SetError(fmt.Sprintf("Error recording usage: %v", err))
+	}
+
+	s.mode = snippetViewCode
+	s.codeViewer = components.NewCodeViewer(
+		s.selectedSnippet.Title(),
+		s.selectedSnippet.Language(),
+		s.selectedSnippet.Description(),
+		s.selectedSnippet.Code(),
+		s.width,
+	)
+	s.GotoTop()
+}
+
+// runSelectedSnippet starts running the selected snippet in the background
+// and switches to the run output view.
+func (s *SnippetsTab) runSelectedSnippet() tea.Cmd {
+	snip := s.selectedSnippet
+	r, ok := s.config.RunnerFor(snip.Language())
+	if !ok {
+		s.SetError(fmt.Sprintf("No runner configured for language '%s'", snip.Language()))
+		s.mode = snippetViewList
+		s.GotoTop()
+		return nil
+	}
+
+	s.mode = snippetViewRun
+	s.running = true
+	s.runResult = nil
+	s.runErr = nil
+	s.GotoTop()
+
+	id, code, timeout := snip.ID(), snip.Code(), s.config.RunTimeout()
+	return func() tea.Msg {
+		result, err := runner.Run(r.Command, r.Extension, code, nil, timeout)
+		return snippetRunMsg{snippetID: id, result: result, err: err}
+	}
+}
+
+func (s *SnippetsTab) updateRunOutput(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			s.mode = snippetViewList
+			s.GotoTop()
+			return nil
+		case "r":
+			if !s.running {
+				return s.runSelectedSnippet()
+			}
+			return nil
 		}
 	}
 	return nil
 }
 
+// renderRunOutput renders the status, stdout and stderr of the last run.
+func (s *SnippetsTab) renderRunOutput() string {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("13"))
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("240"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	okStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10"))
+	failStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("9"))
+
+	b.WriteString(titleStyle.Render("Run: " + s.selectedSnippet.Title()))
+	b.WriteString("\n\n")
+
+	switch {
+	case s.running:
+		b.WriteString(hintStyle.Render(fmt.Sprintf("Running (timeout %s)...", s.config.RunTimeout())))
+		b.WriteString("\n\n")
+		b.WriteString(hintStyle.Render("Esc/q: back to list"))
+		return b.String()
+	case s.runErr != nil:
+		b.WriteString(failStyle.Render(fmt.Sprintf("Error: %v", s.runErr)))
+		b.WriteString("\n\n")
+		b.WriteString(hintStyle.Render("r: run again | Esc/q: back to list"))
+		return b.String()
+	}
+
+	result := s.runResult
+	elapsed := result.Duration.Round(time.Millisecond)
+	switch {
+	case result.TimedOut:
+		b.WriteString(failStyle.Render(fmt.Sprintf("Timed out after %s", s.config.RunTimeout())))
+	case result.ExitCode != 0:
+		b.WriteString(failStyle.Render(fmt.Sprintf("Exit code %d (%s)", result.ExitCode, elapsed)))
+	default:
+		b.WriteString(okStyle.Render(fmt.Sprintf("Exit code 0 (%s)", elapsed)))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(sectionStyle.Render("── stdout ──"))
+	b.WriteString("\n")
+	b.WriteString(outputOrPlaceholder(result.Stdout))
+	b.WriteString("\n\n")
+	b.WriteString(sectionStyle.Render("── stderr ──"))
+	b.WriteString("\n")
+	b.WriteString(outputOrPlaceholder(result.Stderr))
+	b.WriteString("\n\n")
+
+	if result.Truncated {
+		b.WriteString(hintStyle.Render(fmt.Sprintf("Output truncated to %d bytes per stream", runner.MaxOutputBytes)))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(hintStyle.Render("↑↓: scroll | r: run again | Esc/q: back to list"))
+	return b.String()
+}
+
+// outputOrPlaceholder returns output without its trailing newline, or a placeholder if empty.
+func outputOrPlaceholder(output string) string {
+	if output == "" {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true).Render("(empty)")
+	}
+	return strings.TrimRight(output, "\n")
+}
+
+// copySnippet copies a snippet's code to the clipboard and records the copy.
+func (s *SnippetsTab) copySnippet(snip *domain.Snippet) {
+	if err := clipboard.WriteAll(snip.Code()); err != nil {
+		s.SetError(fmt.Sprintf("Error copying to clipboard: %v", err))
+		return
+	}
+
+	snip.RecordCopy()
+	if err := s.repos.RecordUsage(snip); err != nil {
+		s.SetError(fmt.Sprintf("Error recording usage: %v", err))
+		return
+	}
+
+	s.SetSuccess(fmt.Sprintf("Copied '%s' to clipboard", snip.Title()))
+}
+
+// toggleFavorite flips a snippet's favorite flag.
+func (s *SnippetsTab) toggleFavorite(snip *domain.Snippet) {
+	if !s.writable(snip) {
+		return
+	}
+	snip.SetFavorite(!snip.IsFavorite())
+	if err := s.repos.Snippets.Update(snip); err != nil {
+		s.SetError(fmt.Sprintf("Error updating snippet: %v", err))
+		return
+	}
+
+	if snip.IsFavorite() {
+		s.SetSuccess(fmt.Sprintf("Added '%s' to favorites", snip.Title()))
+	} else {
+		s.SetSuccess(fmt.Sprintf("Removed '%s' from favorites", snip.Title()))
+	}
+	s.refreshTable()
+}
+
+// togglePinned flips a snippet's pinned flag.
+func (s *SnippetsTab) togglePinned(snip *domain.Snippet) {
+	if !s.writable(snip) {
+		return
+	}
+	snip.SetPinned(!snip.IsPinned())
+	if err := s.repos.Snippets.Update(snip); err != nil {
+		s.SetError(fmt.Sprintf("Error updating snippet: %v", err))
+		return
+	}
+
+	if snip.IsPinned() {
+		s.SetSuccess(fmt.Sprintf("Pinned '%s'", snip.Title()))
+	} else {
+		s.SetSuccess(fmt.Sprintf("Unpinned '%s'", snip.Title()))
+	}
+	s.refreshTable()
+}
+
 func (s *SnippetsTab) createSnippetMenu() {
 	categoryName := "None"
 	if s.selectedSnippet.CategoryID() != 0 {
@@ -748,23 +1160,69 @@ func (s *SnippetsTab) createSnippetMenu() {
 		tagsStr = strings.Join(tagNames, ", ")
 	}
 
-	subtitle := fmt.Sprintf("Language: %s | Category: %s\nTags: %s",
+	subtitle := fmt.Sprintf("Language: %s | Category: %s\nTags: %s\nUsed: %d view(s), %d copy(ies)",
 		s.selectedSnippet.Language(),
 		categoryName,
 		tagsStr,
+		s.selectedSnippet.ViewCount(),
+		s.selectedSnippet.CopyCount(),
 	)
 
+	favoriteLabel := "Add to Favorites"
+	if s.selectedSnippet.IsFavorite() {
+		favoriteLabel = "Remove from Favorites"
+	}
+	pinLabel := "Pin Snippet"
+	if s.selectedSnippet.IsPinned() {
+		pinLabel = "Unpin Snippet"
+	}
+
+	items := []components.MenuItem{
+		{Label: "View Code", Shortcut: "v"},
+		{Label: "Copy Code", Shortcut: "c"},
+		{Label: "Run Code", Shortcut: "r"},
+		{Label: "Edit Snippet", Shortcut: "e"},
+		{Label: favoriteLabel, Shortcut: "f"},
+		{Label: pinLabel, Shortcut: "p"},
+		{Label: "Delete Snippet", Shortcut: "x"},
+	}
+	if s.repos.ReadOnly(s.selectedSnippet.ID()) {
+		subtitle += fmt.Sprintf("\nRead-only: from the %s library", s.repos.Origin(s.selectedSnippet.ID()))
+		items = append(items, components.MenuItem{Label: "Fork to Personal Library", Shortcut: "o"})
+	}
+
 	s.menuView = components.NewMenuView(
 		s.selectedSnippet.Title(),
 		subtitle,
-		[]components.MenuItem{
-			{Label: "View Code", Shortcut: "v"},
-			{Label: "Edit Snippet", Shortcut: "e"},
-			{Label: "Delete Snippet", Shortcut: "x"},
-		},
+		items,
 	)
 }
 
+// writable reports whether a snippet can be changed. For a snippet of a
+// read-only library it shows how to fork it instead.
+func (s *SnippetsTab) writable(snip *domain.Snippet) bool {
+	if !s.repos.ReadOnly(snip.ID()) {
+		return true
+	}
+	s.SetError(fmt.Sprintf("'%s' is read-only. Fork it to your personal library to edit it (o in its menu)", snip.Title()))
+	return false
+}
+
+// forkSnippet copies a read-only snippet into the personal library and
+// returns to the list.
+func (s *SnippetsTab) forkSnippet(snip *domain.Snippet) {
+	fork, err := s.repos.Fork(snip.ID())
+	if err != nil {
+		s.SetError(fmt.Sprintf("Error forking snippet: %v", err))
+		return
+	}
+
+	s.SetSuccess(fmt.Sprintf("Forked '%s' into your personal library (ID: %d)", fork.Title(), fork.ID()))
+	s.mode = snippetViewList
+	s.GotoTop()
+	s.refreshTable()
+}
+
 func (s *SnippetsTab) createDeleteDialog() {
 	message := fmt.Sprintf("Title: %s\nLanguage: %s\n\nAre you sure you want to delete this snippet?",
 		s.selectedSnippet.Title(),
@@ -791,27 +1249,36 @@ func (s *SnippetsTab) executeMenuAction() tea.Cmd {
 
 	switch selection {
 	case 0: // View Code
-		s.mode = snippetViewCode
-		s.codeViewer = components.NewCodeViewer(
-			s.selectedSnippet.Title(),
-			s.selectedSnippet.Language(),
-			s.selectedSnippet.Description(),
-			s.selectedSnippet.Code(),
-			s.width,
-		)
-		s.GotoTop()
+		s.openCodeViewer()
 		return nil
-	case 1: // Edit
+	case 1: // Copy Code
+		s.copySnippet(s.selectedSnippet)
+	case 2: // Run Code
+		return s.runSelectedSnippet()
+	case 3: // Edit
+		if !s.writable(s.selectedSnippet) {
+			return nil
+		}
 		s.mode = snippetViewEdit
-		s.codeEditor = components.NewCodeEditor(s.width, s.height)
+		s.codeEditor = s.newCodeEditor()
 		s.restoreEditorValues()
 		s.GotoTop()
 		return nil
-	case 2: // Delete
+	case 4: // Favorite
+		s.toggleFavorite(s.selectedSnippet)
+	case 5: // Pin
+		s.togglePinned(s.selectedSnippet)
+	case 6: // Delete
+		if !s.writable(s.selectedSnippet) {
+			return nil
+		}
 		s.mode = snippetViewDelete
 		s.createDeleteDialog()
 		s.GotoTop()
 		return nil
+	case 7: // Fork, for read-only snippets
+		s.forkSnippet(s.selectedSnippet)
+		return nil
 	}
 
 	s.mode = snippetViewList
@@ -819,6 +1286,271 @@ func (s *SnippetsTab) executeMenuAction() tea.Cmd {
 	return nil
 }
 
+// selectedSnippetIDs returns the IDs of the snippets selected in the table.
+func (s *SnippetsTab) selectedSnippetIDs() []int {
+	keys := s.tableView.SelectedKeys()
+	ids := make([]int, 0, len(keys))
+	for _, key := range keys {
+		var id int
+		if _, err := fmt.Sscanf(key, "%d", &id); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (s *SnippetsTab) createBulkMenu() {
+	s.menuView = components.NewMenuView(
+		fmt.Sprintf("Bulk Actions (%d selected)", s.tableView.SelectionCount()),
+		"Changes apply to all selected snippets at once",
+		[]components.MenuItem{
+			{Label: "Add Tag", Shortcut: "t"},
+			{Label: "Remove Tag", Shortcut: "u"},
+			{Label: "Move to Category", Shortcut: "m"},
+			{Label: "Set Language", Shortcut: "l"},
+			{Label: "Delete Snippets", Shortcut: "x"},
+			{Label: "Clear Selection", Shortcut: "n"},
+		},
+	)
+}
+
+func (s *SnippetsTab) updateBulkMenu(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			s.menuView.MoveUp()
+			return nil
+		case "down", "j":
+			s.menuView.MoveDown()
+			return nil
+		case "enter":
+			if s.menuView.IsCancel() {
+				s.mode = snippetViewList
+				s.GotoTop()
+				return nil
+			}
+			return s.executeBulkAction(s.menuView.GetSelection())
+		case "esc":
+			s.mode = snippetViewList
+			s.GotoTop()
+			return nil
+		case "t":
+			return s.executeBulkAction(0)
+		case "u":
+			return s.executeBulkAction(1)
+		case "m":
+			return s.executeBulkAction(2)
+		case "l":
+			return s.executeBulkAction(3)
+		case "x":
+			return s.executeBulkAction(4)
+		case "n":
+			return s.executeBulkAction(5)
+		}
+	}
+	return nil
+}
+
+func (s *SnippetsTab) executeBulkAction(selection int) tea.Cmd {
+	switch selection {
+	case 0, 1: // Add Tag, Remove Tag
+		s.bulkRemoveTag = selection == 1
+		title := "Add Tag"
+		if s.bulkRemoveTag {
+			title = "Remove Tag"
+		}
+		s.mode = snippetViewBulkTag
+		s.tagSelector = components.NewSelectorView(
+			title,
+			fmt.Sprintf("Choose a tag for %d selected snippet(s)", s.tableView.SelectionCount()),
+			false,
+			s.width,
+			s.height,
+		)
+		s.loadTagsIntoBulkSelector()
+	case 2: // Move to Category
+		s.mode = snippetViewBulkCategory
+		s.categorySelector = components.NewSelectorView(
+			"Move to Category",
+			fmt.Sprintf("Choose a category for %d selected snippet(s)", s.tableView.SelectionCount()),
+			false,
+			s.width,
+			s.height,
+		)
+		s.loadCategoriesIntoSelector()
+	case 3: // Set Language
+		s.mode = snippetViewBulkLanguage
+		s.formView = components.NewFormView(
+			"Set Language",
+			fmt.Sprintf("New language for %d selected snippet(s)", s.tableView.SelectionCount()),
+			"e.g. go",
+		)
+		s.formView.SetLabel("Language")
+		s.GotoTop()
+		return s.formView.Focus()
+	case 4: // Delete
+		s.mode = snippetViewBulkDelete
+		s.confirmDialog = components.NewConfirmDialog(
+			"Delete Snippets",
+			fmt.Sprintf("Are you sure you want to delete %d selected snippet(s)?", s.tableView.SelectionCount()),
+			"⚠ Warning: This action cannot be undone.\nThe snippets will be permanently deleted.",
+		)
+	case 5: // Clear Selection
+		s.tableView.ClearSelection()
+		s.mode = snippetViewList
+	}
+
+	s.GotoTop()
+	return nil
+}
+
+func (s *SnippetsTab) loadTagsIntoBulkSelector() {
+	tags, _ := s.repos.Tags.List()
+
+	var rows []table.Row
+	for _, tag := range tags {
+		snippets, _ := s.repos.Snippets.FindByTag(tag.ID())
+		rows = append(rows, table.Row{
+			fmt.Sprintf("%d", tag.ID()),
+			tag.Name(),
+			fmt.Sprintf("%d", len(snippets)),
+		})
+	}
+
+	s.tagSelector.SetRows(rows)
+}
+
+func (s *SnippetsTab) updateBulkTagSelector(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			tagID := s.tagSelector.GetSelectedID()
+			if tagID == 0 {
+				s.SetError("No tag selected")
+				return nil
+			}
+			if s.bulkRemoveTag {
+				s.applyBulkEdit(domain.RemoveTagEdit(tagID))
+			} else {
+				s.applyBulkEdit(domain.AddTagEdit(tagID))
+			}
+			return nil
+		case "esc", "n":
+			s.mode = snippetViewList
+			s.GotoTop()
+			return nil
+		}
+	}
+
+	s.tagSelector, cmd = s.tagSelector.Update(msg)
+	return cmd
+}
+
+func (s *SnippetsTab) updateBulkCategorySelector(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			categoryID := s.categorySelector.GetSelectedID()
+			if categoryID == 0 {
+				s.SetError("No category selected. Press 'n' to remove the category instead")
+				return nil
+			}
+			s.applyBulkEdit(domain.SetCategoryEdit(categoryID))
+			return nil
+		case "n":
+			s.applyBulkEdit(domain.SetCategoryEdit(0))
+			return nil
+		case "esc":
+			s.mode = snippetViewList
+			s.GotoTop()
+			return nil
+		}
+	}
+
+	s.categorySelector, cmd = s.categorySelector.Update(msg)
+	return cmd
+}
+
+func (s *SnippetsTab) updateBulkLanguage(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			language := strings.TrimSpace(s.formView.Value())
+			if language == "" {
+				s.SetError("Language cannot be empty")
+				return nil
+			}
+			s.applyBulkEdit(domain.SetLanguageEdit(language))
+			return nil
+		case "esc":
+			s.mode = snippetViewList
+			s.GotoTop()
+			return nil
+		}
+	}
+
+	s.formView, cmd = s.formView.Update(msg)
+	return cmd
+}
+
+func (s *SnippetsTab) updateBulkDelete(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "left", "h", "n":
+			s.confirmDialog.SelectNo()
+			return nil
+		case "right", "l", "y":
+			s.confirmDialog.SelectYes()
+			return nil
+		case "enter":
+			if s.confirmDialog.IsYes() {
+				ids := s.selectedSnippetIDs()
+				if err := s.repos.Snippets.BulkDelete(ids); err != nil {
+					s.SetError(fmt.Sprintf("Error deleting snippets, nothing was changed: %v", err))
+				} else {
+					s.SetSuccess(fmt.Sprintf("Deleted %d snippet(s)", len(ids)))
+					s.tableView.ClearSelection()
+					s.refreshTable()
+				}
+			}
+			s.mode = snippetViewList
+			s.GotoTop()
+			return nil
+		case "esc":
+			s.mode = snippetViewList
+			s.GotoTop()
+			return nil
+		}
+	}
+	return nil
+}
+
+// applyBulkEdit applies edit to all selected snippets atomically and returns to the list.
+func (s *SnippetsTab) applyBulkEdit(edit domain.SnippetEdit) {
+	ids := s.selectedSnippetIDs()
+	if err := s.repos.Snippets.BulkUpdate(ids, edit); err != nil {
+		s.SetError(fmt.Sprintf("Error updating snippets, nothing was changed: %v", err))
+	} else {
+		s.SetSuccess(fmt.Sprintf("Updated %d snippet(s)", len(ids)))
+		s.tableView.ClearSelection()
+		s.refreshTable()
+	}
+
+	s.mode = snippetViewList
+	s.GotoTop()
+}
+
 func (s *SnippetsTab) handleAddSnippet(title, language, description, code string) tea.Cmd {
 	snippet, err := domain.NewSnippet(title, language, code)
 	if err != nil {
@@ -840,13 +1572,24 @@ func (s *SnippetsTab) handleAddSnippet(title, language, description, code string
 		snippet.AddTag(tagID)
 	}
 
+	duplicates, _ := s.repos.Snippets.FindDuplicates(snippet.Code(), snippet.Language())
+
 	err = s.repos.Snippets.Create(snippet)
 	if err != nil {
 		s.SetError(fmt.Sprintf("Error creating snippet: %v", err))
 		return nil
 	}
 
-	s.SetSuccess("Snippet created successfully")
+	if len(duplicates) > 0 {
+		ids := make([]string, 0, len(duplicates))
+		for _, d := range duplicates {
+			ids = append(ids, fmt.Sprintf("#%d", d.ID()))
+		}
+		s.SetSuccess(fmt.Sprintf("Snippet created; identical code already exists in %s (run 'snip snippet dedupe')",
+			strings.Join(ids, ", ")))
+	} else {
+		s.SetSuccess("Snippet created successfully")
+	}
 	s.mode = snippetViewList
 	s.GotoTop()
 	s.refreshTable()
@@ -862,7 +1605,7 @@ func (s *SnippetsTab) handleEditSnippet(title, language, description, code strin
 		}
 	}
 
-	if language != s.selectedSnippet.Language() {
+	if domain.CanonicalLanguage(language) != s.selectedSnippet.Language() {
 		err := s.selectedSnippet.SetLanguage(language)
 		if err != nil {
 			s.SetError(fmt.Sprintf("Error: %v", err))
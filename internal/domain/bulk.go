@@ -0,0 +1,39 @@
+// Package domain contains the core business entities and logic for the snippet manager.
+package domain
+
+// SnippetEdit modifies a single snippet as part of a bulk operation.
+// An error aborts the whole operation.
+type SnippetEdit func(*Snippet) error
+
+// AddTagEdit returns an edit that adds the tag to a snippet.
+func AddTagEdit(tagID int) SnippetEdit {
+	return func(s *Snippet) error {
+		s.AddTag(tagID)
+		return nil
+	}
+}
+
+// RemoveTagEdit returns an edit that removes the tag from a snippet.
+func RemoveTagEdit(tagID int) SnippetEdit {
+	return func(s *Snippet) error {
+		s.RemoveTag(tagID)
+		return nil
+	}
+}
+
+// SetCategoryEdit returns an edit that moves a snippet to the category.
+// A categoryID of 0 makes the snippet uncategorized.
+func SetCategoryEdit(categoryID int) SnippetEdit {
+	return func(s *Snippet) error {
+		s.SetCategory(categoryID)
+		return nil
+	}
+}
+
+// SetLanguageEdit returns an edit that changes a snippet's language.
+// It fails with ErrEmptyLanguage if language is empty.
+func SetLanguageEdit(language string) SnippetEdit {
+	return func(s *Snippet) error {
+		return s.SetLanguage(language)
+	}
+}
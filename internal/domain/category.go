@@ -11,6 +11,7 @@ import (
 type Category struct {
 	id        int
 	name      string
+	parentID  int
 	createdAt time.Time
 	updatedAt time.Time
 }
@@ -35,6 +36,9 @@ func (c *Category) ID() int { return c.id }
 // Name returns the category's name.
 func (c *Category) Name() string { return c.name }
 
+// ParentID returns the ID of the category's parent, or 0 for a top-level category.
+func (c *Category) ParentID() int { return c.parentID }
+
 // CreatedAt returns the category's creation time.
 func (c *Category) CreatedAt() time.Time { return c.createdAt }
 
@@ -52,6 +56,20 @@ func (c *Category) SetName(name string) error {
 	return nil
 }
 
+// SetParent updates the category's parent ID and modification timestamp.
+// Passing 0 makes the category a top-level category.
+// It returns ErrCategoryCycle if parentID refers to the category itself.
+// Deeper cycles can only be detected against the full set of categories,
+// see CategoryTree.CheckParent.
+func (c *Category) SetParent(parentID int) error {
+	if parentID != 0 && parentID == c.id {
+		return ErrCategoryCycle
+	}
+	c.parentID = parentID
+	c.updatedAt = time.Now()
+	return nil
+}
+
 // SetID sets the category's unique identifier.
 // This should only be called by the storage layer.
 func (c *Category) SetID(id int) {
@@ -71,6 +89,7 @@ func (c *Category) Equal(other *Category) bool {
 	}
 	return c.id == other.id &&
 		c.name == other.name &&
+		c.parentID == other.parentID &&
 		c.createdAt.Equal(other.createdAt) &&
 		c.updatedAt.Equal(other.updatedAt)
 }
@@ -80,11 +99,13 @@ func (c *Category) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&struct {
 		ID        int       `json:"id"`
 		Name      string    `json:"name"`
+		ParentID  int       `json:"parent_id,omitempty"`
 		CreatedAt time.Time `json:"created_at"`
 		UpdatedAt time.Time `json:"updated_at"`
 	}{
 		ID:        c.id,
 		Name:      c.name,
+		ParentID:  c.parentID,
 		CreatedAt: c.createdAt,
 		UpdatedAt: c.updatedAt,
 	})
@@ -95,6 +116,7 @@ func (c *Category) UnmarshalJSON(data []byte) error {
 	aux := &struct {
 		ID        int       `json:"id"`
 		Name      string    `json:"name"`
+		ParentID  int       `json:"parent_id,omitempty"`
 		CreatedAt time.Time `json:"created_at"`
 		UpdatedAt time.Time `json:"updated_at"`
 	}{}
@@ -109,6 +131,7 @@ func (c *Category) UnmarshalJSON(data []byte) error {
 
 	c.id = aux.ID
 	c.name = aux.Name
+	c.parentID = aux.ParentID
 	c.createdAt = aux.CreatedAt
 	c.updatedAt = aux.UpdatedAt
 	return nil
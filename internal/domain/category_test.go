@@ -155,6 +155,50 @@ func TestCategory_SetName(t *testing.T) {
 	})
 }
 
+func TestCategory_SetParent(t *testing.T) {
+	t.Run("sets parent ID", func(t *testing.T) {
+		category := mustCreateCategory(t, "postgres")
+		category.SetID(3)
+
+		if err := category.SetParent(2); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if category.ParentID() != 2 {
+			t.Errorf("expected parent ID 2, got %d", category.ParentID())
+		}
+	})
+
+	t.Run("zero makes category top-level", func(t *testing.T) {
+		category := mustCreateCategory(t, "postgres")
+		category.SetID(3)
+		category.SetParent(2)
+
+		if err := category.SetParent(0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if category.ParentID() != 0 {
+			t.Errorf("expected parent ID 0, got %d", category.ParentID())
+		}
+	})
+
+	t.Run("own ID returns ErrCategoryCycle", func(t *testing.T) {
+		category := mustCreateCategory(t, "postgres")
+		category.SetID(3)
+
+		err := category.SetParent(3)
+
+		if !errors.Is(err, ErrCategoryCycle) {
+			t.Errorf("expected ErrCategoryCycle, got %v", err)
+		}
+
+		if category.ParentID() != 0 {
+			t.Error("parent should not change when SetParent returns error")
+		}
+	})
+}
+
 func TestCategory_String(t *testing.T) {
 	t.Run("with ID and name", func(t *testing.T) {
 		category := mustCreateCategory(t, "algorithms")
@@ -289,6 +333,29 @@ func TestCategory_MarshalJSON(t *testing.T) {
 		if _, ok := result["updated_at"]; !ok {
 			t.Error("missing updated_at field")
 		}
+		if _, ok := result["parent_id"]; ok {
+			t.Error("parent_id should be omitted for top-level categories")
+		}
+	})
+
+	t.Run("includes parent_id for subcategories", func(t *testing.T) {
+		category := mustCreateCategory(t, "postgres")
+		category.SetID(3)
+		category.SetParent(2)
+
+		data, err := json.Marshal(category)
+		if err != nil {
+			t.Fatalf("MarshalJSON failed: %v", err)
+		}
+
+		var result map[string]any
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("generated invalid JSON: %v", err)
+		}
+
+		if parentID, ok := result["parent_id"].(float64); !ok || int(parentID) != 2 {
+			t.Errorf("expected parent_id=2, got %v", result["parent_id"])
+		}
 	})
 }
 
@@ -321,6 +388,25 @@ func TestCategory_UnmarshalJSON(t *testing.T) {
 		}
 	})
 
+	t.Run("unmarshals parent_id", func(t *testing.T) {
+		jsonData := []byte(`{
+			"id": 3,
+			"name": "postgres",
+			"parent_id": 2,
+			"created_at": "2024-01-15T10:30:00Z",
+			"updated_at": "2024-01-16T14:20:00Z"
+		}`)
+
+		var category Category
+		if err := json.Unmarshal(jsonData, &category); err != nil {
+			t.Fatalf("UnmarshalJSON failed: %v", err)
+		}
+
+		if category.ParentID() != 2 {
+			t.Errorf("expected parent ID 2, got %d", category.ParentID())
+		}
+	})
+
 	t.Run("empty name returns error", func(t *testing.T) {
 		jsonData := []byte(`{
 			"id": 1,
@@ -0,0 +1,200 @@
+// Package domain contains the core business entities and logic for the snippet manager.
+package domain
+
+import "strings"
+
+// CategoryPathSeparator separates category names in a hierarchical path
+// such as "backend/db/postgres".
+const CategoryPathSeparator = "/"
+
+// CategoryTree is a read-only view over a set of categories that resolves
+// parent/child relationships. Categories whose parent does not exist in the
+// set are treated as top-level categories, so flat data keeps working.
+type CategoryTree struct {
+	byID     map[int]*Category
+	children map[int][]*Category
+	roots    []*Category
+}
+
+// NewCategoryTree builds a tree from the given categories.
+// The order of categories is preserved among siblings.
+func NewCategoryTree(categories []*Category) *CategoryTree {
+	t := &CategoryTree{
+		byID:     make(map[int]*Category, len(categories)),
+		children: make(map[int][]*Category),
+		roots:    make([]*Category, 0),
+	}
+
+	for _, c := range categories {
+		t.byID[c.ID()] = c
+	}
+
+	for _, c := range categories {
+		parentID := c.ParentID()
+		if _, ok := t.byID[parentID]; parentID == 0 || !ok || parentID == c.ID() {
+			t.roots = append(t.roots, c)
+			continue
+		}
+		t.children[parentID] = append(t.children[parentID], c)
+	}
+
+	return t
+}
+
+// Roots returns the top-level categories.
+func (t *CategoryTree) Roots() []*Category {
+	roots := make([]*Category, len(t.roots))
+	copy(roots, t.roots)
+	return roots
+}
+
+// Children returns the direct subcategories of the category with the given ID.
+func (t *CategoryTree) Children(id int) []*Category {
+	children := make([]*Category, len(t.children[id]))
+	copy(children, t.children[id])
+	return children
+}
+
+// Ancestors returns the chain of parents of the category with the given ID,
+// starting at the top-level category and ending with the direct parent.
+// Corrupt data containing a cycle is cut off at the first repeated category.
+func (t *CategoryTree) Ancestors(id int) []*Category {
+	c, ok := t.byID[id]
+	if !ok {
+		return nil
+	}
+
+	var chain []*Category
+	visited := map[int]bool{id: true}
+	for parentID := c.ParentID(); parentID != 0 && !visited[parentID]; {
+		parent, ok := t.byID[parentID]
+		if !ok {
+			break
+		}
+		visited[parentID] = true
+		chain = append([]*Category{parent}, chain...)
+		parentID = parent.ParentID()
+	}
+	return chain
+}
+
+// Depth returns how many ancestors the category has; top-level categories have depth 0.
+func (t *CategoryTree) Depth(id int) int {
+	return len(t.Ancestors(id))
+}
+
+// Path returns the full path of the category, e.g. "backend/db/postgres".
+// It returns an empty string if the category is not part of the tree.
+func (t *CategoryTree) Path(id int) string {
+	c, ok := t.byID[id]
+	if !ok {
+		return ""
+	}
+
+	names := make([]string, 0, 4)
+	for _, ancestor := range t.Ancestors(id) {
+		names = append(names, ancestor.Name())
+	}
+	names = append(names, c.Name())
+	return strings.Join(names, CategoryPathSeparator)
+}
+
+// Descendants returns the IDs of all subcategories of the category with the
+// given ID, at any depth. The category itself is not included.
+func (t *CategoryTree) Descendants(id int) []int {
+	var ids []int
+	visited := map[int]bool{id: true}
+	queue := []int{id}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range t.children[current] {
+			if visited[child.ID()] {
+				continue
+			}
+			visited[child.ID()] = true
+			ids = append(ids, child.ID())
+			queue = append(queue, child.ID())
+		}
+	}
+	return ids
+}
+
+// FindByPath resolves a slash-separated path such as "backend/db/postgres"
+// to a category. Leading, trailing and repeated separators are ignored.
+// A top-level category whose name matches the whole path is preferred, so
+// legacy flat categories containing a separator can still be found.
+// It returns nil if no category matches.
+func (t *CategoryTree) FindByPath(path string) *Category {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil
+	}
+
+	for _, root := range t.roots {
+		if root.Name() == path {
+			return root
+		}
+	}
+
+	segments := SplitCategoryPath(path)
+	if len(segments) == 0 {
+		return nil
+	}
+
+	level := t.roots
+	var found *Category
+	for _, segment := range segments {
+		found = nil
+		for _, c := range level {
+			if c.Name() == segment {
+				found = c
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		level = t.children[found.ID()]
+	}
+	return found
+}
+
+// CheckParent reports whether the category with the given ID may be placed
+// under parentID. It returns ErrCategoryCycle if parentID is the category
+// itself or one of its descendants.
+func (t *CategoryTree) CheckParent(id, parentID int) error {
+	if parentID == 0 {
+		return nil
+	}
+	if parentID == id {
+		return ErrCategoryCycle
+	}
+
+	visited := make(map[int]bool)
+	for current := parentID; current != 0 && !visited[current]; {
+		if current == id {
+			return ErrCategoryCycle
+		}
+		visited[current] = true
+		c, ok := t.byID[current]
+		if !ok {
+			return nil
+		}
+		current = c.ParentID()
+	}
+	return nil
+}
+
+// SplitCategoryPath splits a category path into its trimmed, non-empty segments.
+func SplitCategoryPath(path string) []string {
+	parts := strings.Split(path, CategoryPathSeparator)
+	segments := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			segments = append(segments, part)
+		}
+	}
+	return segments
+}
@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCategoryTree_Roots(t *testing.T) {
+	t.Run("flat categories are all roots", func(t *testing.T) {
+		tree := NewCategoryTree(buildCategories(t, map[int]int{1: 0, 2: 0, 3: 0}))
+
+		if len(tree.Roots()) != 3 {
+			t.Errorf("expected 3 roots, got %d", len(tree.Roots()))
+		}
+	})
+
+	t.Run("categories with missing parent are roots", func(t *testing.T) {
+		tree := NewCategoryTree(buildCategories(t, map[int]int{1: 0, 2: 99}))
+
+		if len(tree.Roots()) != 2 {
+			t.Errorf("expected 2 roots, got %d", len(tree.Roots()))
+		}
+	})
+
+	t.Run("nested categories are not roots", func(t *testing.T) {
+		tree := NewCategoryTree(buildCategories(t, map[int]int{1: 0, 2: 1, 3: 2}))
+
+		roots := tree.Roots()
+		if len(roots) != 1 || roots[0].ID() != 1 {
+			t.Errorf("expected only category 1 as root, got %v", roots)
+		}
+	})
+}
+
+func TestCategoryTree_Children(t *testing.T) {
+	t.Run("returns direct children only", func(t *testing.T) {
+		tree := NewCategoryTree(buildCategories(t, map[int]int{1: 0, 2: 1, 3: 1, 4: 2}))
+
+		children := tree.Children(1)
+		if len(children) != 2 {
+			t.Fatalf("expected 2 children, got %d", len(children))
+		}
+		if children[0].ID() != 2 || children[1].ID() != 3 {
+			t.Errorf("expected children [2 3], got [%d %d]", children[0].ID(), children[1].ID())
+		}
+	})
+
+	t.Run("returns empty slice for leaf", func(t *testing.T) {
+		tree := NewCategoryTree(buildCategories(t, map[int]int{1: 0}))
+
+		if len(tree.Children(1)) != 0 {
+			t.Error("expected no children")
+		}
+	})
+}
+
+func TestCategoryTree_Path(t *testing.T) {
+	categories := []*Category{
+		newTestCategory(t, 1, "backend", 0),
+		newTestCategory(t, 2, "db", 1),
+		newTestCategory(t, 3, "postgres", 2),
+	}
+	tree := NewCategoryTree(categories)
+
+	t.Run("joins ancestor names", func(t *testing.T) {
+		if got := tree.Path(1); got != "backend" {
+			t.Errorf("expected %q, got %q", "backend", got)
+		}
+		if got := tree.Path(2); got != "backend/db" {
+			t.Errorf("expected %q, got %q", "backend/db", got)
+		}
+		if got := tree.Path(3); got != "backend/db/postgres" {
+			t.Errorf("expected %q, got %q", "backend/db/postgres", got)
+		}
+	})
+
+	t.Run("returns empty path for unknown category", func(t *testing.T) {
+		if got := tree.Path(99); got != "" {
+			t.Errorf("expected empty path, got %q", got)
+		}
+	})
+
+	t.Run("reports depth", func(t *testing.T) {
+		if tree.Depth(3) != 2 {
+			t.Errorf("expected depth 2, got %d", tree.Depth(3))
+		}
+	})
+}
+
+func TestCategoryTree_Descendants(t *testing.T) {
+	t.Run("returns all nested subcategories", func(t *testing.T) {
+		tree := NewCategoryTree(buildCategories(t, map[int]int{1: 0, 2: 1, 3: 2, 4: 1, 5: 0}))
+
+		got := tree.Descendants(1)
+		slices.Sort(got)
+		if !slices.Equal(got, []int{2, 3, 4}) {
+			t.Errorf("expected [2 3 4], got %v", got)
+		}
+	})
+
+	t.Run("terminates on corrupt cyclic data", func(t *testing.T) {
+		a := newTestCategory(t, 1, "a", 2)
+		b := newTestCategory(t, 2, "b", 1)
+		tree := NewCategoryTree([]*Category{a, b})
+
+		tree.Descendants(1)
+		tree.Path(1)
+	})
+}
+
+func TestCategoryTree_FindByPath(t *testing.T) {
+	categories := []*Category{
+		newTestCategory(t, 1, "backend", 0),
+		newTestCategory(t, 2, "db", 1),
+		newTestCategory(t, 3, "postgres", 2),
+		newTestCategory(t, 4, "frontend", 0),
+		newTestCategory(t, 5, "db", 4),
+		newTestCategory(t, 6, "legacy/flat", 0),
+	}
+	tree := NewCategoryTree(categories)
+
+	assertFound := func(t *testing.T, path string, wantID int) {
+		t.Helper()
+		got := tree.FindByPath(path)
+		if got == nil || got.ID() != wantID {
+			t.Errorf("FindByPath(%q) = %v, want ID %d", path, got, wantID)
+		}
+	}
+
+	t.Run("finds nested categories", func(t *testing.T) {
+		assertFound(t, "backend", 1)
+		assertFound(t, "backend/db", 2)
+		assertFound(t, "backend/db/postgres", 3)
+	})
+
+	t.Run("distinguishes same names under different parents", func(t *testing.T) {
+		assertFound(t, "frontend/db", 5)
+	})
+
+	t.Run("ignores surrounding and repeated slashes", func(t *testing.T) {
+		assertFound(t, " /backend//db/ ", 2)
+	})
+
+	t.Run("matches legacy names containing slashes", func(t *testing.T) {
+		assertFound(t, "legacy/flat", 6)
+	})
+
+	t.Run("returns nil for missing paths", func(t *testing.T) {
+		for _, path := range []string{"db", "backend/missing", ""} {
+			if got := tree.FindByPath(path); got != nil {
+				t.Errorf("FindByPath(%q) = %v, want nil", path, got)
+			}
+		}
+	})
+}
+
+func TestCategoryTree_CheckParent(t *testing.T) {
+	tree := NewCategoryTree(buildCategories(t, map[int]int{1: 0, 2: 1, 3: 2, 4: 0}))
+
+	t.Run("allows valid parents", func(t *testing.T) {
+		if err := tree.CheckParent(3, 0); err != nil {
+			t.Errorf("expected top level to be allowed, got %v", err)
+		}
+		if err := tree.CheckParent(1, 4); err != nil {
+			t.Errorf("expected unrelated parent to be allowed, got %v", err)
+		}
+		if err := tree.CheckParent(4, 3); err != nil {
+			t.Errorf("expected moving down to be allowed, got %v", err)
+		}
+	})
+
+	t.Run("rejects self and descendants", func(t *testing.T) {
+		for _, parentID := range []int{1, 2, 3} {
+			if err := tree.CheckParent(1, parentID); !errors.Is(err, ErrCategoryCycle) {
+				t.Errorf("CheckParent(1, %d): expected ErrCategoryCycle, got %v", parentID, err)
+			}
+		}
+	})
+}
+
+// newTestCategory creates a category with the given ID, name and parent.
+func newTestCategory(t *testing.T, id int, name string, parentID int) *Category {
+	t.Helper()
+	category := mustCreateCategory(t, name)
+	category.SetID(id)
+	category.parentID = parentID
+	return category
+}
+
+// buildCategories creates categories named after their IDs from an id->parentID map,
+// ordered by ID.
+func buildCategories(t *testing.T, parents map[int]int) []*Category {
+	t.Helper()
+	ids := make([]int, 0, len(parents))
+	for id := range parents {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	categories := make([]*Category, 0, len(ids))
+	for _, id := range ids {
+		categories = append(categories, newTestCategory(t, id, string(rune('a'+id)), parents[id]))
+	}
+	return categories
+}
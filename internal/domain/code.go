@@ -0,0 +1,34 @@
+// Package domain contains the core business entities and logic for the snippet manager.
+package domain
+
+import "strings"
+
+// Dedent removes the leading whitespace common to all non-blank lines of
+// code, as when a snippet is cut out of a larger file. Blank lines are
+// emptied.
+func Dedent(code string) string {
+	lines := strings.Split(code, "\n")
+	prefix := ""
+	first := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			prefix, first = indent, false
+			continue
+		}
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+			continue
+		}
+		lines[i] = strings.TrimPrefix(line, prefix)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "testing"
+
+func TestDedent(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{"removes common tabs", "\t\tif ok {\n\t\t\treturn\n\t\t}", "if ok {\n\treturn\n}"},
+		{"ignores blank lines", "    a\n\n  \n    b", "a\n\n\nb"},
+		{"keeps mixed indentation", "\tx\n  y", "\tx\n  y"},
+		{"keeps unindented code", "a\n  b", "a\n  b"},
+		{"empties blank lines", "a\n \nb", "a\n\nb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Dedent(tt.code); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
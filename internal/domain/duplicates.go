@@ -0,0 +1,256 @@
+// Package domain contains the core business entities and logic for the snippet manager.
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultSimilarityThreshold is the minimum similarity for two snippets to be
+// reported as near-duplicates.
+const DefaultSimilarityThreshold = 0.85
+
+var (
+	// punctuationSpace matches whitespace around punctuation, so that
+	// "a = b(c, d)" and "a=b(c,d)" normalize to the same code.
+	punctuationSpace = regexp.MustCompile(`\s*([(){}\[\];,=+\-*/<>:.!&|])\s*`)
+
+	// codeToken matches identifiers, numbers and single punctuation characters.
+	codeToken = regexp.MustCompile(`\w+|[^\s\w]`)
+)
+
+// NormalizeCode returns code with trivial formatting differences removed:
+// line endings are unified, blank lines dropped, runs of whitespace
+// collapsed, and whitespace around punctuation removed. String literals are
+// left as they are, and so is indentation in languages where it matters,
+// such as Python and YAML, or that are unknown.
+// It is meant for comparing snippets, not for display.
+func NormalizeCode(code, language string) string {
+	code = strings.ReplaceAll(code, "\r\n", "\n")
+	code = strings.ReplaceAll(code, "\r", "\n")
+
+	keepIndent := true
+	if lang, ok := LookupLanguage(language); ok {
+		keepIndent = lang.SignificantIndent
+	}
+
+	lines := strings.Split(code, "\n")
+	normalized := make([]string, 0, len(lines))
+	var quote byte
+	for _, line := range lines {
+		// Lines inside a multi-line string are kept whole
+		inString := quote != 0
+
+		indent := ""
+		if keepIndent && !inString {
+			rest := strings.TrimLeft(line, " \t")
+			indent, line = line[:len(line)-len(rest)], rest
+		}
+
+		line, quote = normalizeLine(line, quote)
+		if line != "" || inString {
+			normalized = append(normalized, indent+line)
+		}
+	}
+	return strings.Join(normalized, "\n")
+}
+
+// normalizeLine normalizes the whitespace of one line outside string
+// literals. quote is the quote of a string left open by the previous line,
+// or 0; the quote still open at the end of the line is returned. Only
+// backquoted strings continue on the next line.
+func normalizeLine(line string, quote byte) (string, byte) {
+	var b strings.Builder
+	start := 0
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == 0 && (c == '"' || c == '\'' || c == '`'):
+			b.WriteString(normalizeSpace(line[start:i]))
+			start, quote = i, c
+		case quote != 0 && quote != '`' && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			b.WriteString(line[start : i+1])
+			start, quote = i+1, 0
+		}
+	}
+
+	if quote == 0 {
+		b.WriteString(normalizeSpace(line[start:]))
+		return b.String(), 0
+	}
+	b.WriteString(line[start:])
+	if quote != '`' {
+		return strings.TrimRight(b.String(), " \t"), 0
+	}
+	return b.String(), quote
+}
+
+// normalizeSpace collapses the whitespace of code outside string literals.
+func normalizeSpace(code string) string {
+	code = strings.Join(strings.Fields(code), " ")
+	return punctuationSpace.ReplaceAllString(code, "$1")
+}
+
+// CodeHash returns a hex-encoded SHA-256 hash of the normalized code.
+// Snippets in the same language with the same hash are exact duplicates.
+func CodeHash(code, language string) string {
+	sum := sha256.Sum256([]byte(NormalizeCode(code, language)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Similarity returns how similar two pieces of code are, from 0 (nothing in
+// common) to 1 (identical after normalization). It is the Dice coefficient
+// of the longest common token subsequence.
+func Similarity(a, b string) float64 {
+	return tokenSimilarity(codeTokens(a), codeTokens(b))
+}
+
+// DuplicateCluster is a group of snippets with the same or very similar code.
+type DuplicateCluster struct {
+	// Snippets are ordered by ID.
+	Snippets []*Snippet
+	// Exact reports whether all snippets have identical normalized code.
+	Exact bool
+	// Similarity is the lowest similarity between linked snippets in the cluster.
+	Similarity float64
+}
+
+// FindDuplicates groups snippets whose code is identical after normalization
+// or at least threshold similar. Only snippets in the same language are
+// compared. A threshold of 1 or more reports exact duplicates only.
+// Clusters are ordered by the ID of their first snippet.
+func FindDuplicates(snippets []*Snippet, threshold float64) []DuplicateCluster {
+	// Group exact duplicates first; only one representative per hash
+	// needs to be compared for similarity.
+	type group struct {
+		language string
+		tokens   []string
+		members  []*Snippet
+	}
+
+	var groups []*group
+	byHash := make(map[string]*group)
+	for _, s := range snippets {
+		key := strings.ToLower(s.Language()) + "\x00" + CodeHash(s.Code(), s.Language())
+		if g, ok := byHash[key]; ok {
+			g.members = append(g.members, s)
+			continue
+		}
+		g := &group{language: strings.ToLower(s.Language()), members: []*Snippet{s}}
+		byHash[key] = g
+		groups = append(groups, g)
+	}
+
+	parent := make([]int, len(groups))
+	lowest := make([]float64, len(groups))
+	for i := range parent {
+		parent[i] = i
+		lowest[i] = 1
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	if threshold < 1 {
+		for _, g := range groups {
+			g.tokens = codeTokens(g.members[0].Code())
+		}
+		for i := 0; i < len(groups); i++ {
+			for j := i + 1; j < len(groups); j++ {
+				if groups[i].language != groups[j].language {
+					continue
+				}
+				if !couldMatch(len(groups[i].tokens), len(groups[j].tokens), threshold) {
+					continue
+				}
+				score := tokenSimilarity(groups[i].tokens, groups[j].tokens)
+				if score < threshold {
+					continue
+				}
+				ri, rj := find(i), find(j)
+				if ri != rj {
+					parent[rj] = ri
+					lowest[ri] = min(lowest[ri], lowest[rj])
+				}
+				lowest[ri] = min(lowest[ri], score)
+			}
+		}
+	}
+
+	clustered := make(map[int][]int)
+	for i := range groups {
+		root := find(i)
+		clustered[root] = append(clustered[root], i)
+	}
+
+	var clusters []DuplicateCluster
+	for root, members := range clustered {
+		cluster := DuplicateCluster{Exact: len(members) == 1, Similarity: lowest[root]}
+		for _, i := range members {
+			cluster.Snippets = append(cluster.Snippets, groups[i].members...)
+		}
+		if len(cluster.Snippets) < 2 {
+			continue
+		}
+		sort.Slice(cluster.Snippets, func(a, b int) bool {
+			return cluster.Snippets[a].ID() < cluster.Snippets[b].ID()
+		})
+		clusters = append(clusters, cluster)
+	}
+
+	sort.Slice(clusters, func(a, b int) bool {
+		return clusters[a].Snippets[0].ID() < clusters[b].Snippets[0].ID()
+	})
+	return clusters
+}
+
+// codeTokens splits code into identifier and punctuation tokens. Tokens
+// never hold whitespace, so the code needs no normalization first.
+func codeTokens(code string) []string {
+	return codeToken.FindAllString(code, -1)
+}
+
+// couldMatch reports whether token sequences of lengths n and m can possibly
+// reach threshold similarity; the best case is when the shorter one is a
+// subsequence of the longer one.
+func couldMatch(n, m int, threshold float64) bool {
+	if n+m == 0 {
+		return true
+	}
+	return 2*float64(min(n, m))/float64(n+m) >= threshold
+}
+
+// tokenSimilarity returns the Dice coefficient of the longest common
+// subsequence of two token slices.
+func tokenSimilarity(a, b []string) float64 {
+	if len(a)+len(b) == 0 {
+		return 1
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			switch {
+			case a[i-1] == b[j-1]:
+				curr[j] = prev[j-1] + 1
+			case prev[j] >= curr[j-1]:
+				curr[j] = prev[j]
+			default:
+				curr[j] = curr[j-1]
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return 2 * float64(prev[len(b)]) / float64(len(a)+len(b))
+}
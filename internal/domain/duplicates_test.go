@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestNormalizeCode(t *testing.T) {
+	assertSameCode := func(t *testing.T, a, b string) {
+		t.Helper()
+		if NormalizeCode(a, "go") != NormalizeCode(b, "go") {
+			t.Errorf("expected equal normalization:\n%q\n%q", NormalizeCode(a, "go"), NormalizeCode(b, "go"))
+		}
+		if CodeHash(a, "go") != CodeHash(b, "go") {
+			t.Error("expected equal hashes")
+		}
+	}
+
+	t.Run("ignores line endings", func(t *testing.T) {
+		assertSameCode(t, "a := 1\r\nb := 2\r\n", "a := 1\nb := 2")
+	})
+
+	t.Run("ignores indentation and blank lines", func(t *testing.T) {
+		assertSameCode(t, "func f() {\n\n\treturn 1\n}\n", "func f() {\n    return 1\n}")
+	})
+
+	t.Run("ignores trailing whitespace", func(t *testing.T) {
+		assertSameCode(t, "x = 1   \ny = 2\t", "x = 1\ny = 2")
+	})
+
+	t.Run("ignores whitespace around punctuation", func(t *testing.T) {
+		assertSameCode(t, "f(a, b) = c + d;", "f(a,b)=c+d;")
+	})
+
+	t.Run("keeps meaningful differences", func(t *testing.T) {
+		if CodeHash("return a", "go") == CodeHash("return b", "go") {
+			t.Error("expected different hashes")
+		}
+	})
+
+	t.Run("keeps whitespace inside string literals", func(t *testing.T) {
+		if CodeHash(`fmt.Println("a  b")`, "go") == CodeHash(`fmt.Println("a b")`, "go") {
+			t.Error("expected different hashes for different strings")
+		}
+		if CodeHash("s := `a\n\n  b`", "go") == CodeHash("s := `a\n  b`", "go") {
+			t.Error("expected different hashes for different raw strings")
+		}
+		assertSameCode(t, `x := f( "a  b" , 'c' )`, `x:=f("a  b",'c')`)
+	})
+
+	t.Run("keeps indentation where it matters", func(t *testing.T) {
+		nested := "if a:\n    if b:\n        run()\n"
+		flat := "if a:\n    if b:\n    run()\n"
+		if CodeHash(nested, "python") == CodeHash(flat, "python") {
+			t.Error("expected different hashes for different Python nesting")
+		}
+		if CodeHash("a:\n  b: 1", "yaml") == CodeHash("a:\nb: 1", "yaml") {
+			t.Error("expected different hashes for different YAML nesting")
+		}
+		if CodeHash("a:\n  b: 1", "unknown") == CodeHash("a:\nb: 1", "unknown") {
+			t.Error("expected indentation to be kept for unknown languages")
+		}
+		if CodeHash("if a:\n    run()  \n\n", "py") != CodeHash("if a:\n    run()", "py") {
+			t.Error("expected trailing whitespace and blank lines to be ignored")
+		}
+	})
+}
+
+func TestSimilarity(t *testing.T) {
+	t.Run("identical code is fully similar", func(t *testing.T) {
+		if got := Similarity("x := 1", "x   :=   1\n"); got != 1 {
+			t.Errorf("expected 1, got %f", got)
+		}
+	})
+
+	t.Run("small edit is highly similar", func(t *testing.T) {
+		a := "for i := 0; i < len(items); i++ {\n\tfmt.Println(items[i])\n}"
+		b := "for i := 0; i < len(items); i++ {\n\tfmt.Println(items[i], i)\n}"
+		if got := Similarity(a, b); got < DefaultSimilarityThreshold {
+			t.Errorf("expected similarity >= %f, got %f", DefaultSimilarityThreshold, got)
+		}
+	})
+
+	t.Run("unrelated code is dissimilar", func(t *testing.T) {
+		if got := Similarity("SELECT * FROM users", "def main(): pass"); got > 0.3 {
+			t.Errorf("expected low similarity, got %f", got)
+		}
+	})
+}
+
+func TestFindDuplicates(t *testing.T) {
+	newSnippet := func(id int, language, code string) *Snippet {
+		s := mustCreateSnippet(t, "snippet", language, code)
+		s.SetID(id)
+		return s
+	}
+
+	loop := "for i := 0; i < len(items); i++ {\n\tfmt.Println(items[i])\n}"
+	snippets := []*Snippet{
+		newSnippet(1, "go", loop),
+		newSnippet(2, "go", "func add(a, b int) int { return a + b }"),
+		newSnippet(3, "go", "  "+loop+"\r\n"),
+		newSnippet(4, "go", "for i := 0; i < len(items); i++ {\n\tfmt.Println(items[i], i)\n}"),
+		newSnippet(5, "python", loop),
+		newSnippet(6, "go", "func   add(a,b int) int {return a+b}"),
+	}
+
+	t.Run("finds exact duplicates only at threshold 1", func(t *testing.T) {
+		clusters := FindDuplicates(snippets, 1)
+
+		if len(clusters) != 2 {
+			t.Fatalf("expected 2 clusters, got %d", len(clusters))
+		}
+		assertClusterIDs(t, clusters[0], 1, 3)
+		assertClusterIDs(t, clusters[1], 2, 6)
+		for _, c := range clusters {
+			if !c.Exact || c.Similarity != 1 {
+				t.Errorf("expected exact cluster, got %+v", c)
+			}
+		}
+	})
+
+	t.Run("includes near-duplicates", func(t *testing.T) {
+		clusters := FindDuplicates(snippets, DefaultSimilarityThreshold)
+
+		if len(clusters) != 2 {
+			t.Fatalf("expected 2 clusters, got %d", len(clusters))
+		}
+		assertClusterIDs(t, clusters[0], 1, 3, 4)
+		if clusters[0].Exact {
+			t.Error("expected near-duplicate cluster not to be exact")
+		}
+		if clusters[0].Similarity >= 1 || clusters[0].Similarity < DefaultSimilarityThreshold {
+			t.Errorf("unexpected similarity %f", clusters[0].Similarity)
+		}
+	})
+
+	t.Run("does not treat different Python nesting as exact duplicates", func(t *testing.T) {
+		python := []*Snippet{
+			newSnippet(1, "python", "for item in items:\n    if item:\n        total += item\n"),
+			newSnippet(2, "python", "for item in items:\n    if item:\n        pass\n    total += item\n"),
+			newSnippet(3, "python", "for item in items:\n    if item:\n        pass\ntotal += item\n"),
+		}
+		if clusters := FindDuplicates(python, 1); len(clusters) != 0 {
+			t.Errorf("expected no exact duplicates, got %d cluster(s)", len(clusters))
+		}
+	})
+
+	t.Run("returns nothing for unique snippets", func(t *testing.T) {
+		if clusters := FindDuplicates(snippets[:2], DefaultSimilarityThreshold); len(clusters) != 0 {
+			t.Errorf("expected no clusters, got %d", len(clusters))
+		}
+	})
+}
+
+func assertClusterIDs(t *testing.T, cluster DuplicateCluster, ids ...int) {
+	t.Helper()
+	if len(cluster.Snippets) != len(ids) {
+		t.Fatalf("expected %d snippets in cluster, got %d", len(ids), len(cluster.Snippets))
+	}
+	for i, id := range ids {
+		if cluster.Snippets[i].ID() != id {
+			t.Errorf("expected snippet %d at position %d, got %d", id, i, cluster.Snippets[i].ID())
+		}
+	}
+}
@@ -8,4 +8,6 @@ var (
 	ErrEmptyTitle    = errors.New("title cannot be empty")
 	ErrEmptyLanguage = errors.New("language cannot be empty")
 	ErrEmptyCode     = errors.New("code cannot be empty")
+	ErrEmptyAlias    = errors.New("alias cannot be empty")
+	ErrCategoryCycle = errors.New("category cannot be nested inside itself")
 )
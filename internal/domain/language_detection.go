@@ -0,0 +1,262 @@
+// Package domain contains the core business entities and logic for the snippet manager.
+package domain
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LanguageConfidenceThreshold is the confidence below which a detected
+// language should be confirmed by the user before it is used.
+const LanguageConfidenceThreshold = 0.6
+
+// Detection sources reported in LanguageGuess.Source.
+const (
+	DetectedByExtension = "extension"
+	DetectedByShebang   = "shebang"
+	DetectedByContent   = "content"
+)
+
+// LanguageGuess is the result of language detection.
+type LanguageGuess struct {
+	// Language is the detected language, or empty if nothing was detected.
+	Language string
+	// Confidence ranges from 0 (no idea) to 1 (certain).
+	Confidence float64
+	// Source tells what the guess is based on.
+	Source string
+}
+
+// Confident reports whether the guess is reliable enough to use without
+// asking the user.
+func (g LanguageGuess) Confident() bool {
+	return g.Language != "" && g.Confidence >= LanguageConfidenceThreshold
+}
+
+// contentRule is a pattern that hints at a language, with a weight.
+type contentRule struct {
+	pattern *regexp.Regexp
+	weight  int
+}
+
+// contentRules holds the content heuristics per language. Each pattern
+// counts once, no matter how often it matches.
+var contentRules = map[string][]contentRule{
+	"go": {
+		{regexp.MustCompile(`(?m)^package \w+\s*$`), 4},
+		{regexp.MustCompile(`(?m)^func (\(\w+ \*?\w+\) )?\w+\(`), 3},
+		{regexp.MustCompile(`\w+ := `), 2},
+		{regexp.MustCompile(`\bfmt\.\w+\(`), 3},
+		{regexp.MustCompile(`(?m)^import \(`), 3},
+		{regexp.MustCompile(`\berr != nil\b`), 3},
+	},
+	"python": {
+		{regexp.MustCompile(`(?m)^\s*def \w+\(.*\)\s*(->\s*[\w\[\], .]+)?:\s*$`), 4},
+		{regexp.MustCompile(`(?m)^from [\w.]+ import \w`), 4},
+		{regexp.MustCompile(`(?m)^import \w+(\.\w+)*\s*$`), 1},
+		{regexp.MustCompile(`\bself\.\w+`), 2},
+		{regexp.MustCompile(`(?m)^\s*(elif|except)\b.*:\s*$`), 3},
+		{regexp.MustCompile(`(?m)^\s*(if|for|while|with) .+:\s*$`), 2},
+		{regexp.MustCompile(`\bprint\(`), 1},
+		{regexp.MustCompile(`__name__ == ['"]__main__['"]`), 4},
+	},
+	"javascript": {
+		{regexp.MustCompile(`\bconsole\.log\(`), 4},
+		{regexp.MustCompile(`\b(const|let) \w+ = `), 2},
+		{regexp.MustCompile(`\bfunction\s*\w*\s*\(`), 2},
+		{regexp.MustCompile(`\)\s*=>`), 2},
+		{regexp.MustCompile(`\brequire\(['"]`), 3},
+		{regexp.MustCompile(`\b(document|window)\.\w+`), 3},
+		{regexp.MustCompile(`\bmodule\.exports\b`), 4},
+	},
+	"typescript": {
+		{regexp.MustCompile(`\b\w+\??: (string|number|boolean|any|void)\b`), 4},
+		{regexp.MustCompile(`(?m)^\s*(export )?interface \w+ \{`), 4},
+		{regexp.MustCompile(`(?m)^\s*(export )?type \w+ = `), 3},
+		{regexp.MustCompile(`\b(const|let) \w+ = `), 1},
+		{regexp.MustCompile(`\)\s*=>`), 1},
+	},
+	"bash": {
+		{regexp.MustCompile(`(?m)^\s*echo\b`), 2},
+		{regexp.MustCompile(`\$\{\w+[^}]*\}|\$\w+`), 1},
+		{regexp.MustCompile(`(?m)^\s*(fi|done|esac)\s*$`), 4},
+		{regexp.MustCompile(`(?m)^\s*if \[\[? `), 4},
+		{regexp.MustCompile(`\|\s*(grep|awk|sed|xargs|sort|head|tail|wc)\b`), 3},
+		{regexp.MustCompile(`(?m)^\s*export \w+=`), 3},
+		{regexp.MustCompile(`(?m)^\s*(sudo|apt|apt-get|brew|curl|wget|git|docker|kubectl|cd|ls|mkdir|rm|cp|mv|chmod) `), 2},
+	},
+	"sql": {
+		{regexp.MustCompile(`(?is)\bSELECT\b.+\bFROM\b`), 5},
+		{regexp.MustCompile(`(?i)\b(INSERT INTO|DELETE FROM|CREATE TABLE|ALTER TABLE|DROP TABLE)\b`), 5},
+		{regexp.MustCompile(`(?i)\bUPDATE \w+ SET\b`), 5},
+		{regexp.MustCompile(`(?i)\b(WHERE|GROUP BY|ORDER BY|INNER JOIN|LEFT JOIN)\b`), 2},
+	},
+	"rust": {
+		{regexp.MustCompile(`\bfn \w+(<[^>]*>)?\(`), 3},
+		{regexp.MustCompile(`\blet mut \w+`), 4},
+		{regexp.MustCompile(`\b(println|vec|format)!\(`), 4},
+		{regexp.MustCompile(`(?m)^use (std|crate)::`), 4},
+		{regexp.MustCompile(`\bimpl\b.*\{`), 3},
+	},
+	"java": {
+		{regexp.MustCompile(`\bpublic (static )?(final )?(class|void|interface)\b`), 4},
+		{regexp.MustCompile(`\bSystem\.out\.print`), 5},
+		{regexp.MustCompile(`(?m)^import java\.`), 5},
+		{regexp.MustCompile(`\b(private|protected) \w+(<[^>]*>)? \w+;`), 2},
+	},
+	"c": {
+		{regexp.MustCompile(`#include <\w+\.h>`), 4},
+		{regexp.MustCompile(`\bint main\(`), 2},
+		{regexp.MustCompile(`\b(printf|malloc|free|sizeof)\(`), 2},
+	},
+	"cpp": {
+		{regexp.MustCompile(`#include <(iostream|vector|string|map|memory|algorithm)>`), 5},
+		{regexp.MustCompile(`\bstd::\w+`), 4},
+		{regexp.MustCompile(`\b(cout|cin|cerr) (<<|>>)`), 3},
+		{regexp.MustCompile(`\bint main\(`), 1},
+	},
+	"ruby": {
+		{regexp.MustCompile(`(?m)^\s*def \w+[?!]?(\(.*\))?\s*$`), 2},
+		{regexp.MustCompile(`(?m)^\s*end\s*$`), 2},
+		{regexp.MustCompile(`\bputs\b`), 3},
+		{regexp.MustCompile(`\.each (do|\{)\s*\|`), 4},
+		{regexp.MustCompile(`(?m)^\s*require ['"]`), 3},
+	},
+	"php": {
+		{regexp.MustCompile(`<\?php`), 8},
+		{regexp.MustCompile(`\$\w+ = `), 1},
+		{regexp.MustCompile(`\becho \$`), 2},
+	},
+	"html": {
+		{regexp.MustCompile(`(?i)<!DOCTYPE html>`), 8},
+		{regexp.MustCompile(`(?i)<(html|head|body|div|span|p|ul|li|a|script|table)\b[^>]*>`), 4},
+		{regexp.MustCompile(`(?i)</\w+>`), 2},
+	},
+	"css": {
+		{regexp.MustCompile(`(?m)^\s*[.#]?[\w-]+(\s*[,>+~]?\s*[.#]?[\w-]+)*\s*\{\s*$`), 2},
+		{regexp.MustCompile(`(?m)^\s*[\w-]+:\s*[^;{}]+;\s*$`), 3},
+		{regexp.MustCompile(`@media\b|!important\b`), 3},
+	},
+	"yaml": {
+		{regexp.MustCompile(`(?m)^[\w-]+:\s*$`), 2},
+		{regexp.MustCompile(`(?m)^\s+[\w-]+: \S`), 2},
+		{regexp.MustCompile(`(?m)^\s*- [\w-]+`), 1},
+		{regexp.MustCompile(`(?m)^---\s*$`), 2},
+	},
+	"dockerfile": {
+		{regexp.MustCompile(`(?m)^FROM \S+`), 5},
+		{regexp.MustCompile(`(?m)^(RUN|COPY|WORKDIR|CMD|ENTRYPOINT|EXPOSE|ENV|ARG) `), 3},
+	},
+}
+
+// contentSaturation is the content score at which heuristics are fully trusted.
+const contentSaturation = 8
+
+// DetectLanguage guesses the language of code. filename is optional; when
+// given, a known extension or file name wins. Otherwise a shebang line is
+// used, and finally content heuristics.
+func DetectLanguage(filename, code string) LanguageGuess {
+	if filename != "" {
+		if language, ok := LanguageForFile(filename); ok {
+			return LanguageGuess{Language: language.Name, Confidence: 0.95, Source: DetectedByExtension}
+		}
+	}
+
+	if language := shebangLanguage(code); language != "" {
+		return LanguageGuess{Language: language, Confidence: 0.95, Source: DetectedByShebang}
+	}
+
+	return detectByContent(code)
+}
+
+// shebangLanguage returns the language named by a "#!" first line, if any.
+func shebangLanguage(code string) string {
+	firstLine, _, _ := strings.Cut(strings.TrimLeft(code, " \t\r\n"), "\n")
+	if !strings.HasPrefix(firstLine, "#!") {
+		return ""
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(firstLine, "#!"))
+	if len(fields) == 0 {
+		return ""
+	}
+
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		// Skip env options such as "-S".
+		interpreter = ""
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") {
+				interpreter = filepath.Base(field)
+				break
+			}
+		}
+	}
+
+	if language, ok := LookupLanguage(interpreter); ok {
+		return language.Name
+	}
+	// Versioned interpreters such as "python3.12".
+	if language, ok := LookupLanguage(strings.TrimRight(interpreter, "0123456789.")); ok {
+		return language.Name
+	}
+	return ""
+}
+
+// detectByContent scores code against the content heuristics.
+func detectByContent(code string) LanguageGuess {
+	trimmed := strings.TrimSpace(code)
+	if trimmed == "" {
+		return LanguageGuess{}
+	}
+
+	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+		return LanguageGuess{Language: "json", Confidence: 0.9, Source: DetectedByContent}
+	}
+
+	type score struct {
+		language string
+		points   int
+	}
+	var scores []score
+	for language, rules := range contentRules {
+		points := 0
+		for _, rule := range rules {
+			if rule.pattern.MatchString(code) {
+				points += rule.weight
+			}
+		}
+		if points > 0 {
+			scores = append(scores, score{language, points})
+		}
+	}
+	if len(scores) == 0 {
+		return LanguageGuess{}
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].points != scores[j].points {
+			return scores[i].points > scores[j].points
+		}
+		return scores[i].language < scores[j].language
+	})
+
+	best := scores[0]
+	runnerUp := 0
+	if len(scores) > 1 {
+		runnerUp = scores[1].points
+	}
+
+	// Confidence grows with the evidence for the best language and shrinks
+	// when another language is nearly as likely.
+	strength := min(1, float64(best.points)/contentSaturation)
+	margin := float64(best.points) / float64(best.points+runnerUp)
+	return LanguageGuess{
+		Language:   best.language,
+		Confidence: strength * margin,
+		Source:     DetectedByContent,
+	}
+}
@@ -0,0 +1,85 @@
+package domain
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	t.Run("uses the file extension", func(t *testing.T) {
+		guess := DetectLanguage("scripts/deploy.PY", "x = 1")
+		if guess.Language != "python" || guess.Source != DetectedByExtension {
+			t.Errorf("expected python from extension, got %+v", guess)
+		}
+		if !guess.Confident() {
+			t.Errorf("expected extension guess to be confident, got %.2f", guess.Confidence)
+		}
+	})
+
+	t.Run("uses well-known file names", func(t *testing.T) {
+		if guess := DetectLanguage("/src/Dockerfile", ""); guess.Language != "dockerfile" {
+			t.Errorf("expected dockerfile, got %q", guess.Language)
+		}
+	})
+
+	t.Run("falls back to content for unknown extensions", func(t *testing.T) {
+		guess := DetectLanguage("notes.txt", "#!/bin/bash\necho hi")
+		if guess.Language != "bash" {
+			t.Errorf("expected bash, got %q", guess.Language)
+		}
+	})
+
+	t.Run("reads the shebang", func(t *testing.T) {
+		for code, want := range map[string]string{
+			"#!/bin/sh\nls":                      "bash",
+			"#!/usr/bin/env python3\nprint(1)":   "python",
+			"#!/usr/bin/env -S node --flag\nx()": "javascript",
+			"#!/usr/bin/python3.12\npass":        "python",
+		} {
+			guess := DetectLanguage("", code)
+			if guess.Language != want || guess.Source != DetectedByShebang {
+				t.Errorf("%q: expected %s from shebang, got %+v", code, want, guess)
+			}
+		}
+	})
+
+	t.Run("recognises JSON", func(t *testing.T) {
+		if guess := DetectLanguage("", `{"name": "snip", "tags": [1, 2]}`); guess.Language != "json" {
+			t.Errorf("expected json, got %q", guess.Language)
+		}
+	})
+
+	t.Run("detects go from content", func(t *testing.T) {
+		code := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n"
+		guess := DetectLanguage("", code)
+		if guess.Language != "go" || !guess.Confident() {
+			t.Errorf("expected confident go, got %+v", guess)
+		}
+	})
+
+	t.Run("detects python from content", func(t *testing.T) {
+		code := "from os import path\n\ndef exists(p):\n    if path.exists(p):\n        return True\n"
+		guess := DetectLanguage("", code)
+		if guess.Language != "python" || !guess.Confident() {
+			t.Errorf("expected confident python, got %+v", guess)
+		}
+	})
+
+	t.Run("detects sql from content", func(t *testing.T) {
+		guess := DetectLanguage("", "SELECT id, name FROM users WHERE active = 1 ORDER BY name;")
+		if guess.Language != "sql" || !guess.Confident() {
+			t.Errorf("expected confident sql, got %+v", guess)
+		}
+	})
+
+	t.Run("is not confident about weak evidence", func(t *testing.T) {
+		guess := DetectLanguage("", "print(x)")
+		if guess.Confident() {
+			t.Errorf("expected low confidence, got %+v", guess)
+		}
+	})
+
+	t.Run("returns nothing for empty code", func(t *testing.T) {
+		guess := DetectLanguage("", "  \n")
+		if guess.Language != "" || guess.Confidence != 0 {
+			t.Errorf("expected empty guess, got %+v", guess)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+// Package domain contains the core business entities and logic for the snippet manager.
+package domain
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Language describes a programming language known to SNIP.
+type Language struct {
+	// Name is the canonical, lowercase language name stored on snippets.
+	Name string
+	// Aliases are other names that normalize to Name, e.g. "js" for "javascript".
+	Aliases []string
+	// Extensions are lowercase file extensions including the dot.
+	Extensions []string
+	// FileNames are lowercase file names that identify the language without an extension.
+	FileNames []string
+	// LineComment starts a single-line comment, or is empty if there is none.
+	LineComment string
+	// BlockComment holds the start and end of a block comment, or empty strings.
+	BlockComment [2]string
+	// SignificantIndent reports whether indentation changes the meaning of code.
+	SignificantIndent bool
+}
+
+// languages is the language registry, ordered by name.
+var languages = []Language{
+	{Name: "bash", Aliases: []string{"sh", "shell", "shellscript"}, Extensions: []string{".sh", ".bash"}, LineComment: "#"},
+	{Name: "c", Extensions: []string{".c", ".h"}, LineComment: "//", BlockComment: [2]string{"/*", "*/"}},
+	{Name: "cpp", Aliases: []string{"c++", "cxx"}, Extensions: []string{".cpp", ".cc", ".cxx", ".hpp"}, LineComment: "//", BlockComment: [2]string{"/*", "*/"}},
+	{Name: "csharp", Aliases: []string{"c#", "cs"}, Extensions: []string{".cs"}, LineComment: "//", BlockComment: [2]string{"/*", "*/"}},
+	{Name: "css", Extensions: []string{".css"}, BlockComment: [2]string{"/*", "*/"}},
+	{Name: "dockerfile", Aliases: []string{"docker"}, FileNames: []string{"dockerfile"}, LineComment: "#"},
+	{Name: "go", Aliases: []string{"golang"}, Extensions: []string{".go"}, LineComment: "//", BlockComment: [2]string{"/*", "*/"}},
+	{Name: "haskell", Aliases: []string{"hs"}, Extensions: []string{".hs"}, LineComment: "--", BlockComment: [2]string{"{-", "-}"}, SignificantIndent: true},
+	{Name: "html", Aliases: []string{"htm"}, Extensions: []string{".html", ".htm"}, BlockComment: [2]string{"<!--", "-->"}},
+	{Name: "java", Extensions: []string{".java"}, LineComment: "//", BlockComment: [2]string{"/*", "*/"}},
+	{Name: "javascript", Aliases: []string{"js", "node", "nodejs", "jsx"}, Extensions: []string{".js", ".mjs", ".cjs", ".jsx"}, LineComment: "//", BlockComment: [2]string{"/*", "*/"}},
+	{Name: "json", Extensions: []string{".json"}},
+	{Name: "kotlin", Aliases: []string{"kt"}, Extensions: []string{".kt", ".kts"}, LineComment: "//", BlockComment: [2]string{"/*", "*/"}},
+	{Name: "lua", Extensions: []string{".lua"}, LineComment: "--", BlockComment: [2]string{"--[[", "]]"}},
+	{Name: "makefile", Aliases: []string{"make"}, FileNames: []string{"makefile", "gnumakefile"}, LineComment: "#", SignificantIndent: true},
+	{Name: "markdown", Aliases: []string{"md"}, Extensions: []string{".md", ".markdown"}, BlockComment: [2]string{"<!--", "-->"}},
+	{Name: "perl", Aliases: []string{"pl"}, Extensions: []string{".pl", ".pm"}, LineComment: "#"},
+	{Name: "php", Extensions: []string{".php"}, LineComment: "//", BlockComment: [2]string{"/*", "*/"}},
+	{Name: "powershell", Aliases: []string{"ps1", "pwsh"}, Extensions: []string{".ps1"}, LineComment: "#", BlockComment: [2]string{"<#", "#>"}},
+	{Name: "python", Aliases: []string{"py", "python2", "python3"}, Extensions: []string{".py", ".pyw"}, LineComment: "#", BlockComment: [2]string{`"""`, `"""`}, SignificantIndent: true},
+	{Name: "ruby", Aliases: []string{"rb"}, Extensions: []string{".rb"}, LineComment: "#", BlockComment: [2]string{"=begin", "=end"}},
+	{Name: "rust", Aliases: []string{"rs"}, Extensions: []string{".rs"}, LineComment: "//", BlockComment: [2]string{"/*", "*/"}},
+	{Name: "sql", Extensions: []string{".sql"}, LineComment: "--", BlockComment: [2]string{"/*", "*/"}},
+	{Name: "swift", Extensions: []string{".swift"}, LineComment: "//", BlockComment: [2]string{"/*", "*/"}},
+	{Name: "toml", Extensions: []string{".toml"}, LineComment: "#"},
+	{Name: "typescript", Aliases: []string{"ts", "tsx", "deno", "ts-node"}, Extensions: []string{".ts", ".tsx"}, LineComment: "//", BlockComment: [2]string{"/*", "*/"}},
+	{Name: "yaml", Aliases: []string{"yml"}, Extensions: []string{".yaml", ".yml"}, LineComment: "#", SignificantIndent: true},
+	{Name: "zsh", Extensions: []string{".zsh"}, LineComment: "#"},
+}
+
+// Lookup tables built from languages.
+var (
+	languagesByName      = make(map[string]*Language)
+	languagesByExtension = make(map[string]*Language)
+	languagesByFileName  = make(map[string]*Language)
+)
+
+func init() {
+	for i := range languages {
+		language := &languages[i]
+		languagesByName[language.Name] = language
+		for _, alias := range language.Aliases {
+			languagesByName[alias] = language
+		}
+		for _, ext := range language.Extensions {
+			languagesByExtension[ext] = language
+		}
+		for _, name := range language.FileNames {
+			languagesByFileName[name] = language
+		}
+	}
+}
+
+// Languages returns all known languages, ordered by name.
+func Languages() []Language {
+	result := make([]Language, len(languages))
+	copy(result, languages)
+	return result
+}
+
+// LanguageNames returns the canonical names of all known languages, ordered by name.
+func LanguageNames() []string {
+	names := make([]string, len(languages))
+	for i, language := range languages {
+		names[i] = language.Name
+	}
+	return names
+}
+
+// LookupLanguage finds a language by canonical name or alias, ignoring case.
+func LookupLanguage(name string) (Language, bool) {
+	language, ok := languagesByName[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Language{}, false
+	}
+	return *language, true
+}
+
+// LanguageForFile finds a language by the extension or name of a file.
+func LanguageForFile(filename string) (Language, bool) {
+	base := strings.ToLower(filepath.Base(filename))
+	if language, ok := languagesByExtension[filepath.Ext(base)]; ok {
+		return *language, true
+	}
+	if language, ok := languagesByFileName[base]; ok {
+		return *language, true
+	}
+	return Language{}, false
+}
+
+// CanonicalLanguage normalizes a language name. Known names and aliases map
+// to the canonical name; unknown languages are trimmed and lowercased.
+func CanonicalLanguage(name string) string {
+	if language, ok := LookupLanguage(name); ok {
+		return language.Name
+	}
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,94 @@
+package domain
+
+import "testing"
+
+func TestLookupLanguage(t *testing.T) {
+	t.Run("finds languages by name and alias", func(t *testing.T) {
+		for _, name := range []string{"javascript", "JS", " node ", "NodeJS"} {
+			language, ok := LookupLanguage(name)
+			if !ok || language.Name != "javascript" {
+				t.Errorf("%q: expected javascript, got %q (found %v)", name, language.Name, ok)
+			}
+		}
+	})
+
+	t.Run("reports unknown languages", func(t *testing.T) {
+		if _, ok := LookupLanguage("cobol"); ok {
+			t.Error("expected cobol to be unknown")
+		}
+	})
+
+	t.Run("includes comment syntax", func(t *testing.T) {
+		language, _ := LookupLanguage("python")
+		if language.LineComment != "#" {
+			t.Errorf("expected line comment '#', got %q", language.LineComment)
+		}
+
+		language, _ = LookupLanguage("html")
+		if language.LineComment != "" || language.BlockComment != [2]string{"<!--", "-->"} {
+			t.Errorf("unexpected html comments: %q %q", language.LineComment, language.BlockComment)
+		}
+	})
+}
+
+func TestLanguageForFile(t *testing.T) {
+	t.Run("matches extensions", func(t *testing.T) {
+		language, ok := LanguageForFile("src/App.TSX")
+		if !ok || language.Name != "typescript" {
+			t.Errorf("expected typescript, got %q", language.Name)
+		}
+	})
+
+	t.Run("matches file names", func(t *testing.T) {
+		language, ok := LanguageForFile("build/Makefile")
+		if !ok || language.Name != "makefile" {
+			t.Errorf("expected makefile, got %q", language.Name)
+		}
+	})
+
+	t.Run("reports unknown files", func(t *testing.T) {
+		if _, ok := LanguageForFile("notes.txt"); ok {
+			t.Error("expected notes.txt to be unknown")
+		}
+	})
+}
+
+func TestCanonicalLanguage(t *testing.T) {
+	t.Run("maps aliases to canonical names", func(t *testing.T) {
+		if got := CanonicalLanguage("Python3"); got != "python" {
+			t.Errorf("expected python, got %q", got)
+		}
+	})
+
+	t.Run("lowercases unknown languages", func(t *testing.T) {
+		if got := CanonicalLanguage(" Terraform "); got != "terraform" {
+			t.Errorf("expected terraform, got %q", got)
+		}
+	})
+}
+
+func TestLanguages(t *testing.T) {
+	t.Run("names and aliases are unique", func(t *testing.T) {
+		seen := make(map[string]string)
+		for _, language := range Languages() {
+			for _, name := range append([]string{language.Name}, language.Aliases...) {
+				if other, ok := seen[name]; ok {
+					t.Errorf("%q is used by both %s and %s", name, other, language.Name)
+				}
+				seen[name] = language.Name
+			}
+		}
+	})
+
+	t.Run("LanguageNames lists canonical names", func(t *testing.T) {
+		names := LanguageNames()
+		if len(names) != len(Languages()) {
+			t.Fatalf("expected %d names, got %d", len(Languages()), len(names))
+		}
+		for _, name := range names {
+			if CanonicalLanguage(name) != name {
+				t.Errorf("%q is not canonical", name)
+			}
+		}
+	})
+}
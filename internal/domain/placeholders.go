@@ -0,0 +1,86 @@
+// Package domain contains the core business entities and logic for the snippet manager.
+package domain
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// Placeholder is a field in snippet code that is filled in when the snippet
+// is used, written {{name}} or {{name:default}}. Names are numbers, as in
+// editor tab stops, or identifiers such as {{host:localhost}}. {{0}} marks
+// the final cursor position.
+type Placeholder struct {
+	Name    string
+	Default string
+}
+
+// Numbered reports whether the placeholder is a numbered tab stop.
+func (p Placeholder) Numbered() bool {
+	_, err := strconv.Atoi(p.Name)
+	return err == nil
+}
+
+// placeholderPattern matches placeholders. Template expressions such as
+// {{ .Name }} or {{if}} with spaces or dots are not placeholders.
+var placeholderPattern = regexp.MustCompile(`\{\{([0-9]+|[A-Za-z_][A-Za-z0-9_-]*)(?::([^{}\n]*))?\}\}`)
+
+// Placeholders returns the placeholders in code in order of first use.
+// A placeholder used more than once is listed once, with the first
+// non-empty default.
+func Placeholders(code string) []Placeholder {
+	var placeholders []Placeholder
+	for _, m := range placeholderPattern.FindAllStringSubmatch(code, -1) {
+		i := slices.IndexFunc(placeholders, func(p Placeholder) bool { return p.Name == m[1] })
+		if i < 0 {
+			placeholders = append(placeholders, Placeholder{Name: m[1], Default: m[2]})
+		} else if placeholders[i].Default == "" {
+			placeholders[i].Default = m[2]
+		}
+	}
+	return placeholders
+}
+
+// PlaceholderText formats a placeholder in snip syntax.
+func PlaceholderText(p Placeholder) string {
+	if p.Default == "" {
+		return "{{" + p.Name + "}}"
+	}
+	return "{{" + p.Name + ":" + p.Default + "}}"
+}
+
+// ReplacePlaceholders replaces every placeholder occurrence in code with
+// the result of replace. The text between placeholders is passed to
+// literal, which lets callers escape it for another syntax; a nil literal
+// keeps the text unchanged.
+func ReplacePlaceholders(code string, replace func(Placeholder) string, literal func(string) string) string {
+	if literal == nil {
+		literal = func(text string) string { return text }
+	}
+
+	var out []byte
+	last := 0
+	for _, m := range placeholderPattern.FindAllStringSubmatchIndex(code, -1) {
+		out = append(out, literal(code[last:m[0]])...)
+		p := Placeholder{Name: code[m[2]:m[3]]}
+		if m[4] >= 0 {
+			p.Default = code[m[4]:m[5]]
+		}
+		out = append(out, replace(p)...)
+		last = m[1]
+	}
+	out = append(out, literal(code[last:])...)
+	return string(out)
+}
+
+// RenderPlaceholders fills placeholders with values by name. Placeholders
+// without a value get their default.
+func RenderPlaceholders(code string, values map[string]string) string {
+	return ReplacePlaceholders(code, func(p Placeholder) string {
+		if value, ok := values[p.Name]; ok {
+			return value
+		}
+		return p.Default
+	}, nil)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "testing"
+
+func TestPlaceholders(t *testing.T) {
+	t.Run("lists placeholders in order of first use", func(t *testing.T) {
+		got := Placeholders("ssh {{user}}@{{host:localhost}} -p {{1:22}} # {{user:root}} {{0}}")
+		want := []Placeholder{
+			{Name: "user", Default: "root"},
+			{Name: "host", Default: "localhost"},
+			{Name: "1", Default: "22"},
+			{Name: "0"},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("expected %d placeholders, got %+v", len(want), got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("placeholder %d: expected %+v, got %+v", i, want[i], got[i])
+			}
+		}
+	})
+
+	t.Run("ignores template expressions", func(t *testing.T) {
+		code := "{{ .Name }} {{.Title}} {{if .Ok}} ${{ secrets.TOKEN }}"
+		if got := Placeholders(code); len(got) != 0 {
+			t.Errorf("expected no placeholders, got %+v", got)
+		}
+	})
+}
+
+func TestPlaceholder_Numbered(t *testing.T) {
+	if !(Placeholder{Name: "2"}).Numbered() {
+		t.Error("expected 2 to be numbered")
+	}
+	if (Placeholder{Name: "host"}).Numbered() {
+		t.Error("expected host not to be numbered")
+	}
+}
+
+func TestReplacePlaceholders(t *testing.T) {
+	got := ReplacePlaceholders("a $ {{x:1}} b", func(p Placeholder) string {
+		return "<" + p.Name + ">"
+	}, func(text string) string {
+		return "[" + text + "]"
+	})
+	if want := "[a $ ]<x>[ b]"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestRenderPlaceholders(t *testing.T) {
+	got := RenderPlaceholders("ssh {{user}}@{{host:localhost}}{{0}}", map[string]string{"user": "deploy"})
+	if want := "ssh deploy@localhost"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
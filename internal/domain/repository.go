@@ -6,18 +6,27 @@ type SnippetRepository interface {
 	FindByCategory(categoryID int) ([]*Snippet, error)
 	FindByTag(tagID int) ([]*Snippet, error)
 	FindByLanguage(language string) ([]*Snippet, error)
+	FindFavorites() ([]*Snippet, error)
+	FindPinned() ([]*Snippet, error)
+	FindRecent(limit int) ([]*Snippet, error)
+	FindMostUsed(limit int) ([]*Snippet, error)
+	FindDuplicates(code, language string) ([]*Snippet, error)
 	Search(value string) ([]*Snippet, error)
 	Create(snippet *Snippet) error
 	Update(snippet *Snippet) error
 	Delete(id int) error
+	BulkUpdate(ids []int, edit SnippetEdit) error
+	BulkDelete(ids []int) error
 }
 
 type CategoryRepository interface {
 	List() ([]*Category, error)
 	FindByID(id int) (*Category, error)
 	FindByName(name string) (*Category, error)
+	FindByPath(path string) (*Category, error)
 	Create(category *Category) error
 	Update(category *Category) error
+	Move(id, parentID int) error
 	Delete(id int) error
 }
 
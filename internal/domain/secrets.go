@@ -0,0 +1,247 @@
+// Package domain contains the core business entities and logic for the snippet manager.
+package domain
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SecretFinding is a possible credential found in snippet code.
+type SecretFinding struct {
+	Kind        string // e.g. "AWS access key ID"
+	Line        int    // 1-based line of the secret
+	Start, End  int    // Byte offsets of the secret value in the code
+	Value       string // The secret value
+	Placeholder string // Replacement used by RedactSecrets
+}
+
+// Masked returns the value with all but its first and last characters hidden.
+func (f SecretFinding) Masked() string {
+	value := strings.SplitN(f.Value, "\n", 2)[0]
+	if len(value) <= 8 {
+		return strings.Repeat("*", len(value))
+	}
+	return value[:4] + strings.Repeat("*", min(len(value)-8, 12)) + value[len(value)-4:]
+}
+
+// secretRule detects one kind of credential. The secret value is the
+// first capture group, or the whole match if the pattern has none.
+type secretRule struct {
+	kind        string
+	placeholder string
+	pattern     *regexp.Regexp
+	generic     bool // Skip values that look like placeholders
+}
+
+// secretRules are checked in order; later rules ignore text already
+// matched by earlier ones.
+var secretRules = []secretRule{
+	{
+		kind:        "private key",
+		placeholder: "<PRIVATE_KEY>",
+		pattern:     regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY[A-Z ]*-----.*?-----END [A-Z ]*PRIVATE KEY[A-Z ]*-----`),
+	},
+	{
+		kind:        "AWS access key ID",
+		placeholder: "<AWS_ACCESS_KEY_ID>",
+		pattern:     regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	},
+	{
+		kind:        "AWS secret access key",
+		placeholder: "<AWS_SECRET_ACCESS_KEY>",
+		pattern:     regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`),
+	},
+	{
+		kind:        "GitHub token",
+		placeholder: "<GITHUB_TOKEN>",
+		pattern:     regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36}|github_pat_[A-Za-z0-9_]{60,})\b`),
+	},
+	{
+		kind:        "Slack token",
+		placeholder: "<SLACK_TOKEN>",
+		pattern:     regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`),
+	},
+	{
+		kind:        "Stripe key",
+		placeholder: "<STRIPE_KEY>",
+		pattern:     regexp.MustCompile(`\b[rs]k_(?:live|test)_[A-Za-z0-9]{24,}\b`),
+	},
+	{
+		kind:        "Google API key",
+		placeholder: "<GOOGLE_API_KEY>",
+		pattern:     regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`),
+	},
+	{
+		kind:        "API key",
+		placeholder: "<API_KEY>",
+		pattern:     regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}\b`),
+	},
+	{
+		kind:        "JSON Web Token",
+		placeholder: "<JWT>",
+		pattern:     regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`),
+	},
+	{
+		kind:        "password in URL",
+		placeholder: "<PASSWORD>",
+		pattern:     regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@]+:([^/\s:@]+)@`),
+		generic:     true,
+	},
+	{
+		kind:        "credential assignment",
+		placeholder: "<SECRET>",
+		pattern:     regexp.MustCompile(`(?i)[\w.-]*(?:password|passwd|pwd|secret|token|api_?key|apikey|access_?key|auth)[\w.-]*["']?\s*(?::=|[:=])\s*["']([^"'\s]{8,})["']`),
+		generic:     true,
+	},
+}
+
+// highEntropyString matches quoted strings that may hold random tokens.
+var highEntropyString = regexp.MustCompile(`["']([A-Za-z0-9+/_=-]{20,})["']`)
+
+// hexString matches hexadecimal strings such as hashes, which are common in
+// code and rarely secrets.
+var hexString = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+// placeholderValue matches values that are clearly not real secrets.
+var placeholderValue = regexp.MustCompile(`(?i)^(?:<.*>|\$\{.*\}|\{\{.*\}\}|\$\w+|%\w+%|x+|\*+|.*(?:example|changeme|your[_-]|placeholder|dummy|redacted).*)$`)
+
+// MinSecretEntropy is the Shannon entropy, in bits per character, above
+// which a long quoted string is reported as a possible secret.
+const MinSecretEntropy = 3.7
+
+// minLetterDigitSwitches is how often a random token must alternate between
+// letters and digits. It separates tokens from identifiers like "Name2024".
+const minLetterDigitSwitches = 3
+
+// ScanSecrets finds likely credentials in code: well-known token formats,
+// private keys, passwords in URLs and assignments, and high-entropy strings.
+// Findings are ordered by position.
+func ScanSecrets(code string) []SecretFinding {
+	var findings []SecretFinding
+	covered := func(start, end int) bool {
+		for _, f := range findings {
+			if start < f.End && end > f.Start {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, rule := range secretRules {
+		for _, m := range rule.pattern.FindAllStringSubmatchIndex(code, -1) {
+			start, end := m[0], m[1]
+			if len(m) >= 4 && m[2] >= 0 {
+				start, end = m[2], m[3]
+			}
+			value := code[start:end]
+			if rule.generic && placeholderValue.MatchString(value) {
+				continue
+			}
+			if covered(start, end) {
+				continue
+			}
+			findings = append(findings, SecretFinding{
+				Kind:        rule.kind,
+				Line:        strings.Count(code[:start], "\n") + 1,
+				Start:       start,
+				End:         end,
+				Value:       value,
+				Placeholder: rule.placeholder,
+			})
+		}
+	}
+
+	for _, m := range highEntropyString.FindAllStringSubmatchIndex(code, -1) {
+		start, end := m[2], m[3]
+		value := code[start:end]
+		if covered(start, end) || !looksRandom(value) {
+			continue
+		}
+		findings = append(findings, SecretFinding{
+			Kind:        "high-entropy string",
+			Line:        strings.Count(code[:start], "\n") + 1,
+			Start:       start,
+			End:         end,
+			Value:       value,
+			Placeholder: "<SECRET>",
+		})
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Start < findings[j].Start
+	})
+	return findings
+}
+
+// looksRandom reports whether value interleaves letters and digits and has
+// high entropy. Hexadecimal strings are ignored.
+func looksRandom(value string) bool {
+	if hexString.MatchString(value) {
+		return false
+	}
+
+	switches := 0
+	lastDigit, seen := false, false
+	for _, r := range value {
+		isDigit := r >= '0' && r <= '9'
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !isDigit && !isLetter {
+			continue
+		}
+		if seen && isDigit != lastDigit {
+			switches++
+		}
+		lastDigit, seen = isDigit, true
+	}
+	return switches >= minLetterDigitSwitches && ShannonEntropy(value) >= MinSecretEntropy
+}
+
+// ShannonEntropy returns the Shannon entropy of s in bits per character.
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	total := float64(len([]rune(s)))
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / total
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// RedactSecrets replaces each finding in code with its placeholder.
+// findings must come from ScanSecrets on the same code.
+func RedactSecrets(code string, findings []SecretFinding) string {
+	var b strings.Builder
+	last := 0
+	for _, f := range findings {
+		if f.Start < last {
+			continue
+		}
+		b.WriteString(code[last:f.Start])
+		b.WriteString(f.Placeholder)
+		last = f.End
+	}
+	b.WriteString(code[last:])
+	return b.String()
+}
+
+// RedactSecretsEdit returns an edit that replaces secrets in a snippet's
+// code with placeholders. Snippets without secrets are left unchanged.
+func RedactSecretsEdit() SnippetEdit {
+	return func(s *Snippet) error {
+		findings := ScanSecrets(s.Code())
+		if len(findings) == 0 {
+			return nil
+		}
+		return s.SetCode(RedactSecrets(s.Code(), findings))
+	}
+}
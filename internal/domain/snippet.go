@@ -5,29 +5,42 @@ import (
 	"encoding/json"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 )
 
 // Snippet represents a code snippet with metadata including title, language,
-// code content, optional description, category, and tags.
+// code content, optional description, category, tags, aliases, and the
+// source it was imported from.
 type Snippet struct {
 	id          int
 	title       string
 	language    string
 	categoryID  int
 	tags        []int
+	aliases     []string // Short names such as editor snippet prefixes
 	description string
 	code        string
+	source      string // Where the snippet came from, such as docs/setup.md#Install
 	createdAt   time.Time
 	updatedAt   time.Time
+
+	// Usage metadata. Changing these does not touch updatedAt.
+	favorite   bool
+	pinned     bool
+	viewCount  int
+	copyCount  int
+	lastUsedAt time.Time
 }
 
 // NewSnippet creates and returns a new Snippet with the given title, language, and code.
+// The language is normalized with CanonicalLanguage.
 // It returns ErrEmptyTitle, ErrEmptyLanguage, or ErrEmptyCode if validation fails.
 func NewSnippet(title, language, code string) (*Snippet, error) {
 	if title == "" {
 		return nil, ErrEmptyTitle
 	}
+	language = CanonicalLanguage(language)
 	if language == "" {
 		return nil, ErrEmptyLanguage
 	}
@@ -40,6 +53,7 @@ func NewSnippet(title, language, code string) (*Snippet, error) {
 		language:  language,
 		code:      code,
 		tags:      []int{},
+		aliases:   []string{},
 		createdAt: now,
 		updatedAt: now,
 	}, nil
@@ -60,6 +74,10 @@ func (s *Snippet) Code() string { return s.code }
 // Description returns the snippet's optional description.
 func (s *Snippet) Description() string { return s.description }
 
+// Source returns where the snippet was imported from, or "" if it was
+// created in snip.
+func (s *Snippet) Source() string { return s.source }
+
 // CategoryID returns the ID of the snippet's category, or 0 if uncategorized.
 func (s *Snippet) CategoryID() int { return s.categoryID }
 
@@ -69,6 +87,25 @@ func (s *Snippet) CreatedAt() time.Time { return s.createdAt }
 // UpdatedAt returns the snippet's last modification time.
 func (s *Snippet) UpdatedAt() time.Time { return s.updatedAt }
 
+// IsFavorite reports whether the snippet is marked as a favorite.
+func (s *Snippet) IsFavorite() bool { return s.favorite }
+
+// IsPinned reports whether the snippet is pinned to the top of lists.
+func (s *Snippet) IsPinned() bool { return s.pinned }
+
+// ViewCount returns how many times the snippet has been shown.
+func (s *Snippet) ViewCount() int { return s.viewCount }
+
+// CopyCount returns how many times the snippet has been copied.
+func (s *Snippet) CopyCount() int { return s.copyCount }
+
+// UseCount returns the total number of recorded views and copies.
+func (s *Snippet) UseCount() int { return s.viewCount + s.copyCount }
+
+// LastUsedAt returns when the snippet was last shown or copied.
+// It returns the zero time if the snippet has never been used.
+func (s *Snippet) LastUsedAt() time.Time { return s.lastUsedAt }
+
 // Tags returns a copy of the snippet's tag IDs.
 // Modifying the returned slice does not affect the snippet's internal tags.
 func (s *Snippet) Tags() []int {
@@ -80,6 +117,14 @@ func (s *Snippet) Tags() []int {
 	return tags
 }
 
+// Aliases returns a copy of the snippet's aliases.
+func (s *Snippet) Aliases() []string {
+	if s.aliases == nil {
+		return []string{}
+	}
+	return slices.Clone(s.aliases)
+}
+
 // SetTitle updates the snippet's title and modification timestamp.
 // It returns ErrEmptyTitle if title is empty.
 func (s *Snippet) SetTitle(title string) error {
@@ -92,8 +137,10 @@ func (s *Snippet) SetTitle(title string) error {
 }
 
 // SetLanguage updates the snippet's language and modification timestamp.
+// The language is normalized with CanonicalLanguage.
 // It returns ErrEmptyLanguage if language is empty.
 func (s *Snippet) SetLanguage(language string) error {
+	language = CanonicalLanguage(language)
 	if language == "" {
 		return ErrEmptyLanguage
 	}
@@ -102,6 +149,18 @@ func (s *Snippet) SetLanguage(language string) error {
 	return nil
 }
 
+// NormalizeLanguage rewrites the language to its canonical name without
+// updating the modification timestamp. It reports whether the language changed.
+// It is used to migrate snippets stored before languages were normalized.
+func (s *Snippet) NormalizeLanguage() bool {
+	canonical := CanonicalLanguage(s.language)
+	if canonical == s.language || canonical == "" {
+		return false
+	}
+	s.language = canonical
+	return true
+}
+
 // SetCode updates the snippet's code and modification timestamp.
 // It returns ErrEmptyCode if code is empty.
 func (s *Snippet) SetCode(code string) error {
@@ -119,6 +178,13 @@ func (s *Snippet) SetDescription(description string) {
 	s.updatedAt = time.Now()
 }
 
+// SetSource records where the snippet was imported from and updates the
+// modification timestamp.
+func (s *Snippet) SetSource(source string) {
+	s.source = source
+	s.updatedAt = time.Now()
+}
+
 // SetCategory updates the snippet's category ID and modification timestamp.
 func (s *Snippet) SetCategory(catID int) {
 	s.categoryID = catID
@@ -152,6 +218,108 @@ func (s *Snippet) HasTag(tagID int) bool {
 	return slices.Contains(s.tags, tagID)
 }
 
+// AddAlias adds an alias to the snippet if not already present.
+// The alias is trimmed; it returns ErrEmptyAlias if nothing is left.
+func (s *Snippet) AddAlias(alias string) error {
+	alias = strings.TrimSpace(alias)
+	if alias == "" {
+		return ErrEmptyAlias
+	}
+	if slices.Contains(s.aliases, alias) {
+		return nil
+	}
+	s.aliases = append(s.aliases, alias)
+	s.updatedAt = time.Now()
+	return nil
+}
+
+// RemoveAlias removes an alias from the snippet.
+// If the alias is not present, this is a no-op.
+func (s *Snippet) RemoveAlias(alias string) {
+	if i := slices.Index(s.aliases, strings.TrimSpace(alias)); i >= 0 {
+		s.aliases = slices.Delete(s.aliases, i, i+1)
+		s.updatedAt = time.Now()
+	}
+}
+
+// HasAlias reports whether the snippet has the given alias.
+func (s *Snippet) HasAlias(alias string) bool {
+	return slices.Contains(s.aliases, strings.TrimSpace(alias))
+}
+
+// SetFavorite marks or unmarks the snippet as a favorite.
+// Usage metadata does not change the modification timestamp.
+func (s *Snippet) SetFavorite(favorite bool) {
+	s.favorite = favorite
+}
+
+// SetPinned pins or unpins the snippet.
+// Usage metadata does not change the modification timestamp.
+func (s *Snippet) SetPinned(pinned bool) {
+	s.pinned = pinned
+}
+
+// RecordView records that the snippet was shown or rendered.
+func (s *Snippet) RecordView() {
+	s.viewCount++
+	s.lastUsedAt = time.Now()
+}
+
+// RecordCopy records that the snippet's code was copied.
+func (s *Snippet) RecordCopy() {
+	s.copyCount++
+	s.lastUsedAt = time.Now()
+}
+
+// Merge folds the metadata of a duplicate snippet into this one.
+// Tags are combined, the longer description is kept, a missing category is
+// taken from other, favorite and pinned flags are combined, and usage counts
+// are summed. Aliases are combined and a missing source is taken from other.
+// Title, language and code are left unchanged.
+func (s *Snippet) Merge(other *Snippet) {
+	if other == nil || other == s {
+		return
+	}
+
+	for _, tagID := range other.tags {
+		if !slices.Contains(s.tags, tagID) {
+			s.tags = append(s.tags, tagID)
+		}
+	}
+	for _, alias := range other.aliases {
+		if !slices.Contains(s.aliases, alias) {
+			s.aliases = append(s.aliases, alias)
+		}
+	}
+	if len(strings.TrimSpace(other.description)) > len(strings.TrimSpace(s.description)) {
+		s.description = other.description
+	}
+	if s.categoryID == 0 {
+		s.categoryID = other.categoryID
+	}
+	if s.source == "" {
+		s.source = other.source
+	}
+
+	s.favorite = s.favorite || other.favorite
+	s.pinned = s.pinned || other.pinned
+	s.viewCount += other.viewCount
+	s.copyCount += other.copyCount
+	if other.lastUsedAt.After(s.lastUsedAt) {
+		s.lastUsedAt = other.lastUsedAt
+	}
+
+	s.updatedAt = time.Now()
+}
+
+// Clone returns a deep copy of the snippet.
+func (s *Snippet) Clone() *Snippet {
+	clone := *s
+	clone.tags = s.Tags()
+	clone.aliases = s.Aliases()
+	return &clone
+}
+
 // SetID sets the snippet's unique identifier.
 // This should only be called by the storage layer.
 func (s *Snippet) SetID(id int) {
@@ -174,24 +342,43 @@ func (s *Snippet) Equal(other *Snippet) bool {
 		s.language == other.language &&
 		s.code == other.code &&
 		s.description == other.description &&
+		s.source == other.source &&
 		s.categoryID == other.categoryID &&
 		slices.Equal(s.tags, other.tags) &&
+		slices.Equal(s.Aliases(), other.Aliases()) &&
 		s.createdAt.Equal(other.createdAt) &&
-		s.updatedAt.Equal(other.updatedAt)
+		s.updatedAt.Equal(other.updatedAt) &&
+		s.favorite == other.favorite &&
+		s.pinned == other.pinned &&
+		s.viewCount == other.viewCount &&
+		s.copyCount == other.copyCount &&
+		s.lastUsedAt.Equal(other.lastUsedAt)
 }
 
 // MarshalJSON implements json.Marshaler.
 func (s *Snippet) MarshalJSON() ([]byte, error) {
+	var lastUsedAt *time.Time
+	if !s.lastUsedAt.IsZero() {
+		lastUsedAt = &s.lastUsedAt
+	}
+
 	return json.Marshal(&struct {
-		ID          int       `json:"id"`
-		Title       string    `json:"title"`
-		Language    string    `json:"language"`
-		Code        string    `json:"code"`
-		Description string    `json:"description"`
-		CategoryID  int       `json:"category_id"`
-		Tags        []int     `json:"tags"`
-		CreatedAt   time.Time `json:"created_at"`
-		UpdatedAt   time.Time `json:"updated_at"`
+		ID          int        `json:"id"`
+		Title       string     `json:"title"`
+		Language    string     `json:"language"`
+		Code        string     `json:"code"`
+		Description string     `json:"description"`
+		CategoryID  int        `json:"category_id"`
+		Tags        []int      `json:"tags"`
+		Aliases     []string   `json:"aliases,omitempty"`
+		Source      string     `json:"source,omitempty"`
+		CreatedAt   time.Time  `json:"created_at"`
+		UpdatedAt   time.Time  `json:"updated_at"`
+		Favorite    bool       `json:"favorite,omitempty"`
+		Pinned      bool       `json:"pinned,omitempty"`
+		ViewCount   int        `json:"view_count,omitempty"`
+		CopyCount   int        `json:"copy_count,omitempty"`
+		LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
 	}{
 		ID:          s.id,
 		Title:       s.title,
@@ -200,23 +387,37 @@ func (s *Snippet) MarshalJSON() ([]byte, error) {
 		Description: s.description,
 		CategoryID:  s.categoryID,
 		Tags:        s.tags,
+		Aliases:     s.aliases,
+		Source:      s.source,
 		CreatedAt:   s.createdAt,
 		UpdatedAt:   s.updatedAt,
+		Favorite:    s.favorite,
+		Pinned:      s.pinned,
+		ViewCount:   s.viewCount,
+		CopyCount:   s.copyCount,
+		LastUsedAt:  lastUsedAt,
 	})
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
 func (s *Snippet) UnmarshalJSON(data []byte) error {
 	aux := &struct {
-		ID          int       `json:"id"`
-		Title       string    `json:"title"`
-		Language    string    `json:"language"`
-		Code        string    `json:"code"`
-		Description string    `json:"description"`
-		CategoryID  int       `json:"category_id"`
-		Tags        []int     `json:"tags"`
-		CreatedAt   time.Time `json:"created_at"`
-		UpdatedAt   time.Time `json:"updated_at"`
+		ID          int        `json:"id"`
+		Title       string     `json:"title"`
+		Language    string     `json:"language"`
+		Code        string     `json:"code"`
+		Description string     `json:"description"`
+		CategoryID  int        `json:"category_id"`
+		Tags        []int      `json:"tags"`
+		Aliases     []string   `json:"aliases"`
+		Source      string     `json:"source"`
+		CreatedAt   time.Time  `json:"created_at"`
+		UpdatedAt   time.Time  `json:"updated_at"`
+		Favorite    bool       `json:"favorite"`
+		Pinned      bool       `json:"pinned"`
+		ViewCount   int        `json:"view_count"`
+		CopyCount   int        `json:"copy_count"`
+		LastUsedAt  *time.Time `json:"last_used_at"`
 	}{}
 	if err := json.Unmarshal(data, aux); err != nil {
 		return err
@@ -243,7 +444,20 @@ func (s *Snippet) UnmarshalJSON(data []byte) error {
 	if s.tags == nil {
 		s.tags = []int{}
 	}
+	s.aliases = aux.Aliases
+	if s.aliases == nil {
+		s.aliases = []string{}
+	}
+	s.source = aux.Source
 	s.createdAt = aux.CreatedAt
 	s.updatedAt = aux.UpdatedAt
+	s.favorite = aux.Favorite
+	s.pinned = aux.Pinned
+	s.viewCount = aux.ViewCount
+	s.copyCount = aux.CopyCount
+	s.lastUsedAt = time.Time{}
+	if aux.LastUsedAt != nil {
+		s.lastUsedAt = *aux.LastUsedAt
+	}
 	return nil
 }
@@ -3,6 +3,7 @@ package domain
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -99,6 +100,23 @@ func TestNewSnippet(t *testing.T) {
 			t.Errorf("expected empty tags, got %v", snippet.Tags())
 		}
 	})
+
+	t.Run("language is normalized", func(t *testing.T) {
+		snippet, err := NewSnippet("Fetch", "Node", "fetch(url)")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if snippet.Language() != "javascript" {
+			t.Errorf("expected language 'javascript', got %q", snippet.Language())
+		}
+	})
+
+	t.Run("blank language returns error", func(t *testing.T) {
+		if _, err := NewSnippet("title", "  ", "code"); !errors.Is(err, ErrEmptyLanguage) {
+			t.Errorf("expected ErrEmptyLanguage, got %v", err)
+		}
+	})
 }
 
 func TestSnippet_SetID(t *testing.T) {
@@ -205,6 +223,46 @@ func TestSnippet_SetLanguage(t *testing.T) {
 			t.Errorf("expected language 'python', got %q", snippet.Language())
 		}
 	})
+
+	t.Run("normalizes language", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "title", "go", "code")
+
+		if err := snippet.SetLanguage(" JS "); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if snippet.Language() != "javascript" {
+			t.Errorf("expected language 'javascript', got %q", snippet.Language())
+		}
+	})
+}
+
+func TestSnippet_NormalizeLanguage(t *testing.T) {
+	t.Run("rewrites aliases without touching UpdatedAt", func(t *testing.T) {
+		snippet := &Snippet{}
+		if err := json.Unmarshal([]byte(`{"title":"t","language":"Golang","code":"c"}`), snippet); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		updatedAt := snippet.UpdatedAt()
+
+		if !snippet.NormalizeLanguage() {
+			t.Fatal("expected language to change")
+		}
+		if snippet.Language() != "go" {
+			t.Errorf("expected language 'go', got %q", snippet.Language())
+		}
+		if !snippet.UpdatedAt().Equal(updatedAt) {
+			t.Error("UpdatedAt should not change when normalizing")
+		}
+	})
+
+	t.Run("reports canonical languages as unchanged", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "title", "go", "code")
+
+		if snippet.NormalizeLanguage() {
+			t.Error("expected canonical language to be unchanged")
+		}
+	})
 }
 
 func TestSnippet_SetCode(t *testing.T) {
@@ -515,7 +573,7 @@ func TestSnippet_String(t *testing.T) {
 		snippet.SetID(1)
 
 		got := snippet.String()
-		want := `Snippet{id=1, title="\"Hello World\"", language="c++"}`
+		want := `Snippet{id=1, title="\"Hello World\"", language="cpp"}`
 
 		if got != want {
 			t.Errorf("String() = %q, want %q", got, want)
@@ -870,6 +928,313 @@ func TestSnippet_JSONRoundTrip(t *testing.T) {
 	})
 }
 
+func TestSnippet_FavoriteAndPin(t *testing.T) {
+	t.Run("sets flags without touching updatedAt", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "test", "go", "code")
+		updatedAt := snippet.UpdatedAt()
+
+		snippet.SetFavorite(true)
+		snippet.SetPinned(true)
+
+		if !snippet.IsFavorite() || !snippet.IsPinned() {
+			t.Error("expected snippet to be favorite and pinned")
+		}
+		if !snippet.UpdatedAt().Equal(updatedAt) {
+			t.Error("expected updatedAt to be unchanged")
+		}
+	})
+
+	t.Run("clears flags", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "test", "go", "code")
+		snippet.SetFavorite(true)
+		snippet.SetPinned(true)
+
+		snippet.SetFavorite(false)
+		snippet.SetPinned(false)
+
+		if snippet.IsFavorite() || snippet.IsPinned() {
+			t.Error("expected flags to be cleared")
+		}
+	})
+}
+
+func TestSnippet_RecordUsage(t *testing.T) {
+	t.Run("new snippet has no usage", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "test", "go", "code")
+
+		if snippet.UseCount() != 0 {
+			t.Errorf("expected use count 0, got %d", snippet.UseCount())
+		}
+		if !snippet.LastUsedAt().IsZero() {
+			t.Error("expected zero lastUsedAt")
+		}
+	})
+
+	t.Run("counts views and copies", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "test", "go", "code")
+		before := time.Now()
+
+		snippet.RecordView()
+		snippet.RecordView()
+		snippet.RecordCopy()
+
+		if snippet.ViewCount() != 2 {
+			t.Errorf("expected 2 views, got %d", snippet.ViewCount())
+		}
+		if snippet.CopyCount() != 1 {
+			t.Errorf("expected 1 copy, got %d", snippet.CopyCount())
+		}
+		if snippet.UseCount() != 3 {
+			t.Errorf("expected use count 3, got %d", snippet.UseCount())
+		}
+		if snippet.LastUsedAt().Before(before) {
+			t.Error("expected lastUsedAt to be updated")
+		}
+	})
+}
+
+func TestSnippet_UsageJSON(t *testing.T) {
+	t.Run("round trip preserves usage fields", func(t *testing.T) {
+		original := mustCreateSnippet(t, "test", "go", "code")
+		original.SetID(1)
+		original.SetFavorite(true)
+		original.SetPinned(true)
+		original.RecordView()
+		original.RecordCopy()
+
+		data, err := json.Marshal(original)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+
+		var restored Snippet
+		if err := json.Unmarshal(data, &restored); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		if !restored.Equal(original) {
+			t.Error("round trip failed: usage fields differ")
+		}
+	})
+
+	t.Run("omits usage fields for unused snippet", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "test", "go", "code")
+
+		data, err := json.Marshal(snippet)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+
+		for _, field := range []string{"favorite", "pinned", "view_count", "copy_count", "last_used_at"} {
+			if strings.Contains(string(data), field) {
+				t.Errorf("expected %q to be omitted, got %s", field, data)
+			}
+		}
+	})
+}
+
+func TestSnippet_Merge(t *testing.T) {
+	t.Run("combines metadata from duplicate", func(t *testing.T) {
+		keep := mustCreateSnippet(t, "keep", "go", "code")
+		keep.SetDescription("short")
+		keep.AddTag(1)
+		keep.RecordView()
+
+		dup := mustCreateSnippet(t, "dup", "go", "code")
+		dup.SetDescription("a much richer description")
+		dup.SetCategory(7)
+		dup.AddTag(1)
+		dup.AddTag(2)
+		dup.SetFavorite(true)
+		dup.RecordCopy()
+
+		keep.Merge(dup)
+
+		if keep.Title() != "keep" {
+			t.Errorf("expected title to be kept, got %q", keep.Title())
+		}
+		if !keep.HasTag(1) || !keep.HasTag(2) || len(keep.Tags()) != 2 {
+			t.Errorf("expected tags [1 2], got %v", keep.Tags())
+		}
+		if keep.Description() != "a much richer description" {
+			t.Errorf("expected richer description, got %q", keep.Description())
+		}
+		if keep.CategoryID() != 7 {
+			t.Errorf("expected category 7, got %d", keep.CategoryID())
+		}
+		if !keep.IsFavorite() {
+			t.Error("expected favorite flag to be merged")
+		}
+		if keep.UseCount() != 2 {
+			t.Errorf("expected use count 2, got %d", keep.UseCount())
+		}
+		if !keep.LastUsedAt().Equal(dup.LastUsedAt()) {
+			t.Error("expected latest lastUsedAt")
+		}
+	})
+
+	t.Run("keeps own category and longer description", func(t *testing.T) {
+		keep := mustCreateSnippet(t, "keep", "go", "code")
+		keep.SetCategory(3)
+		keep.SetDescription("the better description")
+
+		dup := mustCreateSnippet(t, "dup", "go", "code")
+		dup.SetCategory(7)
+		dup.SetDescription("short")
+
+		keep.Merge(dup)
+
+		if keep.CategoryID() != 3 || keep.Description() != "the better description" {
+			t.Errorf("expected own metadata to be kept, got category %d, description %q",
+				keep.CategoryID(), keep.Description())
+		}
+	})
+
+	t.Run("ignores nil and self", func(t *testing.T) {
+		keep := mustCreateSnippet(t, "keep", "go", "code")
+		keep.RecordView()
+
+		keep.Merge(nil)
+		keep.Merge(keep)
+
+		if keep.ViewCount() != 1 {
+			t.Errorf("expected view count 1, got %d", keep.ViewCount())
+		}
+	})
+}
+
+func TestSnippet_Aliases(t *testing.T) {
+	t.Run("adds trimmed aliases once", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "test", "go", "code")
+
+		if err := snippet.AddAlias(" fori "); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		snippet.AddAlias("fori")
+
+		if aliases := snippet.Aliases(); len(aliases) != 1 || aliases[0] != "fori" {
+			t.Errorf("expected [fori], got %v", aliases)
+		}
+		if !snippet.HasAlias("fori") {
+			t.Error("expected HasAlias to be true")
+		}
+	})
+
+	t.Run("rejects empty aliases", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "test", "go", "code")
+		if err := snippet.AddAlias("  "); !errors.Is(err, ErrEmptyAlias) {
+			t.Errorf("expected ErrEmptyAlias, got %v", err)
+		}
+	})
+
+	t.Run("removes aliases", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "test", "go", "code")
+		snippet.AddAlias("a")
+		snippet.AddAlias("b")
+
+		snippet.RemoveAlias("a")
+		snippet.RemoveAlias("missing")
+
+		if aliases := snippet.Aliases(); len(aliases) != 1 || aliases[0] != "b" {
+			t.Errorf("expected [b], got %v", aliases)
+		}
+	})
+
+	t.Run("survives a JSON round trip", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "test", "go", "code")
+		snippet.AddAlias("fori")
+
+		data, err := json.Marshal(snippet)
+		if err != nil {
+			t.Fatalf("marshal failed: %v", err)
+		}
+		var loaded Snippet
+		if err := json.Unmarshal(data, &loaded); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+		if !loaded.Equal(snippet) {
+			t.Errorf("expected aliases to round-trip, got %v", loaded.Aliases())
+		}
+	})
+}
+
+func TestSnippet_Source(t *testing.T) {
+	t.Run("survives a JSON round trip", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "test", "go", "code")
+		snippet.SetSource("docs/setup.md#Install")
+
+		data, err := json.Marshal(snippet)
+		if err != nil {
+			t.Fatalf("marshal failed: %v", err)
+		}
+		var loaded Snippet
+		if err := json.Unmarshal(data, &loaded); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+		if loaded.Source() != "docs/setup.md#Install" || !loaded.Equal(snippet) {
+			t.Errorf("expected source to round-trip, got %q", loaded.Source())
+		}
+	})
+
+	t.Run("merge keeps an existing source", func(t *testing.T) {
+		snippet := mustCreateSnippet(t, "test", "go", "code")
+		other := mustCreateSnippet(t, "other", "go", "code")
+		other.SetSource("README.md#Usage")
+
+		snippet.Merge(other)
+		if snippet.Source() != "README.md#Usage" {
+			t.Errorf("expected missing source to be taken, got %q", snippet.Source())
+		}
+
+		other.SetSource("elsewhere.md")
+		snippet.Merge(other)
+		if snippet.Source() != "README.md#Usage" {
+			t.Errorf("expected source to be kept, got %q", snippet.Source())
+		}
+	})
+}
+
+func TestSnippet_Clone(t *testing.T) {
+	t.Run("copy is equal and independent", func(t *testing.T) {
+		original := mustCreateSnippet(t, "test", "go", "code")
+		original.SetID(1)
+		original.AddTag(1)
+		original.RecordView()
+
+		clone := original.Clone()
+		if !clone.Equal(original) {
+			t.Fatal("expected clone to equal original")
+		}
+
+		clone.AddTag(2)
+		clone.SetTitle("changed")
+		if original.HasTag(2) || original.Title() != "test" {
+			t.Error("expected original to be unaffected by changes to clone")
+		}
+	})
+}
+
+func TestSnippetEdits(t *testing.T) {
+	snippet := mustCreateSnippet(t, "test", "go", "code")
+
+	edits := []SnippetEdit{AddTagEdit(3), SetCategoryEdit(4), SetLanguageEdit("python")}
+	for _, edit := range edits {
+		if err := edit(snippet); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if !snippet.HasTag(3) || snippet.CategoryID() != 4 || snippet.Language() != "python" {
+		t.Errorf("edits not applied: %v", snippet)
+	}
+
+	if err := RemoveTagEdit(3)(snippet); err != nil || snippet.HasTag(3) {
+		t.Errorf("expected tag to be removed, err=%v", err)
+	}
+	if err := SetLanguageEdit("")(snippet); !errors.Is(err, ErrEmptyLanguage) {
+		t.Errorf("expected ErrEmptyLanguage, got %v", err)
+	}
+}
+
 // mustCreateSnippet creates a snippet or fails the test.
 func mustCreateSnippet(t *testing.T, title, language, code string) *Snippet {
 	t.Helper()
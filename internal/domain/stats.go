@@ -0,0 +1,191 @@
+// Package domain contains the core business entities and logic for the snippet manager.
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Orphaned reference kinds reported by LibraryStats.
+const (
+	OrphanCategory = "category"
+	OrphanTag      = "tag"
+)
+
+// StatCount is the number of snippets associated with a language, category or tag.
+type StatCount struct {
+	ID    int    `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// SnippetSummary is a lightweight description of a snippet used in reports.
+type SnippetSummary struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title"`
+	Language  string    `json:"language"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrphanedReference is a snippet pointing at a category or tag that no longer exists.
+type OrphanedReference struct {
+	SnippetID    int    `json:"snippet_id"`
+	SnippetTitle string `json:"snippet_title"`
+	Kind         string `json:"kind"`
+	RefID        int    `json:"ref_id"`
+}
+
+// LibraryStats summarizes the contents of a snippet library.
+type LibraryStats struct {
+	TotalSnippets   int `json:"total_snippets"`
+	TotalCategories int `json:"total_categories"`
+	TotalTags       int `json:"total_tags"`
+	TotalLines      int `json:"total_lines"`
+
+	// Counts are sorted by count descending, then by name.
+	// Categories and tags without snippets are included with a count of 0.
+	ByLanguage []StatCount `json:"by_language"`
+	ByCategory []StatCount `json:"by_category"`
+	ByTag      []StatCount `json:"by_tag"`
+
+	RecentlyAdded   []SnippetSummary `json:"recently_added"`
+	RecentlyUpdated []SnippetSummary `json:"recently_updated"`
+
+	Untagged      []SnippetSummary    `json:"untagged"`
+	Uncategorized []SnippetSummary    `json:"uncategorized"`
+	Orphaned      []OrphanedReference `json:"orphaned"`
+}
+
+// NewLibraryStats computes statistics for the given snippets, categories and tags.
+// recentLimit caps the recently added and updated lists; 0 or less means no limit.
+// Category counts only include snippets assigned directly to the category and
+// are named by their full path.
+func NewLibraryStats(snippets []*Snippet, categories []*Category, tags []*Tag, recentLimit int) *LibraryStats {
+	stats := &LibraryStats{
+		TotalSnippets:   len(snippets),
+		TotalCategories: len(categories),
+		TotalTags:       len(tags),
+		ByLanguage:      make([]StatCount, 0),
+		ByCategory:      make([]StatCount, 0, len(categories)),
+		ByTag:           make([]StatCount, 0, len(tags)),
+		Untagged:        make([]SnippetSummary, 0),
+		Uncategorized:   make([]SnippetSummary, 0),
+		Orphaned:        make([]OrphanedReference, 0),
+	}
+
+	tree := NewCategoryTree(categories)
+	categoryCounts := make(map[int]int, len(categories))
+	for _, c := range categories {
+		categoryCounts[c.ID()] = 0
+	}
+	tagCounts := make(map[int]int, len(tags))
+	for _, t := range tags {
+		tagCounts[t.ID()] = 0
+	}
+	languageCounts := make(map[string]int)
+
+	for _, s := range snippets {
+		stats.TotalLines += CountLines(s.Code())
+		languageCounts[s.Language()]++
+
+		switch _, ok := categoryCounts[s.CategoryID()]; {
+		case s.CategoryID() == 0:
+			stats.Uncategorized = append(stats.Uncategorized, summarize(s))
+		case !ok:
+			stats.Orphaned = append(stats.Orphaned, OrphanedReference{
+				SnippetID:    s.ID(),
+				SnippetTitle: s.Title(),
+				Kind:         OrphanCategory,
+				RefID:        s.CategoryID(),
+			})
+		default:
+			categoryCounts[s.CategoryID()]++
+		}
+
+		if len(s.Tags()) == 0 {
+			stats.Untagged = append(stats.Untagged, summarize(s))
+		}
+		for _, tagID := range s.Tags() {
+			if _, ok := tagCounts[tagID]; !ok {
+				stats.Orphaned = append(stats.Orphaned, OrphanedReference{
+					SnippetID:    s.ID(),
+					SnippetTitle: s.Title(),
+					Kind:         OrphanTag,
+					RefID:        tagID,
+				})
+				continue
+			}
+			tagCounts[tagID]++
+		}
+	}
+
+	for language, count := range languageCounts {
+		stats.ByLanguage = append(stats.ByLanguage, StatCount{Name: language, Count: count})
+	}
+	for _, c := range categories {
+		stats.ByCategory = append(stats.ByCategory, StatCount{ID: c.ID(), Name: tree.Path(c.ID()), Count: categoryCounts[c.ID()]})
+	}
+	for _, t := range tags {
+		stats.ByTag = append(stats.ByTag, StatCount{ID: t.ID(), Name: t.Name(), Count: tagCounts[t.ID()]})
+	}
+	sortStatCounts(stats.ByLanguage)
+	sortStatCounts(stats.ByCategory)
+	sortStatCounts(stats.ByTag)
+
+	stats.RecentlyAdded = recentSummaries(snippets, recentLimit, func(s *Snippet) time.Time { return s.CreatedAt() })
+	stats.RecentlyUpdated = recentSummaries(snippets, recentLimit, func(s *Snippet) time.Time { return s.UpdatedAt() })
+
+	return stats
+}
+
+// CountLines returns the number of lines in code, ignoring a trailing newline.
+// Empty code has zero lines.
+func CountLines(code string) int {
+	code = strings.TrimSuffix(code, "\n")
+	if code == "" {
+		return 0
+	}
+	return strings.Count(code, "\n") + 1
+}
+
+// summarize builds a SnippetSummary from a snippet.
+func summarize(s *Snippet) SnippetSummary {
+	return SnippetSummary{
+		ID:        s.ID(),
+		Title:     s.Title(),
+		Language:  s.Language(),
+		CreatedAt: s.CreatedAt(),
+		UpdatedAt: s.UpdatedAt(),
+	}
+}
+
+// sortStatCounts orders counts by count descending, then by name.
+func sortStatCounts(counts []StatCount) {
+	sort.SliceStable(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Name < counts[j].Name
+	})
+}
+
+// recentSummaries returns snippets ordered by the given timestamp, newest first.
+func recentSummaries(snippets []*Snippet, limit int, at func(*Snippet) time.Time) []SnippetSummary {
+	sorted := make([]*Snippet, len(snippets))
+	copy(sorted, snippets)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return at(sorted[i]).After(at(sorted[j]))
+	})
+
+	if limit > 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	summaries := make([]SnippetSummary, 0, len(sorted))
+	for _, s := range sorted {
+		summaries = append(summaries, summarize(s))
+	}
+	return summaries
+}
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCountLines(t *testing.T) {
+	t.Run("returns zero for empty code", func(t *testing.T) {
+		if got := CountLines(""); got != 0 {
+			t.Errorf("expected 0, got %d", got)
+		}
+	})
+
+	t.Run("counts lines", func(t *testing.T) {
+		if got := CountLines("one"); got != 1 {
+			t.Errorf("expected 1, got %d", got)
+		}
+		if got := CountLines("one\ntwo"); got != 2 {
+			t.Errorf("expected 2, got %d", got)
+		}
+	})
+
+	t.Run("ignores a single trailing newline", func(t *testing.T) {
+		if got := CountLines("one\n"); got != 1 {
+			t.Errorf("expected 1, got %d", got)
+		}
+		if got := CountLines("one\ntwo\n\n"); got != 3 {
+			t.Errorf("expected 3, got %d", got)
+		}
+	})
+}
+
+func TestNewLibraryStats(t *testing.T) {
+	backend := newTestCategory(t, 1, "backend", 0)
+	db := newTestCategory(t, 2, "db", 1)
+	empty := newTestCategory(t, 3, "empty", 0)
+
+	sorting := mustCreateTag(t, "sorting")
+	sorting.SetID(1)
+	unused := mustCreateTag(t, "unused")
+	unused.SetID(2)
+
+	s1 := mustCreateSnippet(t, "query", "sql", "SELECT 1;\nSELECT 2;\n")
+	s1.SetID(1)
+	s1.SetCategory(2)
+	s1.AddTag(1)
+	s2 := mustCreateSnippet(t, "sort", "go", "sort.Ints(x)")
+	s2.SetID(2)
+	s2.SetCategory(2)
+	s2.AddTag(1)
+	s2.AddTag(99)
+	s3 := mustCreateSnippet(t, "hello", "go", "fmt.Println()")
+	s3.SetID(3)
+	s4 := mustCreateSnippet(t, "lost", "python", "pass")
+	s4.SetID(4)
+	s4.SetCategory(42)
+
+	stats := NewLibraryStats(
+		[]*Snippet{s1, s2, s3, s4},
+		[]*Category{backend, db, empty},
+		[]*Tag{sorting, unused},
+		0,
+	)
+
+	t.Run("counts totals", func(t *testing.T) {
+		if stats.TotalSnippets != 4 || stats.TotalCategories != 3 || stats.TotalTags != 2 {
+			t.Errorf("unexpected totals: %+v", stats)
+		}
+		if stats.TotalLines != 5 {
+			t.Errorf("expected 5 lines, got %d", stats.TotalLines)
+		}
+	})
+
+	t.Run("counts languages by frequency", func(t *testing.T) {
+		if len(stats.ByLanguage) != 3 {
+			t.Fatalf("expected 3 languages, got %d", len(stats.ByLanguage))
+		}
+		if stats.ByLanguage[0] != (StatCount{Name: "go", Count: 2}) {
+			t.Errorf("expected go first, got %+v", stats.ByLanguage[0])
+		}
+	})
+
+	t.Run("counts categories by path including empty ones", func(t *testing.T) {
+		if len(stats.ByCategory) != 3 {
+			t.Fatalf("expected 3 categories, got %d", len(stats.ByCategory))
+		}
+		if stats.ByCategory[0] != (StatCount{ID: 2, Name: "backend/db", Count: 2}) {
+			t.Errorf("unexpected top category: %+v", stats.ByCategory[0])
+		}
+	})
+
+	t.Run("counts tags including unused ones", func(t *testing.T) {
+		if len(stats.ByTag) != 2 {
+			t.Fatalf("expected 2 tags, got %d", len(stats.ByTag))
+		}
+		if stats.ByTag[0].Name != "sorting" || stats.ByTag[0].Count != 2 {
+			t.Errorf("unexpected top tag: %+v", stats.ByTag[0])
+		}
+		if stats.ByTag[1].Count != 0 {
+			t.Errorf("expected unused tag count 0, got %d", stats.ByTag[1].Count)
+		}
+	})
+
+	t.Run("finds untagged and uncategorized snippets", func(t *testing.T) {
+		if len(stats.Untagged) != 2 {
+			t.Errorf("expected 2 untagged snippets, got %d", len(stats.Untagged))
+		}
+		if len(stats.Uncategorized) != 1 || stats.Uncategorized[0].ID != 3 {
+			t.Errorf("expected snippet 3 uncategorized, got %+v", stats.Uncategorized)
+		}
+	})
+
+	t.Run("finds orphaned references", func(t *testing.T) {
+		if len(stats.Orphaned) != 2 {
+			t.Fatalf("expected 2 orphaned references, got %d", len(stats.Orphaned))
+		}
+		for _, o := range stats.Orphaned {
+			switch o.Kind {
+			case OrphanTag:
+				if o.SnippetID != 2 || o.RefID != 99 {
+					t.Errorf("unexpected orphaned tag: %+v", o)
+				}
+			case OrphanCategory:
+				if o.SnippetID != 4 || o.RefID != 42 {
+					t.Errorf("unexpected orphaned category: %+v", o)
+				}
+			default:
+				t.Errorf("unexpected kind %q", o.Kind)
+			}
+		}
+	})
+}
+
+func TestNewLibraryStats_Recent(t *testing.T) {
+	older := mustCreateSnippet(t, "older", "go", "code")
+	older.SetID(1)
+	time.Sleep(2 * time.Millisecond)
+	newer := mustCreateSnippet(t, "newer", "go", "code")
+	newer.SetID(2)
+	time.Sleep(2 * time.Millisecond)
+	if err := older.SetCode("updated"); err != nil {
+		t.Fatalf("SetCode failed: %v", err)
+	}
+
+	stats := NewLibraryStats([]*Snippet{older, newer}, nil, nil, 1)
+
+	if len(stats.RecentlyAdded) != 1 || stats.RecentlyAdded[0].ID != 2 {
+		t.Errorf("expected newer snippet as recently added, got %+v", stats.RecentlyAdded)
+	}
+	if len(stats.RecentlyUpdated) != 1 || stats.RecentlyUpdated[0].ID != 1 {
+		t.Errorf("expected older snippet as recently updated, got %+v", stats.RecentlyUpdated)
+	}
+}
+
+func TestNewLibraryStats_Empty(t *testing.T) {
+	stats := NewLibraryStats(nil, nil, nil, 5)
+
+	if stats.TotalSnippets != 0 || stats.TotalLines != 0 {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+	if stats.ByLanguage == nil || stats.Orphaned == nil || stats.RecentlyAdded == nil {
+		t.Error("expected non-nil slices for JSON output")
+	}
+}
@@ -0,0 +1,93 @@
+package exchange
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// WriteCheatSheets renders a library as Markdown: one cheat sheet per
+// category holding the snippets filed directly in it, a sheet for
+// uncategorized snippets, and a README.md linking them all.
+func WriteCheatSheets(library Library) (Files, error) {
+	idx := newSiteIndex(library)
+
+	tagNames := make(map[int]string, len(idx.tags))
+	for _, tag := range idx.tags {
+		tagNames[tag.ID()] = tag.Name()
+	}
+
+	files := make(Files)
+	var readme strings.Builder
+	readme.WriteString("# Snippet Cheat Sheets\n\n")
+
+	write := func(id int, title string) {
+		snippets := idx.byCategory[id]
+		if len(snippets) == 0 {
+			return
+		}
+		name := idx.categoryFile(id) + ".md"
+		fmt.Fprintf(&readme, "- [%s](%s) (%d)\n", title, name, len(snippets))
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "# %s\n", title)
+		for _, snippet := range snippets {
+			writeCheatSheetEntry(&b, snippet, tagNames)
+		}
+		files[name] = []byte(b.String())
+	}
+
+	for _, category := range idx.categories {
+		write(category.ID(), idx.tree.Path(category.ID()))
+	}
+	write(0, "Uncategorized")
+
+	files["README.md"] = []byte(readme.String())
+	return files, nil
+}
+
+// writeCheatSheetEntry writes one snippet as a cheat sheet section: the
+// title, description and code, followed by tags and aliases. The layout
+// matches what ParseMarkdown reads back.
+func writeCheatSheetEntry(b *strings.Builder, snippet *domain.Snippet, tagNames map[int]string) {
+	fmt.Fprintf(b, "\n## %s\n\n", snippet.Title())
+	if description := snippet.Description(); description != "" {
+		b.WriteString(description + "\n\n")
+	}
+
+	fence := markdownFenceFor(snippet.Code())
+	fmt.Fprintf(b, "%s%s\n%s\n%s\n", fence, snippet.Language(), strings.TrimSuffix(snippet.Code(), "\n"), fence)
+
+	var meta []string
+	var tags []string
+	for _, tagID := range snippet.Tags() {
+		if name, ok := tagNames[tagID]; ok {
+			tags = append(tags, "#"+name)
+		}
+	}
+	if len(tags) > 0 {
+		meta = append(meta, "Tags: "+strings.Join(tags, " "))
+	}
+	if aliases := snippet.Aliases(); len(aliases) > 0 {
+		meta = append(meta, "Aliases: "+strings.Join(aliases, ", "))
+	}
+	if len(meta) > 0 {
+		b.WriteString("\n" + strings.Join(meta, " · ") + "\n")
+	}
+}
+
+// markdownFenceFor returns a backtick fence longer than any backtick run
+// in code.
+func markdownFenceFor(code string) string {
+	longest, run := 0, 0
+	for _, r := range code {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
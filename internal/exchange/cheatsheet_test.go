@@ -0,0 +1,51 @@
+package exchange
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteCheatSheets(t *testing.T) {
+	files, err := WriteCheatSheets(testLibrary(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("writes one sheet per category with snippets", func(t *testing.T) {
+		if len(files) != 4 || files["1-backend.md"] == nil || files["2-backend-db.md"] == nil || files["uncategorized.md"] == nil {
+			t.Fatalf("unexpected files: %v", files)
+		}
+		readme := string(files["README.md"])
+		if !strings.Contains(readme, "- [backend/db](2-backend-db.md) (1)") {
+			t.Errorf("expected README to link sheets:\n%s", readme)
+		}
+		if sheet := string(files["2-backend-db.md"]); !strings.Contains(sheet, "Tags: #web · Aliases: sel") {
+			t.Errorf("expected tags and aliases:\n%s", sheet)
+		}
+	})
+
+	t.Run("fences code containing backticks", func(t *testing.T) {
+		if sheet := string(files["uncategorized.md"]); !strings.Contains(sheet, "````bash\necho \"```\"") {
+			t.Errorf("expected a longer fence:\n%s", sheet)
+		}
+	})
+
+	t.Run("reads back with ParseMarkdown", func(t *testing.T) {
+		for name, want := range map[string]string{
+			"2-backend-db.md":  "SELECT * FROM {{table:users}}; -- all",
+			"uncategorized.md": "echo \"```\" $# # done",
+		} {
+			snippets, err := ParseMarkdown(name, files[name])
+			if err != nil || len(snippets) != 1 {
+				t.Fatalf("failed to parse %s: %v", name, err)
+			}
+			if snippets[0].Code() != want {
+				t.Errorf("%s: expected code %q, got %q", name, want, snippets[0].Code())
+			}
+		}
+		snippets, _ := ParseMarkdown("2-backend-db.md", files["2-backend-db.md"])
+		if snippets[0].Title() != "Query <rows>" || snippets[0].Description() != "List every row" || snippets[0].Language() != "sql" {
+			t.Errorf("unexpected snippet: %s %q", snippets[0], snippets[0].Description())
+		}
+	})
+}
@@ -0,0 +1,68 @@
+// Package exchange converts snippets to and from the file formats of other
+// snippet tools and editors. Each format has a parser taking a file name and
+// its contents, and a writer. Editor tab stops are converted to and from
+// domain placeholders.
+package exchange
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// Files holds the output of formats that write a directory tree, keyed by
+// slash-separated paths relative to the output directory.
+type Files map[string][]byte
+
+// unsafeFileChars matches characters that are replaced in generated file names.
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._+-]+`)
+
+// fileName turns text into a file name, or returns fallback if nothing is left.
+func fileName(text, fallback string) string {
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(text, "-"), "-.")
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+// trigger returns the first alias of a snippet's aliases, or a trigger
+// derived from its title.
+func trigger(aliases []string, title string) string {
+	if len(aliases) > 0 {
+		return aliases[0]
+	}
+	return strings.ToLower(fileName(title, "snippet"))
+}
+
+// Library is a set of snippets with the categories and tags they refer to,
+// for formats that organize snippets by category and tag.
+type Library struct {
+	Snippets   []*domain.Snippet
+	Categories []*domain.Category
+	Tags       []*domain.Tag
+}
+
+// Imported is a parsed snippet with the names of its tags, for formats that
+// tag snippets. The tags are found or created when the snippet is saved.
+type Imported struct {
+	Snippet *domain.Snippet
+	Tags    []string
+}
+
+// SkippedError lists the entries of a file that could not be converted.
+// A parser returns it along with the snippets of the other entries.
+type SkippedError struct {
+	Entries []error
+}
+
+// Error implements error.
+func (e *SkippedError) Error() string {
+	messages := make([]string, len(e.Entries))
+	for i, err := range e.Entries {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("skipped %d entry(ies): %s", len(e.Entries), strings.Join(messages, "; "))
+}
@@ -0,0 +1,124 @@
+package exchange
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// highlightKeywords lists the keywords highlighted for each language.
+// Other languages get comments, strings, numbers and placeholders only.
+var highlightKeywords = map[string][]string{
+	"bash":       {"if", "then", "else", "elif", "fi", "for", "while", "until", "do", "done", "case", "esac", "in", "function", "return", "local", "export", "readonly", "set", "unset", "shift", "exit", "break", "continue"},
+	"c":          {"if", "else", "for", "while", "do", "switch", "case", "default", "break", "continue", "return", "goto", "struct", "union", "enum", "typedef", "const", "static", "extern", "sizeof", "void", "int", "char", "long", "short", "unsigned", "signed", "float", "double"},
+	"cpp":        {"if", "else", "for", "while", "do", "switch", "case", "default", "break", "continue", "return", "struct", "class", "public", "private", "protected", "virtual", "override", "template", "typename", "namespace", "using", "const", "constexpr", "static", "auto", "new", "delete", "nullptr", "true", "false", "void", "int", "char", "bool", "double"},
+	"csharp":     {"if", "else", "for", "foreach", "while", "do", "switch", "case", "default", "break", "continue", "return", "class", "struct", "interface", "namespace", "using", "public", "private", "protected", "static", "readonly", "var", "new", "null", "true", "false", "async", "await", "void", "string", "int", "bool"},
+	"go":         {"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func", "go", "goto", "if", "import", "interface", "map", "package", "range", "return", "select", "struct", "switch", "type", "var", "nil", "true", "false"},
+	"java":       {"if", "else", "for", "while", "do", "switch", "case", "default", "break", "continue", "return", "class", "interface", "enum", "extends", "implements", "package", "import", "public", "private", "protected", "static", "final", "new", "null", "true", "false", "void", "try", "catch", "finally", "throw", "throws"},
+	"javascript": {"async", "await", "break", "case", "catch", "class", "const", "continue", "default", "delete", "do", "else", "export", "extends", "finally", "for", "from", "function", "if", "import", "in", "instanceof", "let", "new", "null", "of", "return", "switch", "this", "throw", "try", "typeof", "undefined", "var", "while", "yield", "true", "false"},
+	"kotlin":     {"if", "else", "for", "while", "when", "return", "class", "object", "interface", "fun", "val", "var", "package", "import", "null", "true", "false", "is", "in", "try", "catch", "finally", "throw"},
+	"lua":        {"and", "break", "do", "else", "elseif", "end", "false", "for", "function", "if", "in", "local", "nil", "not", "or", "repeat", "return", "then", "true", "until", "while"},
+	"php":        {"if", "else", "elseif", "foreach", "for", "while", "switch", "case", "default", "break", "continue", "return", "function", "class", "public", "private", "protected", "static", "new", "echo", "null", "true", "false", "use", "namespace"},
+	"python":     {"and", "as", "assert", "async", "await", "break", "class", "continue", "def", "del", "elif", "else", "except", "finally", "for", "from", "global", "if", "import", "in", "is", "lambda", "None", "nonlocal", "not", "or", "pass", "raise", "return", "True", "False", "try", "while", "with", "yield"},
+	"ruby":       {"begin", "class", "def", "do", "else", "elsif", "end", "ensure", "false", "for", "if", "in", "module", "next", "nil", "require", "rescue", "return", "self", "then", "true", "unless", "until", "when", "while", "yield"},
+	"rust":       {"as", "break", "const", "continue", "crate", "else", "enum", "fn", "for", "if", "impl", "in", "let", "loop", "match", "mod", "move", "mut", "pub", "ref", "return", "self", "Self", "static", "struct", "trait", "type", "use", "where", "while", "true", "false"},
+	"sql":        {"select", "from", "where", "and", "or", "not", "insert", "into", "values", "update", "set", "delete", "create", "table", "drop", "alter", "index", "join", "left", "right", "inner", "outer", "on", "group", "by", "order", "having", "limit", "as", "null", "is", "in", "distinct", "primary", "key"},
+	"swift":      {"if", "else", "for", "while", "switch", "case", "default", "break", "continue", "return", "class", "struct", "enum", "protocol", "extension", "func", "let", "var", "import", "nil", "true", "false", "guard", "in", "self"},
+	"typescript": {"async", "await", "break", "case", "catch", "class", "const", "continue", "default", "do", "else", "enum", "export", "extends", "finally", "for", "from", "function", "if", "implements", "import", "in", "interface", "let", "new", "null", "of", "return", "switch", "this", "throw", "try", "type", "typeof", "undefined", "var", "while", "true", "false"},
+}
+
+// highlightBackquotes lists the languages where backquotes delimit strings
+// or commands.
+var highlightBackquotes = map[string]bool{"bash": true, "go": true, "javascript": true, "typescript": true, "zsh": true}
+
+// highlightToken matches numbers, words and placeholders.
+var highlightToken = regexp.MustCompile(`^(?:\{\{[^{}\n]*\}\}|[0-9][0-9a-fA-FxX_.]*|[A-Za-z_][A-Za-z0-9_]*)`)
+
+// highlightHTML returns code as escaped HTML with comments, strings,
+// numbers, keywords and placeholders wrapped in spans with the classes
+// c, s, n, k and p. A '#' starts a comment only after whitespace, so shell
+// expansions such as $# and ${#list} are not comments.
+func highlightHTML(code, language string) string {
+	language = domain.CanonicalLanguage(language)
+	if language == "zsh" {
+		language = "bash"
+	}
+	info, _ := domain.LookupLanguage(language)
+	keywords := make(map[string]bool)
+	for _, keyword := range highlightKeywords[language] {
+		if language == "sql" {
+			keyword = strings.ToUpper(keyword)
+		}
+		keywords[keyword] = true
+	}
+
+	var b strings.Builder
+	span := func(class, text string) {
+		b.WriteString(`<span class="` + class + `">` + html.EscapeString(text) + `</span>`)
+	}
+
+	for i := 0; i < len(code); {
+		rest := code[i:]
+		switch {
+		case info.LineComment != "" && strings.HasPrefix(rest, info.LineComment) &&
+			(info.LineComment != "#" || i == 0 || strings.ContainsRune(" \t\n", rune(code[i-1]))):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			span("c", rest[:end])
+			i += end
+		case info.BlockComment[0] != "" && strings.HasPrefix(rest, info.BlockComment[0]):
+			end := strings.Index(rest[len(info.BlockComment[0]):], info.BlockComment[1])
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end += len(info.BlockComment[0]) + len(info.BlockComment[1])
+			}
+			span("c", rest[:end])
+			i += end
+		case rest[0] == '"' || (rest[0] == '\'' && language != "rust") || (rest[0] == '`' && highlightBackquotes[language]):
+			end := stringEnd(rest)
+			span("s", rest[:end])
+			i += end
+		default:
+			token := highlightToken.FindString(rest)
+			switch {
+			case token == "":
+				b.WriteString(html.EscapeString(rest[:1]))
+				i++
+				continue
+			case strings.HasPrefix(token, "{{"):
+				span("p", token)
+			case token[0] >= '0' && token[0] <= '9':
+				span("n", token)
+			case keywords[token] || (language == "sql" && keywords[strings.ToUpper(token)]):
+				span("k", token)
+			default:
+				b.WriteString(html.EscapeString(token))
+			}
+			i += len(token)
+		}
+	}
+	return b.String()
+}
+
+// stringEnd returns the length of the string literal at the start of s,
+// which ends at the matching unescaped quote or, except for backquotes, at
+// the end of the line.
+func stringEnd(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote != '`':
+			i++
+		case s[i] == quote:
+			return i + 1
+		case s[i] == '\n' && quote != '`':
+			return i
+		}
+	}
+	return len(s)
+}
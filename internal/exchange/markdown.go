@@ -0,0 +1,175 @@
+package exchange
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// markdownLanguages maps fence info strings to snip languages where
+// CanonicalLanguage does not already do so. Plain text blocks are detected.
+var markdownLanguages = map[string]string{
+	"console":       "bash",
+	"shell-session": "bash",
+	"terminal":      "bash",
+	"golang":        "go",
+	"text":          "",
+	"txt":           "",
+	"plaintext":     "",
+	"plain":         "",
+}
+
+var (
+	// markdownHeading matches an ATX heading such as "## Install ##".
+	markdownHeading = regexp.MustCompile(`^ {0,3}#{1,6}(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	// markdownFence matches the opening line of a fenced code block.
+	markdownFence = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})(.*)$")
+	// markdownSetext matches the underline of a setext heading.
+	markdownSetext = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+)
+
+// LanguageFromMarkdown returns the snip language for a fence info string,
+// or "" if it names none.
+func LanguageFromMarkdown(info string) string {
+	word, _, _ := strings.Cut(strings.TrimSpace(info), " ")
+	word = strings.ToLower(strings.Trim(word, "{}."))
+	if language, ok := markdownLanguages[word]; ok {
+		return language
+	}
+	return domain.CanonicalLanguage(word)
+}
+
+// markdownBlock is a fenced code block and the text around it.
+type markdownBlock struct {
+	heading     string
+	description string
+	language    string
+	code        string
+}
+
+// ParseMarkdown reads the fenced code blocks of a Markdown document. The
+// info string sets the language, detected from the code if it is missing;
+// the nearest heading above a block becomes its title, the paragraph before
+// it (or after it, if there is none) its description, and the file and
+// heading its source, as "file#heading". Empty blocks are skipped.
+func ParseMarkdown(filename string, data []byte) ([]*domain.Snippet, error) {
+	blocks := parseMarkdownBlocks(strings.ReplaceAll(string(data), "\r\n", "\n"))
+
+	fallback := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	names := make(map[string]bool)
+	var snippets []*domain.Snippet
+	for _, block := range blocks {
+		title := block.heading
+		if title == "" {
+			title = fallback
+		}
+
+		language := block.language
+		if language == "" {
+			language = domain.DetectLanguage("", block.code).Language
+		}
+		if language == "" {
+			language = "text"
+		}
+
+		snippet, err := domain.NewSnippet(uniqueName(title, names), language, block.code)
+		if err != nil {
+			return nil, err
+		}
+		snippet.SetDescription(block.description)
+		source := filename
+		if block.heading != "" {
+			source += "#" + block.heading
+		}
+		snippet.SetSource(source)
+		snippets = append(snippets, snippet)
+	}
+	return snippets, nil
+}
+
+// parseMarkdownBlocks splits a document into its non-empty fenced code
+// blocks.
+func parseMarkdownBlocks(text string) []*markdownBlock {
+	lines := strings.Split(text, "\n")
+
+	var blocks []*markdownBlock
+	var heading string
+	var paragraph []string // Lines of the paragraph being read
+	var previous string    // Last paragraph under the current heading
+	var waiting []*markdownBlock
+
+	endParagraph := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		previous = strings.Join(paragraph, " ")
+		for _, block := range waiting {
+			block.description = previous
+		}
+		waiting = nil
+		paragraph = nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			endParagraph()
+		case markdownHeading.MatchString(line):
+			endParagraph()
+			heading = strings.TrimSpace(markdownHeading.FindStringSubmatch(line)[1])
+			previous, waiting = "", nil
+		case len(paragraph) == 1 && markdownSetext.MatchString(line):
+			heading = paragraph[0]
+			paragraph, previous, waiting = nil, "", nil
+		case markdownFence.MatchString(line):
+			endParagraph()
+			m := markdownFence.FindStringSubmatch(line)
+			indent, fence, info := len(m[1]), m[2], m[3]
+			if fence[0] == '`' && strings.Contains(info, "`") {
+				paragraph = append(paragraph, strings.TrimSpace(line))
+				continue
+			}
+
+			var code []string
+			for i++; i < len(lines); i++ {
+				closing := strings.TrimSpace(lines[i])
+				if strings.HasPrefix(closing, fence) && strings.Trim(closing, fence[:1]) == "" &&
+					len(lines[i])-len(strings.TrimLeft(lines[i], " ")) <= 3 {
+					break
+				}
+				code = append(code, trimIndent(lines[i], indent))
+			}
+
+			block := &markdownBlock{
+				heading:     heading,
+				description: previous,
+				language:    LanguageFromMarkdown(info),
+				code:        strings.Join(code, "\n"),
+			}
+			if strings.TrimSpace(block.code) == "" {
+				continue
+			}
+			blocks = append(blocks, block)
+			if previous == "" {
+				waiting = append(waiting, block)
+			}
+			// A paragraph after this block describes only waiting blocks.
+			previous = ""
+		default:
+			paragraph = append(paragraph, strings.TrimSpace(line))
+		}
+	}
+	endParagraph()
+	return blocks
+}
+
+// trimIndent removes up to n leading spaces from line.
+func trimIndent(line string, n int) string {
+	for i := 0; i < n && strings.HasPrefix(line, " "); i++ {
+		line = line[1:]
+	}
+	return line
+}
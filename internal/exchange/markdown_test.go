@@ -0,0 +1,77 @@
+package exchange
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	data, err := os.ReadFile("testdata/wiki.md")
+	if err != nil {
+		t.Fatalf("failed to read sample: %v", err)
+	}
+
+	snippets, err := ParseMarkdown("docs/wiki.md", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(snippets) != 4 {
+		t.Fatalf("expected 4 snippets, got %d", len(snippets))
+	}
+
+	t.Run("uses the heading and paragraph before a block", func(t *testing.T) {
+		build := snippets[0]
+		if build.Title() != "Build the image" || build.Language() != "bash" {
+			t.Errorf("unexpected snippet: %s", build)
+		}
+		if build.Description() != "Build and tag the image before pushing it." {
+			t.Errorf("unexpected description %q", build.Description())
+		}
+		if build.Source() != "docs/wiki.md#Build the image" {
+			t.Errorf("unexpected source %q", build.Source())
+		}
+		if want := "docker build -t {{image:app}} .\ndocker push {{image:app}}"; build.Code() != want {
+			t.Errorf("expected code %q, got %q", want, build.Code())
+		}
+	})
+
+	t.Run("falls back to the paragraph after a block", func(t *testing.T) {
+		status := snippets[1]
+		if status.Title() != "Check status" || status.Language() != "bash" {
+			t.Errorf("unexpected snippet: %s", status)
+		}
+		if status.Description() != "Lists the pods of the current namespace." {
+			t.Errorf("unexpected description %q", status.Description())
+		}
+	})
+
+	t.Run("detects missing languages and numbers repeated headings", func(t *testing.T) {
+		main := snippets[2]
+		if main.Title() != "Check status (2)" || main.Language() != "go" {
+			t.Errorf("unexpected snippet: %s", main)
+		}
+		if main.Description() != "Lists the pods of the current namespace." {
+			t.Errorf("expected the paragraph between blocks, got %q", main.Description())
+		}
+	})
+
+	t.Run("reads setext headings and indented tilde fences", func(t *testing.T) {
+		indented := snippets[3]
+		if indented.Title() != "Setext Heading" || indented.Language() != "python" {
+			t.Errorf("unexpected snippet: %s", indented)
+		}
+		if want := "print(\"indented\")\n  print(\"more\")"; indented.Code() != want {
+			t.Errorf("expected code %q, got %q", want, indented.Code())
+		}
+	})
+
+	t.Run("uses the file name without headings", func(t *testing.T) {
+		snippets, err := ParseMarkdown("notes.md", []byte("```go\nx := 1\n```\n"))
+		if err != nil || len(snippets) != 1 {
+			t.Fatalf("unexpected result: %v %v", snippets, err)
+		}
+		if snippets[0].Title() != "notes" || snippets[0].Source() != "notes.md" {
+			t.Errorf("unexpected title or source: %q %q", snippets[0].Title(), snippets[0].Source())
+		}
+	})
+}
@@ -0,0 +1,198 @@
+package exchange
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// naviEcho matches a navi variable suggestion that prints a single value,
+// as written by WriteNavi for placeholder defaults.
+var naviEcho = regexp.MustCompile(`^echo\s+('(?:[^']|'\\'')*'|"[^"]*"|[^\s'"|;&]+)\s*$`)
+
+// naviCommand is a command of a cheat section before its variables are known.
+type naviCommand struct {
+	title       string
+	description []string
+	lines       []string
+}
+
+// ParseNavi reads a navi `.cheat` file. Each `# description` line and the
+// command lines after it become a bash snippet titled by the description;
+// `;` comment lines directly above become its description, and the tags of
+// the `%` line above become its tags. <variable> parameters become
+// placeholders; a variable whose `$` suggestion simply echoes a value gets
+// that value as its default. Other suggestion commands are not imported.
+func ParseNavi(filename string, data []byte) ([]Imported, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	var imported []Imported
+	var tags []string
+	var section []*naviCommand
+	defaults := make(map[string]string)
+	var comments []string
+	var current *naviCommand
+
+	endSection := func() error {
+		for _, cmd := range section {
+			code := domain.ReplacePlaceholders(fromAngleParams(strings.Join(cmd.lines, "\n")), func(p domain.Placeholder) string {
+				if p.Default == "" {
+					p.Default = defaults[p.Name]
+				}
+				return domain.PlaceholderText(p)
+			}, nil)
+
+			title := cmd.title
+			if title == "" {
+				title = strings.TrimSpace(cmd.lines[0])
+			}
+			snippet, err := domain.NewSnippet(title, "bash", code)
+			if err != nil {
+				return fmt.Errorf("command %q: %w", title, err)
+			}
+			snippet.SetDescription(strings.Join(cmd.description, "\n"))
+			imported = append(imported, Imported{Snippet: snippet, Tags: slices.Clone(tags)})
+		}
+		section, current = nil, nil
+		defaults = make(map[string]string)
+		return nil
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			current, comments = nil, nil
+		case strings.HasPrefix(trimmed, "%"):
+			if err := endSection(); err != nil {
+				return nil, err
+			}
+			tags = nil
+			for _, tag := range strings.Split(strings.TrimPrefix(trimmed, "%"), ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					tags = append(tags, tag)
+				}
+			}
+			comments = nil
+		case strings.HasPrefix(trimmed, "#"):
+			current = &naviCommand{title: strings.TrimSpace(strings.TrimPrefix(trimmed, "#")), description: comments}
+			comments = nil
+		case strings.HasPrefix(trimmed, ";"):
+			comments = append(comments, strings.TrimSpace(strings.TrimPrefix(trimmed, ";")))
+		case strings.HasPrefix(trimmed, "$"):
+			name, suggestion, ok := strings.Cut(strings.TrimPrefix(trimmed, "$"), ":")
+			if !ok {
+				continue
+			}
+			suggestion, _, _ = strings.Cut(suggestion, "---")
+			if m := naviEcho.FindStringSubmatch(strings.TrimSpace(suggestion)); m != nil {
+				defaults[strings.TrimSpace(name)] = naviUnquote(m[1])
+			}
+			current = nil
+		case strings.HasPrefix(trimmed, "@"):
+			current = nil
+		default:
+			if current == nil {
+				current = &naviCommand{description: comments}
+				comments = nil
+			}
+			if len(current.lines) == 0 {
+				section = append(section, current)
+			}
+			current.lines = append(current.lines, line)
+		}
+	}
+	if err := endSection(); err != nil {
+		return nil, err
+	}
+	return imported, nil
+}
+
+// naviUnquote removes the shell quotes around an echoed value.
+func naviUnquote(value string) string {
+	switch {
+	case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+		return strings.ReplaceAll(value[1:len(value)-1], `'\''`, "'")
+	case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// WriteNavi writes snippets as a navi `.cheat` file. Snippets are grouped
+// into sections by their `%` tags: the category path followed by the tag
+// names, or the language for snippets with neither. Titles become `#`
+// descriptions, descriptions `;` comments, and placeholders <variables>;
+// defaults are written as `$ name: echo 'default'` suggestions.
+func WriteNavi(library Library) ([]byte, error) {
+	tree := domain.NewCategoryTree(library.Categories)
+	tagNames := make(map[int]string, len(library.Tags))
+	for _, tag := range library.Tags {
+		tagNames[tag.ID()] = tag.Name()
+	}
+
+	header := func(snippet *domain.Snippet) string {
+		var tags []string
+		if path := tree.Path(snippet.CategoryID()); path != "" {
+			tags = append(tags, path)
+		}
+		for _, tagID := range snippet.Tags() {
+			if name, ok := tagNames[tagID]; ok {
+				tags = append(tags, name)
+			}
+		}
+		if len(tags) == 0 {
+			tags = append(tags, snippet.Language())
+		}
+		return strings.Join(tags, ", ")
+	}
+
+	var headers []string
+	sections := make(map[string][]*domain.Snippet)
+	for _, snippet := range library.Snippets {
+		h := header(snippet)
+		if _, ok := sections[h]; !ok {
+			headers = append(headers, h)
+		}
+		sections[h] = append(sections[h], snippet)
+	}
+
+	var b strings.Builder
+	for i, h := range headers {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%% %s\n", h)
+
+		var names []string
+		defaults := make(map[string]string)
+		for _, snippet := range sections[h] {
+			b.WriteString("\n")
+			if description := snippet.Description(); description != "" {
+				for _, line := range strings.Split(description, "\n") {
+					b.WriteString(strings.TrimRight("; "+line, " ") + "\n")
+				}
+			}
+			fmt.Fprintf(&b, "# %s\n", snippet.Title())
+			b.WriteString(strings.TrimRight(toAngleParams(snippet.Code(), false), "\n") + "\n")
+
+			for _, p := range domain.Placeholders(snippet.Code()) {
+				if _, ok := defaults[p.Name]; !ok && p.Default != "" {
+					names = append(names, p.Name)
+					defaults[p.Name] = p.Default
+				}
+			}
+		}
+
+		if len(names) > 0 {
+			b.WriteString("\n")
+		}
+		for _, name := range names {
+			fmt.Fprintf(&b, "$ %s: echo '%s'\n", name, strings.ReplaceAll(defaults[name], "'", `'\''`))
+		}
+	}
+	return []byte(b.String()), nil
+}
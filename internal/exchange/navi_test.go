@@ -0,0 +1,80 @@
+package exchange
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseNavi(t *testing.T) {
+	data, err := os.ReadFile("testdata/git.cheat")
+	if err != nil {
+		t.Fatalf("failed to read sample: %v", err)
+	}
+	imported, err := ParseNavi("git.cheat", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(imported) != 3 {
+		t.Fatalf("expected 3 snippets, got %d", len(imported))
+	}
+
+	t.Run("maps sections, descriptions and comments", func(t *testing.T) {
+		log := imported[0]
+		if log.Snippet.Title() != "Show log of a branch" || log.Snippet.Description() != "Shows commits reachable from the branch" {
+			t.Errorf("unexpected snippet: %s %q", log.Snippet, log.Snippet.Description())
+		}
+		if !slices.Equal(log.Tags, []string{"git", "vcs"}) {
+			t.Errorf("unexpected tags %v", log.Tags)
+		}
+		if !slices.Equal(imported[2].Tags, []string{"docker"}) {
+			t.Errorf("unexpected tags %v", imported[2].Tags)
+		}
+	})
+
+	t.Run("applies echoed suggestions as defaults", func(t *testing.T) {
+		want := "git checkout -b {{branch:main}}\ngit push -u origin {{branch:main}}"
+		if code := imported[1].Snippet.Code(); code != want {
+			t.Errorf("expected code %q, got %q", want, code)
+		}
+		if code := imported[0].Snippet.Code(); code != "git log --oneline {{branch:main}}" {
+			t.Errorf("expected the default to apply to the whole section, got %q", code)
+		}
+		if code := imported[2].Snippet.Code(); code != "docker run --rm -it {{image}} {{cmd:sh}}" {
+			t.Errorf("expected only echoed defaults, got %q", code)
+		}
+	})
+}
+
+func TestWriteNavi(t *testing.T) {
+	data, err := WriteNavi(testLibrary(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cheat := string(data)
+	for _, want := range []string{
+		"% backend/db, web\n\n; List every row\n# Query <rows>\nSELECT * FROM <table>; -- all\n\n$ table: echo 'users'\n",
+		"% backend, web\n",
+		"% bash\n",
+	} {
+		if !strings.Contains(cheat, want) {
+			t.Errorf("expected %q in:\n%s", want, cheat)
+		}
+	}
+
+	imported, err := ParseNavi("snip.cheat", data)
+	if err != nil {
+		t.Fatalf("failed to read back: %v", err)
+	}
+	if len(imported) != 3 {
+		t.Fatalf("expected 3 snippets, got %d", len(imported))
+	}
+	query := imported[0]
+	if query.Snippet.Code() != "SELECT * FROM {{table:users}}; -- all" || query.Snippet.Description() != "List every row" {
+		t.Errorf("unexpected snippet: %s %q", query.Snippet, query.Snippet.Code())
+	}
+	if !slices.Equal(query.Tags, []string{"backend/db", "web"}) {
+		t.Errorf("unexpected tags %v", query.Tags)
+	}
+}
@@ -0,0 +1,47 @@
+package exchange
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// angleParam matches the <name> parameters of pet and navi, and pet's
+// <name=default> form. Names follow snip placeholder rules; other text in
+// angle brackets, such as <(command) or <<EOF, is left alone.
+var angleParam = regexp.MustCompile(`<([0-9]+|[A-Za-z_][A-Za-z0-9_-]*)(?:=([^<>\n]*))?>`)
+
+// fromAngleParams converts <name> and <name=default> parameters to
+// placeholders. Pet choices such as <env=|_dev_||_prod_|> keep their first
+// option as the default.
+func fromAngleParams(command string) string {
+	return angleParam.ReplaceAllStringFunc(command, func(match string) string {
+		m := angleParam.FindStringSubmatch(match)
+		placeholder := domain.Placeholder{Name: m[1], Default: m[2]}
+		if strings.HasPrefix(placeholder.Default, "|_") {
+			first, _, _ := strings.Cut(strings.TrimPrefix(placeholder.Default, "|_"), "_|")
+			placeholder.Default = first
+		}
+		if strings.ContainsAny(placeholder.Default, "{}") {
+			placeholder.Default = ""
+		}
+		return domain.PlaceholderText(placeholder)
+	})
+}
+
+// toAngleParams converts placeholders to <name> parameters, or to
+// <name=default> if withDefaults is true and there is a default. The final
+// cursor {{0}} has no equivalent and is dropped.
+func toAngleParams(code string, withDefaults bool) string {
+	return domain.ReplacePlaceholders(code, func(p domain.Placeholder) string {
+		switch {
+		case p.Name == "0":
+			return ""
+		case withDefaults && p.Default != "":
+			return "<" + p.Name + "=" + p.Default + ">"
+		default:
+			return "<" + p.Name + ">"
+		}
+	}, nil)
+}
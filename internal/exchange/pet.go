@@ -0,0 +1,69 @@
+package exchange
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// ParsePet reads a pet snippet file, usually snippet.toml. Each
+// [[snippets]] entry becomes a bash snippet: the description is the title,
+// the command is the code with <param> and <param=default> parameters as
+// placeholders, and the tags are kept. Example output is not imported.
+func ParsePet(filename string, data []byte) ([]Imported, error) {
+	tables, err := readTOMLArray(data, "snippets")
+	if err != nil {
+		return nil, err
+	}
+
+	var imported []Imported
+	for i, table := range tables {
+		command := table.String("command")
+		if strings.TrimSpace(command) == "" {
+			return nil, fmt.Errorf("snippet %d: missing command", i+1)
+		}
+
+		title := strings.TrimSpace(table.String("description"))
+		if title == "" {
+			title, _, _ = strings.Cut(strings.TrimSpace(command), "\n")
+		}
+
+		snippet, err := domain.NewSnippet(title, "bash", fromAngleParams(command))
+		if err != nil {
+			return nil, fmt.Errorf("snippet %d: %w", i+1, err)
+		}
+		imported = append(imported, Imported{Snippet: snippet, Tags: table.Strings("tag")})
+	}
+	return imported, nil
+}
+
+// WritePet writes snippets as a pet snippet file. Titles become
+// descriptions, placeholders become <param=default> parameters, and tag
+// names are written as tags.
+func WritePet(library Library) ([]byte, error) {
+	tagNames := make(map[int]string, len(library.Tags))
+	for _, tag := range library.Tags {
+		tagNames[tag.ID()] = tag.Name()
+	}
+
+	var b strings.Builder
+	for i, snippet := range library.Snippets {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		var tags []string
+		for _, tagID := range snippet.Tags() {
+			if name, ok := tagNames[tagID]; ok {
+				tags = append(tags, tomlString(name))
+			}
+		}
+
+		b.WriteString("[[snippets]]\n")
+		fmt.Fprintf(&b, "  description = %s\n", tomlString(snippet.Title()))
+		fmt.Fprintf(&b, "  command = %s\n", tomlString(toAngleParams(snippet.Code(), true)))
+		fmt.Fprintf(&b, "  tag = [%s]\n", strings.Join(tags, ", "))
+		b.WriteString("  output = \"\"\n")
+	}
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,91 @@
+package exchange
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParsePet(t *testing.T) {
+	data, err := os.ReadFile("testdata/snippet.toml")
+	if err != nil {
+		t.Fatalf("failed to read sample: %v", err)
+	}
+	imported, err := ParsePet("snippet.toml", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(imported) != 3 {
+		t.Fatalf("expected 3 snippets, got %d", len(imported))
+	}
+
+	t.Run("maps description, command and tags", func(t *testing.T) {
+		find := imported[0]
+		if find.Snippet.Title() != "Find large files" || find.Snippet.Language() != "bash" {
+			t.Errorf("unexpected snippet: %s", find.Snippet)
+		}
+		if want := "find {{dir:.}} -size +{{size:100M}}"; find.Snippet.Code() != want {
+			t.Errorf("expected code %q, got %q", want, find.Snippet.Code())
+		}
+		if !slices.Equal(find.Tags, []string{"files", "disk"}) {
+			t.Errorf("unexpected tags %v", find.Tags)
+		}
+	})
+
+	t.Run("uses the first choice as default", func(t *testing.T) {
+		want := "kubectl config use-context {{env:staging}}\nkubectl apply -f {{file}}"
+		if code := imported[1].Snippet.Code(); code != want {
+			t.Errorf("expected code %q, got %q", want, code)
+		}
+	})
+
+	t.Run("falls back to the command as title", func(t *testing.T) {
+		if title := imported[2].Snippet.Title(); title != "git log --oneline -n <count=10>" {
+			t.Errorf("unexpected title %q", title)
+		}
+		if len(imported[2].Tags) != 0 {
+			t.Errorf("expected no tags, got %v", imported[2].Tags)
+		}
+	})
+
+	t.Run("rejects entries without a command", func(t *testing.T) {
+		if _, err := ParsePet("snippet.toml", []byte("[[snippets]]\ndescription = \"x\"\n")); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("reports malformed TOML", func(t *testing.T) {
+		if _, err := ParsePet("snippet.toml", []byte("[[snippets]]\ncommand = \"unterminated\n")); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestWritePet(t *testing.T) {
+	data, err := WritePet(testLibrary(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `command = "SELECT * FROM <table=users>; -- all"`) {
+		t.Errorf("expected parameters with defaults:\n%s", data)
+	}
+
+	imported, err := ParsePet("snippet.toml", data)
+	if err != nil {
+		t.Fatalf("failed to read back: %v", err)
+	}
+	if len(imported) != 3 {
+		t.Fatalf("expected 3 snippets, got %d", len(imported))
+	}
+	query := imported[0]
+	if query.Snippet.Title() != "Query <rows>" || query.Snippet.Code() != "SELECT * FROM {{table:users}}; -- all" {
+		t.Errorf("unexpected snippet: %s %q", query.Snippet, query.Snippet.Code())
+	}
+	if !slices.Equal(query.Tags, []string{"web"}) {
+		t.Errorf("unexpected tags %v", query.Tags)
+	}
+	if code := imported[2].Snippet.Code(); code != "echo \"```\" $# # done" {
+		t.Errorf("expected code to survive escaping, got %q", code)
+	}
+}
@@ -0,0 +1,131 @@
+package exchange
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// regionComment matches the comment leaders a region marker may follow on
+// its line: //, #, --, ;, %, /*, <!--, {-, (* and REM.
+const regionComment = `^\s*(?://+|#+|--+|;+|%+|/\*+|<!--|\{-|\(\*|REM\s)?\s*`
+
+var (
+	// regionBegin matches a "snip:begin <name> [title]" marker line, such as
+	// "// snip:begin retry-backoff Retry with backoff".
+	regionBegin = regexp.MustCompile(regionComment + `snip:begin\s+([A-Za-z0-9][A-Za-z0-9._-]*)(.*)$`)
+
+	// regionEnd matches a "snip:end [name]" marker line.
+	regionEnd = regexp.MustCompile(regionComment + `snip:end\b(?:\s+([A-Za-z0-9][A-Za-z0-9._-]*))?`)
+
+	// regionSource matches the source of a harvested snippet, capturing the
+	// file, the line range and the region name.
+	regionSource = regexp.MustCompile(`^(.+):([0-9]+)-([0-9]+)#([A-Za-z0-9][A-Za-z0-9._-]*)$`)
+)
+
+// Region is a marked region of a source file.
+type Region struct {
+	Name    string
+	Start   int // First non-blank line after the begin marker
+	End     int // Last non-blank line before the end marker
+	Snippet *domain.Snippet
+}
+
+// ParseRegions reads the regions between "snip:begin <name>" and
+// "snip:end" markers in a source file. A marker starts its line, after an
+// optional comment leader such as // or #. Text after the name on the begin
+// line becomes the title, which defaults to the name. Regions may nest, and
+// marker lines are left out of the code. The language is detected from the
+// file name and the code, common indentation is removed, and the source is
+// "<file>:<start>-<end>#<name>".
+func ParseRegions(filename string, data []byte) ([]Region, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	type open struct {
+		name, title string
+		line        int
+		code        []string
+	}
+	var stack []*open
+	var regions []Region
+	seen := make(map[string]int)
+
+	for i, line := range lines {
+		number := i + 1
+		if m := regionBegin.FindStringSubmatch(line); m != nil {
+			if first, ok := seen[m[1]]; ok {
+				return nil, fmt.Errorf("line %d: region %q already begins on line %d", number, m[1], first)
+			}
+			seen[m[1]] = number
+			stack = append(stack, &open{name: m[1], title: regionTitle(m[2]), line: number})
+			continue
+		}
+		if m := regionEnd.FindStringSubmatch(line); m != nil {
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: snip:end without snip:begin", number)
+			}
+			region := stack[len(stack)-1]
+			if m[1] != "" && m[1] != region.name {
+				return nil, fmt.Errorf("line %d: snip:end %s closes region %q", number, m[1], region.name)
+			}
+			stack = stack[:len(stack)-1]
+
+			start, end := region.line+1, number-1
+			for start <= end && strings.TrimSpace(lines[start-1]) == "" {
+				start++
+			}
+			for end >= start && strings.TrimSpace(lines[end-1]) == "" {
+				end--
+			}
+			if start > end {
+				return nil, fmt.Errorf("line %d: region %q is empty", region.line, region.name)
+			}
+			code := strings.Trim(domain.Dedent(strings.Join(region.code, "\n")), "\n")
+			title := region.title
+			if title == "" {
+				title = region.name
+			}
+			language := domain.DetectLanguage(filename, code).Language
+			if language == "" {
+				language = "text"
+			}
+			snippet, err := domain.NewSnippet(title, language, code)
+			if err != nil {
+				return nil, fmt.Errorf("region %q: %w", region.name, err)
+			}
+			snippet.SetSource(fmt.Sprintf("%s:%d-%d#%s", filename, start, end, region.name))
+			regions = append(regions, Region{Name: region.name, Start: start, End: end, Snippet: snippet})
+			continue
+		}
+		for _, region := range stack {
+			region.code = append(region.code, line)
+		}
+	}
+	if len(stack) > 0 {
+		region := stack[len(stack)-1]
+		return nil, fmt.Errorf("line %d: region %q has no snip:end", region.line, region.name)
+	}
+	return regions, nil
+}
+
+// regionTitle returns the title after a region name, without the closing
+// delimiters of block comments.
+func regionTitle(rest string) string {
+	rest = strings.TrimSpace(rest)
+	for _, closer := range []string{"-->", "*/", "*)", "-}", "#}}", "%}"} {
+		rest = strings.TrimSpace(strings.TrimSuffix(rest, closer))
+	}
+	return rest
+}
+
+// RegionSource splits the source of a harvested snippet into its file and
+// region name. It reports false for other sources.
+func RegionSource(source string) (file, name string, ok bool) {
+	m := regionSource.FindStringSubmatch(source)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[4], true
+}
@@ -0,0 +1,90 @@
+package exchange
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseRegions(t *testing.T) {
+	data, err := os.ReadFile("testdata/retry.go")
+	if err != nil {
+		t.Fatalf("failed to read sample: %v", err)
+	}
+	regions, err := ParseRegions("testdata/retry.go", data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(regions) != 2 {
+		t.Fatalf("expected 2 regions, got %d", len(regions))
+	}
+
+	t.Run("reads nested regions in closing order", func(t *testing.T) {
+		sleep := regions[0]
+		if sleep.Name != "backoff-sleep" || sleep.Start != 11 || sleep.End != 12 {
+			t.Errorf("unexpected region: %s %d-%d", sleep.Name, sleep.Start, sleep.End)
+		}
+		if code := sleep.Snippet.Code(); code != "time.Sleep(delay)\ndelay *= 2" {
+			t.Errorf("expected dedented code, got %q", code)
+		}
+		if sleep.Snippet.Title() != "backoff-sleep" || sleep.Snippet.Language() != "go" {
+			t.Errorf("unexpected snippet: %s", sleep.Snippet)
+		}
+	})
+
+	t.Run("leaves marker lines out and records the source", func(t *testing.T) {
+		retry := regions[1]
+		if retry.Snippet.Title() != "Retry with exponential backoff" {
+			t.Errorf("unexpected title %q", retry.Snippet.Title())
+		}
+		code := retry.Snippet.Code()
+		if code[:len("func Retry")] != "func Retry" || code[len(code)-1] != '}' {
+			t.Errorf("unexpected code %q", code)
+		}
+		if source := retry.Snippet.Source(); source != "testdata/retry.go:6-19#retry-backoff" {
+			t.Errorf("unexpected source %q", source)
+		}
+		file, name, ok := RegionSource(retry.Snippet.Source())
+		if !ok || file != "testdata/retry.go" || name != "retry-backoff" {
+			t.Errorf("unexpected split: %q %q %v", file, name, ok)
+		}
+	})
+
+	t.Run("reads other comment styles", func(t *testing.T) {
+		data := "<!-- snip:begin card Card markup -->\n<div class=\"card\"></div>\n<!-- snip:end -->\n# snip:begin ls\nls -la\n# snip:end ls\n"
+		regions, err := ParseRegions("page.html", []byte(data))
+		if err != nil || len(regions) != 2 {
+			t.Fatalf("expected 2 regions, got %d: %v", len(regions), err)
+		}
+		if regions[0].Snippet.Title() != "Card markup" || regions[1].Snippet.Code() != "ls -la" {
+			t.Errorf("unexpected regions: %s, %q", regions[0].Snippet, regions[1].Snippet.Code())
+		}
+	})
+
+	t.Run("rejects malformed markers", func(t *testing.T) {
+		for name, data := range map[string]string{
+			"unclosed":  "// snip:begin a\nx\n",
+			"unopened":  "x\n// snip:end\n",
+			"mismatch":  "// snip:begin a\nx\n// snip:end b\n",
+			"duplicate": "// snip:begin a\nx\n// snip:end\n// snip:begin a\ny\n// snip:end\n",
+			"empty":     "// snip:begin a\n\n// snip:end\n",
+		} {
+			if _, err := ParseRegions("x.go", []byte(data)); err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+	})
+
+	t.Run("ignores files without markers", func(t *testing.T) {
+		if regions, err := ParseRegions("x.go", []byte("// snip:endpoint\nfmt.Println(\"snip:begin later\")\n")); err != nil || len(regions) != 0 {
+			t.Errorf("expected no regions, got %d: %v", len(regions), err)
+		}
+	})
+}
+
+func TestRegionSource(t *testing.T) {
+	for _, source := range []string{"", "docs/setup.md#Install", "/src/main.go:40-72", "history"} {
+		if _, _, ok := RegionSource(source); ok {
+			t.Errorf("%q: expected not a region source", source)
+		}
+	}
+}
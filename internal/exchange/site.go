@@ -0,0 +1,295 @@
+package exchange
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// siteAssets holds the page templates, style sheet and search script.
+//
+//go:embed site
+var siteAssets embed.FS
+
+// siteTemplates are parsed once from siteAssets.
+var siteTemplates = template.Must(template.ParseFS(siteAssets, "site/*.html"))
+
+// siteLink is a link to a page, with the number of snippets it lists.
+type siteLink struct {
+	Name  string
+	URL   string
+	Count int
+}
+
+// siteSnippet is a snippet prepared for the templates. URLs are relative
+// to the site root.
+type siteSnippet struct {
+	ID          int
+	Title       string
+	Language    string
+	Description string
+	Source      string
+	Aliases     []string
+	Code        template.HTML
+	Category    *siteLink
+	Tags        []siteLink
+	URL         string
+}
+
+// sitePage is the data of one page. Root is the relative path from the
+// page to the site root, such as "../".
+type sitePage struct {
+	Root       string
+	Title      string
+	Crumbs     []siteLink
+	Children   []siteLink
+	Categories []siteLink
+	Tags       []siteLink
+	Snippets   []*siteSnippet
+	Snippet    *siteSnippet
+}
+
+// searchEntry is one snippet in the client-side search index.
+type searchEntry struct {
+	Title       string   `json:"title"`
+	URL         string   `json:"url"`
+	Language    string   `json:"language"`
+	Category    string   `json:"category,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Code        string   `json:"code"`
+}
+
+// siteIndex groups a library by category and tag for the site and cheat
+// sheet writers. Snippets are ordered by title.
+type siteIndex struct {
+	tree       *domain.CategoryTree
+	snippets   []*domain.Snippet
+	categories []*domain.Category        // Categories with snippets in their subtree, in tree order
+	byCategory map[int][]*domain.Snippet // Snippets directly in each category; 0 is uncategorized
+	tags       []*domain.Tag             // Tags with snippets, ordered by name
+	byTag      map[int][]*domain.Snippet
+}
+
+// newSiteIndex builds the index of a library. Snippets referring to
+// missing categories are treated as uncategorized, and missing tags are
+// ignored.
+func newSiteIndex(library Library) *siteIndex {
+	idx := &siteIndex{
+		tree:       domain.NewCategoryTree(library.Categories),
+		snippets:   slices.Clone(library.Snippets),
+		byCategory: make(map[int][]*domain.Snippet),
+		byTag:      make(map[int][]*domain.Snippet),
+	}
+	slices.SortStableFunc(idx.snippets, func(a, b *domain.Snippet) int {
+		return strings.Compare(strings.ToLower(a.Title()), strings.ToLower(b.Title()))
+	})
+
+	for _, snippet := range idx.snippets {
+		categoryID := snippet.CategoryID()
+		if idx.tree.Path(categoryID) == "" {
+			categoryID = 0
+		}
+		idx.byCategory[categoryID] = append(idx.byCategory[categoryID], snippet)
+		for _, tagID := range snippet.Tags() {
+			idx.byTag[tagID] = append(idx.byTag[tagID], snippet)
+		}
+	}
+
+	var walk func(categories []*domain.Category)
+	walk = func(categories []*domain.Category) {
+		for _, category := range categories {
+			if idx.subtreeCount(category.ID()) > 0 {
+				idx.categories = append(idx.categories, category)
+				walk(idx.tree.Children(category.ID()))
+			}
+		}
+	}
+	walk(idx.tree.Roots())
+
+	for _, tag := range library.Tags {
+		if len(idx.byTag[tag.ID()]) > 0 {
+			idx.tags = append(idx.tags, tag)
+		}
+	}
+	slices.SortFunc(idx.tags, func(a, b *domain.Tag) int {
+		return strings.Compare(strings.ToLower(a.Name()), strings.ToLower(b.Name()))
+	})
+	return idx
+}
+
+// subtreeCount returns the number of snippets in a category and its
+// subcategories.
+func (idx *siteIndex) subtreeCount(id int) int {
+	count := len(idx.byCategory[id])
+	for _, descendant := range idx.tree.Descendants(id) {
+		count += len(idx.byCategory[descendant])
+	}
+	return count
+}
+
+// categoryFile returns the file name of a category's page or cheat sheet,
+// without extension.
+func (idx *siteIndex) categoryFile(id int) string {
+	if id == 0 {
+		return "uncategorized"
+	}
+	return fmt.Sprintf("%d-%s", id, strings.ToLower(fileName(idx.tree.Path(id), "category")))
+}
+
+// WriteSite renders a library as a static HTML site: an index page with a
+// client-side search, a page per snippet with highlighted code, and index
+// pages per category and tag. The search works from the file system, as
+// the index is loaded as a script.
+func WriteSite(library Library) (Files, error) {
+	idx := newSiteIndex(library)
+
+	tagNames := make(map[int]string, len(idx.tags))
+	tagLinks := make(map[int]siteLink, len(idx.tags))
+	var allTags []siteLink
+	for _, tag := range idx.tags {
+		link := siteLink{
+			Name:  tag.Name(),
+			URL:   fmt.Sprintf("tags/%d-%s.html", tag.ID(), strings.ToLower(fileName(tag.Name(), "tag"))),
+			Count: len(idx.byTag[tag.ID()]),
+		}
+		tagNames[tag.ID()] = tag.Name()
+		tagLinks[tag.ID()] = link
+		allTags = append(allTags, link)
+	}
+
+	categoryLink := func(id int) siteLink {
+		name := idx.tree.Path(id)
+		if id == 0 {
+			name = "Uncategorized"
+		}
+		return siteLink{Name: name, URL: "categories/" + idx.categoryFile(id) + ".html", Count: idx.subtreeCount(id)}
+	}
+	var allCategories []siteLink
+	for _, category := range idx.categories {
+		allCategories = append(allCategories, categoryLink(category.ID()))
+	}
+	if len(idx.byCategory[0]) > 0 {
+		allCategories = append(allCategories, categoryLink(0))
+	}
+
+	prepared := make(map[int]*siteSnippet, len(idx.snippets))
+	prepare := func(snippets []*domain.Snippet) []*siteSnippet {
+		result := make([]*siteSnippet, len(snippets))
+		for i, snippet := range snippets {
+			result[i] = prepared[snippet.ID()]
+		}
+		return result
+	}
+
+	var search []searchEntry
+	for _, snippet := range idx.snippets {
+		s := &siteSnippet{
+			ID:          snippet.ID(),
+			Title:       snippet.Title(),
+			Language:    snippet.Language(),
+			Description: snippet.Description(),
+			Source:      snippet.Source(),
+			Aliases:     snippet.Aliases(),
+			Code:        template.HTML(highlightHTML(snippet.Code(), snippet.Language())),
+			URL:         fmt.Sprintf("snippets/%d.html", snippet.ID()),
+		}
+		entry := searchEntry{
+			Title:       s.Title,
+			URL:         s.URL,
+			Language:    s.Language,
+			Aliases:     s.Aliases,
+			Description: s.Description,
+			Code:        snippet.Code(),
+		}
+		if path := idx.tree.Path(snippet.CategoryID()); path != "" {
+			link := categoryLink(snippet.CategoryID())
+			s.Category = &link
+			entry.Category = path
+		}
+		for _, tagID := range snippet.Tags() {
+			if link, ok := tagLinks[tagID]; ok {
+				s.Tags = append(s.Tags, link)
+				entry.Tags = append(entry.Tags, tagNames[tagID])
+			}
+		}
+		prepared[snippet.ID()] = s
+		search = append(search, entry)
+	}
+
+	files := make(Files)
+	render := func(name, tmpl string, page sitePage) error {
+		page.Root = strings.Repeat("../", strings.Count(name, "/"))
+		var b bytes.Buffer
+		if err := siteTemplates.ExecuteTemplate(&b, tmpl, page); err != nil {
+			return fmt.Errorf("render %s: %w", name, err)
+		}
+		files[name] = b.Bytes()
+		return nil
+	}
+
+	if err := render("index.html", "index.html", sitePage{
+		Title:      "Snippets",
+		Categories: allCategories,
+		Tags:       allTags,
+		Snippets:   prepare(idx.snippets),
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, s := range prepared {
+		if err := render(s.URL, "snippet.html", sitePage{Title: s.Title, Snippet: s}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, category := range idx.categories {
+		link := categoryLink(category.ID())
+		page := sitePage{Title: category.Name(), Snippets: prepare(idx.byCategory[category.ID()])}
+		for _, ancestor := range idx.tree.Ancestors(category.ID()) {
+			page.Crumbs = append(page.Crumbs, categoryLink(ancestor.ID()))
+		}
+		for _, child := range idx.tree.Children(category.ID()) {
+			if idx.subtreeCount(child.ID()) > 0 {
+				page.Children = append(page.Children, categoryLink(child.ID()))
+			}
+		}
+		if err := render(link.URL, "list.html", page); err != nil {
+			return nil, err
+		}
+	}
+	if uncategorized := idx.byCategory[0]; len(uncategorized) > 0 {
+		link := categoryLink(0)
+		if err := render(link.URL, "list.html", sitePage{Title: link.Name, Snippets: prepare(uncategorized)}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, tag := range idx.tags {
+		link := tagLinks[tag.ID()]
+		if err := render(link.URL, "list.html", sitePage{Title: "#" + tag.Name(), Snippets: prepare(idx.byTag[tag.ID()])}); err != nil {
+			return nil, err
+		}
+	}
+
+	index, err := json.Marshal(search)
+	if err != nil {
+		return nil, err
+	}
+	files["search-index.js"] = []byte("window.SNIP_SEARCH_INDEX = " + string(index) + ";\n")
+	for _, asset := range []string{"style.css", "search.js"} {
+		data, err := siteAssets.ReadFile("site/" + asset)
+		if err != nil {
+			return nil, err
+		}
+		files[asset] = data
+	}
+	return files, nil
+}
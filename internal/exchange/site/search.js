@@ -0,0 +1,65 @@
+// Client-side search over window.SNIP_SEARCH_INDEX. Every word of the query
+// must appear in a snippet's title, language, category, tags, aliases,
+// description or code. Title matches are listed first.
+(function () {
+  var index = window.SNIP_SEARCH_INDEX || [];
+  var input = document.getElementById("search");
+  var results = document.getElementById("results");
+  var browse = document.getElementById("browse");
+  if (!input) return;
+
+  var haystacks = index.map(function (s) {
+    return [s.title, s.language, s.category, (s.tags || []).join(" "),
+      (s.aliases || []).join(" "), s.description, s.code].join("\n").toLowerCase();
+  });
+
+  function render(query) {
+    var words = query.toLowerCase().split(/\s+/).filter(Boolean);
+    results.innerHTML = "";
+    results.hidden = words.length === 0;
+    browse.hidden = words.length > 0;
+    if (words.length === 0) return;
+
+    var matches = [];
+    index.forEach(function (s, i) {
+      if (words.every(function (w) { return haystacks[i].indexOf(w) >= 0; })) {
+        var inTitle = words.every(function (w) { return s.title.toLowerCase().indexOf(w) >= 0; });
+        matches.push({ snippet: s, rank: inTitle ? 0 : 1 });
+      }
+    });
+    matches.sort(function (a, b) { return a.rank - b.rank; });
+
+    if (matches.length === 0) {
+      var empty = document.createElement("li");
+      empty.className = "card";
+      empty.textContent = "No snippets found";
+      results.appendChild(empty);
+      return;
+    }
+
+    matches.slice(0, 100).forEach(function (m) {
+      var item = document.createElement("li");
+      item.className = "card";
+      var link = document.createElement("a");
+      link.className = "title";
+      link.href = m.snippet.url;
+      link.textContent = m.snippet.title;
+      var badge = document.createElement("span");
+      badge.className = "badge";
+      badge.textContent = m.snippet.language;
+      item.appendChild(link);
+      item.appendChild(document.createTextNode(" "));
+      item.appendChild(badge);
+      if (m.snippet.description) {
+        var description = document.createElement("p");
+        description.className = "description";
+        description.textContent = m.snippet.description;
+        item.appendChild(description);
+      }
+      results.appendChild(item);
+    });
+  }
+
+  input.addEventListener("input", function () { render(input.value); });
+  render(input.value);
+})();
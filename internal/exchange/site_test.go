@@ -0,0 +1,117 @@
+package exchange
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// testLibrary returns a library with a nested category, an empty category,
+// an unused tag and an uncategorized snippet.
+func testLibrary(t *testing.T) Library {
+	t.Helper()
+	newCategory := func(id, parentID int, name string) *domain.Category {
+		category, _ := domain.NewCategory(name)
+		category.SetID(id)
+		category.SetParent(parentID)
+		return category
+	}
+	newTag := func(id int, name string) *domain.Tag {
+		tag, _ := domain.NewTag(name)
+		tag.SetID(id)
+		return tag
+	}
+	newSnippet := func(id int, title, language, code string, categoryID int, tagIDs ...int) *domain.Snippet {
+		snippet, err := domain.NewSnippet(title, language, code)
+		if err != nil {
+			t.Fatalf("failed to create snippet: %v", err)
+		}
+		snippet.SetID(id)
+		snippet.SetCategory(categoryID)
+		for _, tagID := range tagIDs {
+			snippet.AddTag(tagID)
+		}
+		return snippet
+	}
+
+	query := newSnippet(1, "Query <rows>", "sql", "SELECT * FROM {{table:users}}; -- all", 2, 1)
+	query.SetDescription("List every row")
+	query.AddAlias("sel")
+	serve := newSnippet(2, "Serve files", "go", "http.ListenAndServe(\":8080\", nil) // serve", 1, 1)
+	loose := newSnippet(3, "Loose", "bash", "echo \"```\" $# # done", 0)
+
+	return Library{
+		Snippets:   []*domain.Snippet{query, serve, loose},
+		Categories: []*domain.Category{newCategory(1, 0, "backend"), newCategory(2, 1, "db"), newCategory(3, 0, "empty")},
+		Tags:       []*domain.Tag{newTag(1, "web"), newTag(2, "unused")},
+	}
+}
+
+func TestWriteSite(t *testing.T) {
+	files, err := WriteSite(testLibrary(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("writes index, snippet, category and tag pages", func(t *testing.T) {
+		for _, name := range []string{
+			"index.html", "style.css", "search.js", "search-index.js",
+			"snippets/1.html", "snippets/2.html", "snippets/3.html",
+			"categories/1-backend.html", "categories/2-backend-db.html", "categories/uncategorized.html",
+			"tags/1-web.html",
+		} {
+			if files[name] == nil {
+				t.Errorf("expected %s", name)
+			}
+		}
+		if len(files) != 11 {
+			t.Errorf("expected no pages for empty categories and unused tags, got %d files", len(files))
+		}
+	})
+
+	t.Run("links pages relative to the root", func(t *testing.T) {
+		page := string(files["categories/2-backend-db.html"])
+		for _, want := range []string{`href="../style.css"`, `href="../categories/1-backend.html"`, `href="../snippets/1.html"`} {
+			if !strings.Contains(page, want) {
+				t.Errorf("expected category page to contain %s:\n%s", want, page)
+			}
+		}
+		if index := string(files["index.html"]); !strings.Contains(index, `href="categories/2-backend-db.html">backend/db</a>`) {
+			t.Errorf("expected index to link categories by path:\n%s", index)
+		}
+	})
+
+	t.Run("escapes and highlights code", func(t *testing.T) {
+		page := string(files["snippets/1.html"])
+		for _, want := range []string{
+			"Query &lt;rows&gt;",
+			`<span class="k">SELECT</span>`,
+			`<span class="p">{{table:users}}</span>`,
+			`<span class="c">-- all</span>`,
+			`href="../tags/1-web.html">#web</a>`,
+			"<code>sel</code>",
+		} {
+			if !strings.Contains(page, want) {
+				t.Errorf("expected snippet page to contain %s:\n%s", want, page)
+			}
+		}
+	})
+
+	t.Run("writes a search index script", func(t *testing.T) {
+		index := string(files["search-index.js"])
+		if !strings.HasPrefix(index, "window.SNIP_SEARCH_INDEX = [") ||
+			!strings.Contains(index, `"category":"backend/db"`) || !strings.Contains(index, `"tags":["web"]`) {
+			t.Errorf("unexpected search index:\n%s", index)
+		}
+	})
+}
+
+func TestHighlightHTML(t *testing.T) {
+	got := highlightHTML("if [ $# -gt 1 ]; then echo \"a<b\" # note\nfi", "sh")
+	want := `<span class="k">if</span> [ $# -gt <span class="n">1</span> ]; <span class="k">then</span> echo ` +
+		`<span class="s">&#34;a&lt;b&#34;</span> <span class="c"># note</span>` + "\n" + `<span class="k">fi</span>`
+	if got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
@@ -0,0 +1,23 @@
+package retry
+
+import "time"
+
+// snip:begin retry-backoff Retry with exponential backoff
+func Retry(attempts int, fn func() error) error {
+	delay := 100 * time.Millisecond
+	var err error
+	for i := 0; i < attempts; i++ {
+		// snip:begin backoff-sleep
+		time.Sleep(delay)
+		delay *= 2
+		// snip:end backoff-sleep
+		if err = fn(); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// snip:end
+
+var doc = "a string mentioning // snip:begin not-a-region"
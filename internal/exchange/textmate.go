@@ -0,0 +1,268 @@
+package exchange
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// TextMate snippet syntax is shared by VS Code, UltiSnips and yasnippet:
+// $1 and ${1:default} are tab stops, $0 is the final cursor position,
+// ${1|a,b|} is a choice, and a backslash escapes special characters. The
+// tools differ in details, described by a textMateDialect.
+
+// textMateDialect describes one tool's variant of the TextMate syntax.
+type textMateDialect struct {
+	escapes   string   // Characters a backslash escapes
+	variables bool     // $NAME and ${NAME:default} are variables
+	names     []string // Variables supported even if variables is false
+	// interpolation names the placeholder that replaces `code` embedded in
+	// backquotes; nil if backquotes are ordinary text.
+	interpolation func(code string) string
+	// mirrorDefaults drops defaults starting with '$', which are transforms
+	// such as yasnippet's ${1:$(upcase yas-text)}.
+	mirrorDefaults bool
+}
+
+// vscodeDialect is the VS Code snippet syntax.
+var vscodeDialect = textMateDialect{escapes: `$}\`, variables: true}
+
+// fromTextMate converts a TextMate snippet body to snip code. Tab stops and
+// variables become placeholders; choices keep their first option as the
+// default, and transforms are dropped.
+func fromTextMate(body string, dialect textMateDialect) string {
+	p := &textMateParser{src: []rune(body), dialect: dialect}
+	code, _ := p.parse(false)
+	return code
+}
+
+// textMateParser is a recursive descent parser for TextMate snippet bodies.
+type textMateParser struct {
+	src     []rune
+	pos     int
+	dialect textMateDialect
+}
+
+// parse reads text up to the end or, if nested, up to an unescaped closing
+// brace. It returns the text in snip syntax and as plain text, with nested
+// placeholders replaced by their defaults.
+func (p *textMateParser) parse(nested bool) (code, plain string) {
+	var c, t strings.Builder
+	for p.pos < len(p.src) {
+		r := p.src[p.pos]
+		switch {
+		case r == '\\' && p.pos+1 < len(p.src) && strings.ContainsRune(p.dialect.escapes, p.src[p.pos+1]):
+			c.WriteRune(p.src[p.pos+1])
+			t.WriteRune(p.src[p.pos+1])
+			p.pos += 2
+		case r == '}' && nested:
+			return c.String(), t.String()
+		case r == '`' && p.dialect.interpolation != nil:
+			end := p.pos + 1
+			for end < len(p.src) && p.src[end] != '`' {
+				if p.src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			name := p.dialect.interpolation(string(p.src[p.pos+1 : min(end, len(p.src))]))
+			c.WriteString(domain.PlaceholderText(domain.Placeholder{Name: name}))
+			p.pos = end + 1
+		case r == '$':
+			placeholder, ok := p.placeholder()
+			if !ok {
+				c.WriteRune(r)
+				t.WriteRune(r)
+				p.pos++
+				continue
+			}
+			c.WriteString(domain.PlaceholderText(placeholder))
+			t.WriteString(placeholder.Default)
+		default:
+			c.WriteRune(r)
+			t.WriteRune(r)
+			p.pos++
+		}
+	}
+	return c.String(), t.String()
+}
+
+// placeholder parses a tab stop or variable at a '$'. It reports false,
+// without moving, if the '$' starts none.
+func (p *textMateParser) placeholder() (domain.Placeholder, bool) {
+	start := p.pos
+	p.pos++ // $
+
+	if name := p.name(); name != "" && p.supports(name) {
+		return domain.Placeholder{Name: name}, true
+	}
+	if p.pos >= len(p.src) || p.src[p.pos] != '{' {
+		p.pos = start
+		return domain.Placeholder{}, false
+	}
+	p.pos++ // {
+
+	name := p.name()
+	if name == "" || !p.supports(name) || p.pos >= len(p.src) {
+		p.pos = start
+		return domain.Placeholder{}, false
+	}
+
+	placeholder := domain.Placeholder{Name: name}
+	switch p.src[p.pos] {
+	case '}':
+		p.pos++
+	case ':':
+		p.pos++
+		_, placeholder.Default = p.parse(true)
+		p.pos++ // }
+	case '|':
+		p.pos++
+		placeholder.Default = p.choice()
+	case '/':
+		p.skipTransform()
+	default:
+		p.pos = start
+		return domain.Placeholder{}, false
+	}
+
+	// Defaults that cannot be written in snip syntax are dropped.
+	if strings.ContainsAny(placeholder.Default, "{}\n") ||
+		(p.dialect.mirrorDefaults && strings.HasPrefix(placeholder.Default, "$")) {
+		placeholder.Default = ""
+	}
+	return placeholder, true
+}
+
+// supports reports whether name is a tab stop or a variable of the dialect.
+func (p *textMateParser) supports(name string) bool {
+	if name[0] >= '0' && name[0] <= '9' {
+		return true
+	}
+	return p.dialect.variables || slices.Contains(p.dialect.names, name)
+}
+
+// name reads a tab stop number or variable name.
+func (p *textMateParser) name() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		r := p.src[p.pos]
+		isDigit := r >= '0' && r <= '9'
+		isWord := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if isDigit && p.pos == start {
+			for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+				p.pos++
+			}
+			return string(p.src[start:p.pos])
+		}
+		if !isWord && !(isDigit && p.pos > start) {
+			break
+		}
+		p.pos++
+	}
+	return string(p.src[start:p.pos])
+}
+
+// choice reads the options of ${1|a,b|} and returns the first one.
+func (p *textMateParser) choice() string {
+	var first strings.Builder
+	done := false
+	for p.pos < len(p.src) {
+		r := p.src[p.pos]
+		switch {
+		case r == '\\' && p.pos+1 < len(p.src):
+			if !done {
+				first.WriteRune(p.src[p.pos+1])
+			}
+			p.pos += 2
+			continue
+		case r == '|' && p.pos+1 < len(p.src) && p.src[p.pos+1] == '}':
+			p.pos += 2
+			return first.String()
+		case r == ',':
+			done = true
+		default:
+			if !done {
+				first.WriteRune(r)
+			}
+		}
+		p.pos++
+	}
+	return first.String()
+}
+
+// skipTransform skips a /regex/format/options} transform.
+func (p *textMateParser) skipTransform() {
+	depth := 0
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos++
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				p.pos++
+				return
+			}
+			depth--
+		}
+		p.pos++
+	}
+}
+
+// toTextMate converts snip code to a TextMate snippet body. Numbered
+// placeholders keep their numbers and the dialect's variables keep their
+// names; other named placeholders get the next free numbers, with the name
+// as default when there is none.
+func toTextMate(code string, dialect textMateDialect) string {
+	numbers := tabStopNumbers(code, dialect)
+	special := []string{`\`, `\\`, `$`, `\$`}
+	if dialect.interpolation != nil {
+		special = append(special, "`", "\\`")
+	}
+	escape := strings.NewReplacer(special...)
+	escapeDefault := strings.NewReplacer(append(special, `}`, `\}`)...)
+
+	return domain.ReplacePlaceholders(code, func(ph domain.Placeholder) string {
+		number, ok := numbers[ph.Name]
+		if !ok {
+			number = ph.Name
+		}
+		if ph.Default == "" && (ph.Numbered() || !ok) {
+			return "${" + number + "}"
+		}
+		value := ph.Default
+		if value == "" {
+			value = ph.Name
+		}
+		return "${" + number + ":" + escapeDefault.Replace(value) + "}"
+	}, escape.Replace)
+}
+
+// tabStopNumbers assigns a tab stop number to every placeholder name in
+// code that is not a variable of the dialect.
+func tabStopNumbers(code string, dialect textMateDialect) map[string]string {
+	placeholders := domain.Placeholders(code)
+	numbers := make(map[string]string, len(placeholders))
+
+	next := 1
+	for _, ph := range placeholders {
+		if n, err := strconv.Atoi(ph.Name); err == nil {
+			numbers[ph.Name] = strconv.Itoa(n)
+			next = max(next, n+1)
+		}
+	}
+	for _, ph := range placeholders {
+		if slices.Contains(dialect.names, ph.Name) {
+			continue
+		}
+		if _, ok := numbers[ph.Name]; !ok {
+			numbers[ph.Name] = strconv.Itoa(next)
+			next++
+		}
+	}
+	return numbers
+}
@@ -0,0 +1,45 @@
+package exchange
+
+import "testing"
+
+func TestFromTextMate(t *testing.T) {
+	t.Run("converts tab stops and variables", func(t *testing.T) {
+		got := fromTextMate("for ${1:i} := 0; $1 < ${2:n}; $1++ {\n\t$0\n} // $TM_FILENAME ${CLIPBOARD:text}", vscodeDialect)
+		want := "for {{1:i}} := 0; {{1}} < {{2:n}}; {{1}}++ {\n\t{{0}}\n} // {{TM_FILENAME}} {{CLIPBOARD:text}}"
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("unescapes literals", func(t *testing.T) {
+		got := fromTextMate(`echo \$HOME \} \\ $ 5$`, vscodeDialect)
+		if want := `echo $HOME } \ $ 5$`; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("flattens nested placeholders and choices", func(t *testing.T) {
+		got := fromTextMate("${1:Hello ${2:World}} ${3|info,warn|} ${TM_FILENAME/(.*)/${1:/upcase}/}", vscodeDialect)
+		want := "{{1:Hello World}} {{3:info}} {{TM_FILENAME}}"
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+}
+
+func TestToTextMate(t *testing.T) {
+	t.Run("escapes literals and numbers named placeholders", func(t *testing.T) {
+		got := toTextMate(`ssh {{user}}@{{host:local$}} -p {{1}} "$HOME\n" {{user}}{{0}}`, vscodeDialect)
+		want := `ssh ${2:user}@${3:local\$} -p ${1} "\$HOME\\n" ${2:user}${0}`
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("round-trips through TextMate syntax", func(t *testing.T) {
+		code := "if err := {{1:call}}(); err != nil {\n\treturn ${err}\n}\n{{0}}"
+		if got := fromTextMate(toTextMate(code, vscodeDialect), vscodeDialect); got != code {
+			t.Errorf("expected %q, got %q", code, got)
+		}
+	})
+}
@@ -0,0 +1,325 @@
+package exchange
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tomlTable is a TOML table read by readTOMLArray. Values are strings,
+// []any arrays, or the raw text of other scalars such as numbers.
+type tomlTable map[string]any
+
+// String returns the string value of key, or "" if it is missing or not a string.
+func (t tomlTable) String(key string) string {
+	s, _ := t[key].(string)
+	return s
+}
+
+// Strings returns the string elements of the array at key. A single string
+// is returned as a one-element list.
+func (t tomlTable) Strings(key string) []string {
+	switch v := t[key].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var list []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// readTOMLArray reads the tables of the array of tables called name, such
+// as the [[snippets]] entries of a pet file. It supports the TOML subset
+// these files use: comments, bare and quoted keys, the four string forms,
+// arrays and plain scalars. Inline tables and dotted keys are not supported.
+func readTOMLArray(data []byte, name string) ([]tomlTable, error) {
+	p := &tomlParser{src: string(data), line: 1}
+	var tables []tomlTable
+	var current tomlTable
+
+	for {
+		p.skipSpace(true)
+		if p.done() {
+			return tables, nil
+		}
+
+		switch {
+		case strings.HasPrefix(p.rest(), "[["):
+			end := strings.Index(p.rest(), "]]")
+			if end < 0 {
+				return nil, p.errorf("unterminated table header")
+			}
+			header := strings.TrimSpace(p.rest()[2:end])
+			p.advance(end + 2)
+			current = nil
+			if header == name {
+				current = make(tomlTable)
+				tables = append(tables, current)
+			}
+		case p.peek() == '[':
+			end := strings.IndexByte(p.rest(), ']')
+			if end < 0 {
+				return nil, p.errorf("unterminated table header")
+			}
+			p.advance(end + 1)
+			current = nil
+		default:
+			key, err := p.key()
+			if err != nil {
+				return nil, err
+			}
+			p.skipSpace(false)
+			if p.peek() != '=' {
+				return nil, p.errorf("expected '=' after key %q", key)
+			}
+			p.advance(1)
+			p.skipSpace(false)
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			if current != nil {
+				current[key] = value
+			}
+		}
+
+		p.skipSpace(false)
+		if !p.done() && p.peek() != '\n' && p.peek() != '\r' {
+			return nil, p.errorf("unexpected %q", p.peek())
+		}
+	}
+}
+
+// tomlParser reads TOML text.
+type tomlParser struct {
+	src  string
+	pos  int
+	line int
+}
+
+// done reports whether all input has been read.
+func (p *tomlParser) done() bool { return p.pos >= len(p.src) }
+
+// rest returns the unread input.
+func (p *tomlParser) rest() string { return p.src[p.pos:] }
+
+// peek returns the next byte, or 0 at the end.
+func (p *tomlParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+// advance moves n bytes forward, counting lines.
+func (p *tomlParser) advance(n int) {
+	p.line += strings.Count(p.src[p.pos:p.pos+n], "\n")
+	p.pos += n
+}
+
+// errorf returns an error prefixed with the current line.
+func (p *tomlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// skipSpace skips spaces, tabs and comments, and newlines if newlines is true.
+func (p *tomlParser) skipSpace(newlines bool) {
+	for !p.done() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t':
+			p.advance(1)
+		case c == '#':
+			end := strings.IndexByte(p.rest(), '\n')
+			if end < 0 {
+				end = len(p.rest())
+			}
+			p.advance(end)
+		case newlines && (c == '\n' || c == '\r'):
+			p.advance(1)
+		default:
+			return
+		}
+	}
+}
+
+// key reads a bare or quoted key.
+func (p *tomlParser) key() (string, error) {
+	if c := p.peek(); c == '"' || c == '\'' {
+		return p.str()
+	}
+	start := p.pos
+	for !p.done() {
+		c := p.peek()
+		if !(c == '_' || c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')) {
+			break
+		}
+		p.advance(1)
+	}
+	if p.pos == start {
+		return "", p.errorf("expected a key")
+	}
+	return p.src[start:p.pos], nil
+}
+
+// value reads a string, an array or a plain scalar.
+func (p *tomlParser) value() (any, error) {
+	switch p.peek() {
+	case '"', '\'':
+		return p.str()
+	case '[':
+		p.advance(1)
+		var list []any
+		for {
+			p.skipSpace(true)
+			if p.peek() == ']' {
+				p.advance(1)
+				return list, nil
+			}
+			item, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+			p.skipSpace(true)
+			switch p.peek() {
+			case ',':
+				p.advance(1)
+			case ']':
+			default:
+				return nil, p.errorf("expected ',' or ']' in array")
+			}
+		}
+	case '{':
+		return nil, p.errorf("inline tables are not supported")
+	}
+
+	start := p.pos
+	for !p.done() && !strings.ContainsRune(",]#\r\n", rune(p.peek())) {
+		p.advance(1)
+	}
+	raw := strings.TrimSpace(p.src[start:p.pos])
+	if raw == "" {
+		return nil, p.errorf("expected a value")
+	}
+	return raw, nil
+}
+
+// str reads any of the four TOML string forms.
+func (p *tomlParser) str() (string, error) {
+	quote := p.src[p.pos : p.pos+1]
+	multiline := strings.HasPrefix(p.rest(), strings.Repeat(quote, 3))
+	delimiter := quote
+	if multiline {
+		delimiter = strings.Repeat(quote, 3)
+	}
+	p.advance(len(delimiter))
+	if multiline && strings.HasPrefix(p.rest(), "\n") {
+		p.advance(1) // A newline right after the opening delimiter is trimmed.
+	} else if multiline && strings.HasPrefix(p.rest(), "\r\n") {
+		p.advance(2)
+	}
+
+	var b strings.Builder
+	for {
+		if p.done() {
+			return "", p.errorf("unterminated string")
+		}
+		if strings.HasPrefix(p.rest(), delimiter) {
+			p.advance(len(delimiter))
+			// Up to two quotes may directly precede the closing delimiter.
+			for i := 0; multiline && i < 2 && strings.HasPrefix(p.rest(), quote); i++ {
+				b.WriteString(quote)
+				p.advance(1)
+			}
+			return b.String(), nil
+		}
+
+		c := p.peek()
+		switch {
+		case c == '\n' && !multiline:
+			return "", p.errorf("newline in string")
+		case c == '\\' && quote == `"`:
+			if err := p.escape(&b, multiline); err != nil {
+				return "", err
+			}
+		default:
+			_, size := utf8.DecodeRuneInString(p.rest())
+			b.WriteString(p.rest()[:size])
+			p.advance(size)
+		}
+	}
+}
+
+// escape reads a backslash escape of a basic string.
+func (p *tomlParser) escape(b *strings.Builder, multiline bool) error {
+	p.advance(1) // backslash
+	if p.done() {
+		return p.errorf("unterminated escape")
+	}
+	c := p.peek()
+	simple := map[byte]string{'b': "\b", 't': "\t", 'n': "\n", 'f': "\f", 'r': "\r", '"': `"`, '\\': `\`, 'e': "\x1b"}
+	switch {
+	case simple[c] != "":
+		b.WriteString(simple[c])
+		p.advance(1)
+	case c == 'u' || c == 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		if len(p.rest()) < n+1 {
+			return p.errorf("short unicode escape")
+		}
+		code, err := strconv.ParseUint(p.rest()[1:n+1], 16, 32)
+		if err != nil {
+			return p.errorf("invalid unicode escape")
+		}
+		b.WriteRune(rune(code))
+		p.advance(n + 1)
+	case multiline && (c == ' ' || c == '\t' || c == '\n' || c == '\r'):
+		// A line-ending backslash trims the following whitespace.
+		rest := strings.TrimLeft(p.rest(), " \t")
+		if !strings.HasPrefix(rest, "\n") && !strings.HasPrefix(rest, "\r\n") {
+			return p.errorf("invalid escape")
+		}
+		p.advance(len(p.rest()) - len(strings.TrimLeft(p.rest(), " \t\r\n")))
+	default:
+		return p.errorf("invalid escape '\\%c'", c)
+	}
+	return nil
+}
+
+// tomlString formats s as a TOML basic string.
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
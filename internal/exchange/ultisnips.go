@@ -0,0 +1,196 @@
+package exchange
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// ultiSnipsFiletypes maps snip languages to Vim filetypes where they differ.
+var ultiSnipsFiletypes = map[string]string{
+	"bash":     "sh",
+	"csharp":   "cs",
+	"makefile": "make",
+	"text":     "all",
+}
+
+// ultiSnipsDialect is the UltiSnips snippet syntax. ${VISUAL} is the text
+// selected before expanding; backquotes hold shell, Python (!p) or
+// Vimscript (!v) interpolation.
+var ultiSnipsDialect = textMateDialect{
+	escapes: "$`{}\\",
+	names:   []string{"VISUAL"},
+	interpolation: func(code string) string {
+		switch {
+		case strings.HasPrefix(code, "!p"):
+			return "python"
+		case strings.HasPrefix(code, "!v"):
+			return "vim"
+		default:
+			return "shell"
+		}
+	},
+}
+
+// ultiSnipsTriggerDelimiters are tried in order to quote triggers that
+// contain whitespace.
+const ultiSnipsTriggerDelimiters = `!|/%^#`
+
+// UltiSnipsFiletype returns the Vim filetype for a snip language.
+func UltiSnipsFiletype(language string) string {
+	language = domain.CanonicalLanguage(language)
+	if filetype, ok := ultiSnipsFiletypes[language]; ok {
+		return filetype
+	}
+	return language
+}
+
+// LanguageFromUltiSnips returns the snip language for a Vim filetype.
+func LanguageFromUltiSnips(filetype string) string {
+	if filetype == "all" {
+		return "text"
+	}
+	return domain.CanonicalLanguage(filetype)
+}
+
+// ultiSnipsLanguage returns the language of an UltiSnips file named
+// ft.snippets, ft_name.snippets or ft/name.snippets.
+func ultiSnipsLanguage(filename string) string {
+	base := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	filetype, _, _ := strings.Cut(base, "_")
+	if _, ok := domain.LookupLanguage(filetype); ok || filetype == "all" {
+		return LanguageFromUltiSnips(filetype)
+	}
+	if dir := filepath.Base(filepath.Dir(filename)); dir != "." && dir != string(filepath.Separator) {
+		if _, ok := domain.LookupLanguage(dir); ok || dir == "all" {
+			return LanguageFromUltiSnips(dir)
+		}
+	}
+	return LanguageFromUltiSnips(filetype)
+}
+
+// ParseUltiSnips reads an UltiSnips `.snippets` file. The language comes
+// from the file name. Triggers become aliases, descriptions become titles,
+// and comment lines directly above a snippet become its description.
+// Priorities, extends, global Python blocks and snippet options are ignored.
+func ParseUltiSnips(filename string, data []byte) ([]*domain.Snippet, error) {
+	language := ultiSnipsLanguage(filename)
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	var snippets []*domain.Snippet
+	var comments []string
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		keyword, _, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch {
+		case strings.HasPrefix(line, "#"):
+			comments = append(comments, strings.TrimPrefix(strings.TrimPrefix(line, "#"), " "))
+			continue
+		case keyword == "snippet":
+			trig, title, err := parseUltiSnipsHeader(strings.TrimSpace(line)[len("snippet"):])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+
+			start := i + 1
+			for i++; i < len(lines) && strings.TrimRight(lines[i], " \t") != "endsnippet"; i++ {
+			}
+			if i >= len(lines) {
+				return nil, fmt.Errorf("line %d: snippet %q has no endsnippet", start, trig)
+			}
+
+			if title == "" {
+				title = trig
+			}
+			code := fromTextMate(strings.Join(lines[start:i], "\n"), ultiSnipsDialect)
+			snippet, err := domain.NewSnippet(title, language, code)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", start, err)
+			}
+			snippet.SetDescription(strings.Join(comments, "\n"))
+			if err := snippet.AddAlias(trig); err != nil {
+				return nil, fmt.Errorf("line %d: %w", start, err)
+			}
+			snippets = append(snippets, snippet)
+		case keyword == "global":
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "endglobal"; i++ {
+			}
+		}
+		comments = nil
+	}
+	return snippets, nil
+}
+
+// parseUltiSnipsHeader splits the rest of a `snippet trigger "description"
+// options` line as UltiSnips does: options follow a quoted description, and
+// triggers with spaces are enclosed in a delimiter character.
+func parseUltiSnipsHeader(header string) (trig, description string, err error) {
+	remain := strings.TrimSpace(header)
+	if remain == "" {
+		return "", "", fmt.Errorf("snippet has no trigger")
+	}
+
+	words := strings.Fields(remain)
+	opts := ""
+	if n := len(words); n > 2 && !strings.Contains(words[n-1], `"`) && strings.HasSuffix(words[n-2], `"`) {
+		opts = words[n-1]
+		remain = strings.TrimSpace(remain[:len(remain)-len(opts)])
+	}
+
+	if strings.HasSuffix(remain, `"`) {
+		if left := strings.LastIndex(remain[:len(remain)-1], `"`); left > 0 {
+			description = remain[left+1 : len(remain)-1]
+			remain = remain[:left]
+		}
+	}
+
+	trig = strings.TrimSpace(remain)
+	if len(strings.Fields(trig)) > 1 || strings.Contains(opts, "r") {
+		if len(trig) < 2 || trig[0] != trig[len(trig)-1] {
+			return "", "", fmt.Errorf("invalid multiword trigger %q", trig)
+		}
+		trig = trig[1 : len(trig)-1]
+	}
+	return trig, description, nil
+}
+
+// WriteUltiSnips writes snippets as UltiSnips files, one `<filetype>.snippets`
+// file per language. The first alias becomes the trigger, or a trigger made
+// from the title if there is none; the title becomes the description, and
+// the snip description is written as comments above the snippet.
+func WriteUltiSnips(snippets []*domain.Snippet) (Files, error) {
+	files := make(Files)
+	for _, snippet := range snippets {
+		name := UltiSnipsFiletype(snippet.Language()) + ".snippets"
+
+		var b strings.Builder
+		if len(files[name]) > 0 {
+			b.WriteString("\n")
+		}
+		if description := snippet.Description(); description != "" {
+			for _, line := range strings.Split(description, "\n") {
+				b.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+			}
+		}
+
+		trig := trigger(snippet.Aliases(), snippet.Title())
+		if strings.ContainsAny(trig, " \t") {
+			i := strings.IndexFunc(ultiSnipsTriggerDelimiters, func(r rune) bool { return !strings.ContainsRune(trig, r) })
+			if i < 0 {
+				return nil, fmt.Errorf("snippet %d: trigger %q cannot be quoted", snippet.ID(), trig)
+			}
+			delimiter := ultiSnipsTriggerDelimiters[i : i+1]
+			trig = delimiter + trig + delimiter
+		}
+		title := strings.ReplaceAll(snippet.Title(), `"`, "'")
+
+		fmt.Fprintf(&b, "snippet %s \"%s\"\n", trig, title)
+		b.WriteString(toTextMate(snippet.Code(), ultiSnipsDialect))
+		b.WriteString("\nendsnippet\n")
+
+		files[name] = append(files[name], b.String()...)
+	}
+	return files, nil
+}
@@ -0,0 +1,115 @@
+package exchange
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+func TestParseUltiSnips(t *testing.T) {
+	t.Run("reads a snippets file", func(t *testing.T) {
+		data, err := os.ReadFile("testdata/go.snippets")
+		if err != nil {
+			t.Fatalf("failed to read sample: %v", err)
+		}
+
+		snippets, err := ParseUltiSnips("testdata/go.snippets", data)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(snippets) != 3 {
+			t.Fatalf("expected 3 snippets, got %d", len(snippets))
+		}
+
+		iferr := snippets[0]
+		if iferr.Title() != "If error" || iferr.Language() != "go" || !iferr.HasAlias("iferr") {
+			t.Errorf("unexpected snippet: %s %v", iferr, iferr.Aliases())
+		}
+		if want := "Handle an error by returning it\nwith context."; iferr.Description() != want {
+			t.Errorf("expected comments as description, got %q", iferr.Description())
+		}
+		if want := "if err != nil {\n\treturn {{1:nil}}, fmt.Errorf(\"{{2:context}}: %w\", err)\n}\n{{0}}"; iferr.Code() != want {
+			t.Errorf("expected code %q, got %q", want, iferr.Code())
+		}
+
+		loop := snippets[1]
+		if !loop.HasAlias("for range") || loop.Description() != "" {
+			t.Errorf("expected multiword trigger without description, got %v %q", loop.Aliases(), loop.Description())
+		}
+		if !strings.Contains(loop.Code(), "\t{{VISUAL}}{{0}}") {
+			t.Errorf("expected VISUAL placeholder, got %q", loop.Code())
+		}
+
+		todo := snippets[2]
+		if want := "// TODO({{shell}}): {{1}} `raw` $HOME"; todo.Code() != want {
+			t.Errorf("expected code %q, got %q", want, todo.Code())
+		}
+	})
+
+	t.Run("takes the language from the file name", func(t *testing.T) {
+		data := []byte("snippet x\nx\nendsnippet\n")
+		for filename, want := range map[string]string{
+			"UltiSnips/sh.snippets":            "bash",
+			"UltiSnips/python_django.snippets": "python",
+			"UltiSnips/rust/tests.snippets":    "rust",
+			"UltiSnips/all.snippets":           "text",
+		} {
+			snippets, err := ParseUltiSnips(filename, data)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if snippets[0].Language() != want {
+				t.Errorf("%s: expected %q, got %q", filename, want, snippets[0].Language())
+			}
+		}
+	})
+
+	t.Run("reports malformed snippets", func(t *testing.T) {
+		if _, err := ParseUltiSnips("go.snippets", []byte("snippet x\nbody\n")); err == nil {
+			t.Error("expected error for missing endsnippet")
+		}
+		if _, err := ParseUltiSnips("go.snippets", []byte("snippet a b\nx\nendsnippet\n")); err == nil {
+			t.Error("expected error for unquoted multiword trigger")
+		}
+	})
+}
+
+func TestWriteUltiSnips(t *testing.T) {
+	t.Run("round-trips snippets", func(t *testing.T) {
+		first, _ := domain.NewSnippet(`Say "hi"`, "bash", "echo \"${USER}\" {{1:name}} `date`\n{{0}}")
+		first.AddAlias("say hi")
+		first.SetDescription("Greet someone\n\nby name")
+		second, _ := domain.NewSnippet("Print", "go", "fmt.Println({{1}})\n")
+
+		files, err := WriteUltiSnips([]*domain.Snippet{first, second})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(files) != 2 || files["sh.snippets"] == nil || files["go.snippets"] == nil {
+			t.Fatalf("expected sh and go files, got %v", files)
+		}
+		if !strings.Contains(string(files["sh.snippets"]), `snippet !say hi! "Say 'hi'"`) {
+			t.Errorf("expected quoted trigger and description:\n%s", files["sh.snippets"])
+		}
+
+		snippets, err := ParseUltiSnips("sh.snippets", files["sh.snippets"])
+		if err != nil || len(snippets) != 1 {
+			t.Fatalf("failed to parse output: %v\n%s", err, files["sh.snippets"])
+		}
+		got := snippets[0]
+		if got.Code() != first.Code() || got.Description() != first.Description() ||
+			got.Language() != "bash" || !got.HasAlias("say hi") {
+			t.Errorf("snippet did not round-trip: %q %q %v", got.Code(), got.Description(), got.Aliases())
+		}
+
+		snippets, err = ParseUltiSnips("go.snippets", files["go.snippets"])
+		if err != nil || len(snippets) != 1 {
+			t.Fatalf("failed to parse output: %v\n%s", err, files["go.snippets"])
+		}
+		if snippets[0].Code() != second.Code() || !snippets[0].HasAlias("print") {
+			t.Errorf("expected code and derived trigger, got %q %v", snippets[0].Code(), snippets[0].Aliases())
+		}
+	})
+}
@@ -0,0 +1,273 @@
+package exchange
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// vscodeLanguages maps snip languages to VS Code language IDs where they differ.
+var vscodeLanguages = map[string]string{
+	"bash": "shellscript",
+	"zsh":  "shellscript",
+}
+
+// vscodeScopes maps VS Code language IDs to snip languages where
+// CanonicalLanguage does not already do so.
+var vscodeScopes = map[string]string{
+	"javascriptreact": "javascript",
+	"typescriptreact": "typescript",
+	"jsonc":           "json",
+	"plaintext":       "text",
+}
+
+// VSCodeLanguage returns the VS Code language ID for a snip language.
+func VSCodeLanguage(language string) string {
+	language = domain.CanonicalLanguage(language)
+	if id, ok := vscodeLanguages[language]; ok {
+		return id
+	}
+	return language
+}
+
+// LanguageFromVSCode returns the snip language for a VS Code language ID.
+func LanguageFromVSCode(id string) string {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if language, ok := vscodeScopes[id]; ok {
+		return language
+	}
+	return domain.CanonicalLanguage(id)
+}
+
+// stringList is a JSON value that may be a string or an array of strings,
+// as VS Code accepts for prefixes, bodies and descriptions.
+type stringList []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = stringList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return errors.New("expected a string or an array of strings")
+	}
+	*l = list
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. A single value is written as a string.
+func (l stringList) MarshalJSON() ([]byte, error) {
+	if len(l) == 1 {
+		return json.Marshal(l[0])
+	}
+	return json.Marshal([]string(l))
+}
+
+// vscodeSnippet is one entry of a VS Code snippet file.
+type vscodeSnippet struct {
+	Prefix      stringList `json:"prefix,omitempty"`
+	Body        stringList `json:"body"`
+	Description stringList `json:"description,omitempty"`
+	Scope       string     `json:"scope,omitempty"`
+}
+
+// ParseVSCode reads a VS Code snippet file: a global `.code-snippets` file,
+// whose entries name their languages in "scope", or a language file such as
+// `go.json`, whose language comes from filename. Comments and trailing
+// commas are allowed, as in VS Code. Entry names become titles, prefixes
+// become aliases, and tab stops become placeholders. An entry scoped to
+// several languages is imported with the first one. Entries that can't be
+// converted, such as ones with an empty body, are skipped and reported
+// with a *SkippedError.
+func ParseVSCode(filename string, data []byte) ([]*domain.Snippet, error) {
+	fileLanguage := ""
+	if ext := filepath.Ext(filename); strings.EqualFold(ext, ".json") {
+		fileLanguage = LanguageFromVSCode(strings.TrimSuffix(filepath.Base(filename), ext))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(stripJSONC(data)))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, errors.New("VS Code snippet file must contain a JSON object")
+	}
+
+	var snippets []*domain.Snippet
+	var skipped []error
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		name := tok.(string)
+
+		// Only malformed JSON fails the file; a bad entry is skipped
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("snippet %q: %w", name, err)
+		}
+		var entry vscodeSnippet
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			skipped = append(skipped, fmt.Errorf("snippet %q: %w", name, err))
+			continue
+		}
+
+		snippet, err := entry.toSnippet(name, fileLanguage)
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("snippet %q: %w", name, err))
+			continue
+		}
+		snippets = append(snippets, snippet)
+	}
+	if len(skipped) > 0 {
+		return snippets, &SkippedError{Entries: skipped}
+	}
+	return snippets, nil
+}
+
+// IsVSCodeSnippets reports whether data looks like a VS Code snippet file:
+// a JSON object, with comments allowed, whose values are all objects with
+// a "body".
+func IsVSCodeSnippets(data []byte) bool {
+	var entries map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(stripJSONC(data), &entries); err != nil || len(entries) == 0 {
+		return false
+	}
+	for _, entry := range entries {
+		if _, ok := entry["body"]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// toSnippet converts an entry to a snippet.
+func (e vscodeSnippet) toSnippet(name, fileLanguage string) (*domain.Snippet, error) {
+	code := fromTextMate(strings.Join(e.Body, "\n"), vscodeDialect)
+
+	language := fileLanguage
+	if scope, _, _ := strings.Cut(e.Scope, ","); strings.TrimSpace(scope) != "" {
+		language = LanguageFromVSCode(scope)
+	}
+	if language == "" {
+		language = domain.DetectLanguage("", code).Language
+	}
+	if language == "" {
+		language = "text"
+	}
+
+	snippet, err := domain.NewSnippet(name, language, code)
+	if err != nil {
+		return nil, err
+	}
+	snippet.SetDescription(strings.Join(e.Description, "\n"))
+	for _, prefix := range e.Prefix {
+		if err := snippet.AddAlias(prefix); err != nil {
+			return nil, err
+		}
+	}
+	return snippet, nil
+}
+
+// WriteVSCode writes snippets as a global `.code-snippets` file. Titles
+// become entry names, made unique if needed, aliases become prefixes (the
+// title is used when there are none), and the language becomes the scope.
+func WriteVSCode(snippets []*domain.Snippet) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{")
+
+	names := make(map[string]bool)
+	for i, snippet := range snippets {
+		name := uniqueName(snippet.Title(), names)
+
+		prefix := stringList(snippet.Aliases())
+		if len(prefix) == 0 {
+			prefix = stringList{snippet.Title()}
+		}
+		entry := vscodeSnippet{
+			Prefix: prefix,
+			Body:   strings.Split(toTextMate(snippet.Code(), vscodeDialect), "\n"),
+			Scope:  VSCodeLanguage(snippet.Language()),
+		}
+		if snippet.Description() != "" {
+			entry.Description = stringList{snippet.Description()}
+		}
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.MarshalIndent(entry, "  ", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n  ")
+		b.Write(key)
+		b.WriteString(": ")
+		b.Write(value)
+	}
+
+	b.WriteString("\n}\n")
+	return b.Bytes(), nil
+}
+
+// uniqueName returns name, or name with a number appended if it is taken,
+// and marks the result as taken.
+func uniqueName(name string, taken map[string]bool) string {
+	unique := name
+	for n := 2; taken[unique]; n++ {
+		unique = fmt.Sprintf("%s (%d)", name, n)
+	}
+	taken[unique] = true
+	return unique
+}
+
+// stripJSONC removes comments and trailing commas from JSON with comments,
+// leaving strings untouched.
+func stripJSONC(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c == '"':
+			start := i
+			for i++; i < len(data) && data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+			out = append(out, data[start:min(i+1, len(data))]...)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			i--
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				i = len(data)
+			} else {
+				i += end + 3
+			}
+		case c == ']' || c == '}':
+			trimmed := bytes.TrimRight(out, " \t\r\n")
+			if len(trimmed) > 0 && trimmed[len(trimmed)-1] == ',' {
+				out = append(trimmed[:len(trimmed)-1], out[len(trimmed):]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,147 @@
+package exchange
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+func TestParseVSCode(t *testing.T) {
+	t.Run("reads a code-snippets file", func(t *testing.T) {
+		data, err := os.ReadFile("testdata/sample.code-snippets")
+		if err != nil {
+			t.Fatalf("failed to read sample: %v", err)
+		}
+
+		snippets, err := ParseVSCode("sample.code-snippets", data)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(snippets) != 2 {
+			t.Fatalf("expected 2 snippets, got %d", len(snippets))
+		}
+
+		loop := snippets[0]
+		if loop.Title() != "For loop" || loop.Language() != "javascript" {
+			t.Errorf("unexpected title or language: %s", loop)
+		}
+		if aliases := loop.Aliases(); len(aliases) != 2 || aliases[0] != "for" || aliases[1] != "fori" {
+			t.Errorf("expected prefixes as aliases, got %v", aliases)
+		}
+		if loop.Description() != "For loop over an array" {
+			t.Errorf("unexpected description %q", loop.Description())
+		}
+		wantCode := "for (let {{1:i}} = 0; {{1}} < {{2:array}}.length; {{1}}++) {\n\tconst {{3:element}} = {{2}}[{{1}}];\n\t{{0}}\n}"
+		if loop.Code() != wantCode {
+			t.Errorf("expected code %q, got %q", wantCode, loop.Code())
+		}
+
+		shell := snippets[1]
+		if shell.Language() != "bash" {
+			t.Errorf("expected shellscript scope to map to bash, got %q", shell.Language())
+		}
+		if want := `echo "$1 from {{TM_FILENAME}}" {{1:info}}`; shell.Code() != want {
+			t.Errorf("expected code %q, got %q", want, shell.Code())
+		}
+	})
+
+	t.Run("takes the language from a language file name", func(t *testing.T) {
+		data := []byte(`{"Print": {"prefix": "pr", "body": "fmt.Println($1)"}}`)
+
+		snippets, err := ParseVSCode("snippets/go.json", data)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if snippets[0].Language() != "go" {
+			t.Errorf("expected go, got %q", snippets[0].Language())
+		}
+	})
+
+	t.Run("reports invalid entries", func(t *testing.T) {
+		if _, err := ParseVSCode("x.code-snippets", []byte(`{"Bad": {"body": 42}}`)); err == nil ||
+			!strings.Contains(err.Error(), "Bad") {
+			t.Errorf("expected error naming the entry, got %v", err)
+		}
+		if _, err := ParseVSCode("x.code-snippets", []byte(`[]`)); err == nil {
+			t.Error("expected error for non-object file")
+		}
+	})
+
+	t.Run("skips invalid entries and keeps the others", func(t *testing.T) {
+		data := []byte(`{"Empty": {"body": ""}, "Bad": {"body": 42}, "Print": {"body": "print($1)", "scope": "python"}}`)
+
+		snippets, err := ParseVSCode("x.code-snippets", data)
+		var skipped *SkippedError
+		if !errors.As(err, &skipped) || len(skipped.Entries) != 2 {
+			t.Fatalf("expected 2 skipped entries, got %v", err)
+		}
+		if len(snippets) != 1 || snippets[0].Title() != "Print" {
+			t.Errorf("expected the Print snippet, got %v", snippets)
+		}
+	})
+}
+
+func TestIsVSCodeSnippets(t *testing.T) {
+	for data, want := range map[string]bool{
+		`{"Print": {"prefix": "pr", "body": ["print($1)"]}}`:  true,
+		"{\n  // comment\n  \"Print\": {\"body\": \"x\"},\n}": true,
+		`{"name": "app", "version": "1.0.0"}`:                 false,
+		`{"compilerOptions": {"strict": true}}`:               false,
+		`{}`:                                                  false,
+		`[]`:                                                  false,
+	} {
+		if got := IsVSCodeSnippets([]byte(data)); got != want {
+			t.Errorf("IsVSCodeSnippets(%s): expected %v, got %v", data, want, got)
+		}
+	}
+}
+
+func TestWriteVSCode(t *testing.T) {
+	t.Run("round-trips snippets", func(t *testing.T) {
+		first, _ := domain.NewSnippet("Retry", "bash", "for i in 1 2 3; do\n  {{1:cmd}} && break\n  echo \"$i\"\ndone")
+		first.AddAlias("retry")
+		first.SetDescription("Retry a command")
+		second, _ := domain.NewSnippet("Retry", "go", "fmt.Println({{msg}})")
+
+		data, err := WriteVSCode([]*domain.Snippet{first, second})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(string(data), `"scope": "shellscript"`) {
+			t.Errorf("expected bash to be written as shellscript:\n%s", data)
+		}
+
+		snippets, err := ParseVSCode("out.code-snippets", data)
+		if err != nil {
+			t.Fatalf("failed to parse output: %v\n%s", err, data)
+		}
+		if len(snippets) != 2 {
+			t.Fatalf("expected 2 snippets, got %d", len(snippets))
+		}
+		if snippets[0].Code() != first.Code() || snippets[0].Description() != first.Description() ||
+			snippets[0].Language() != "bash" || !snippets[0].HasAlias("retry") {
+			t.Errorf("first snippet did not round-trip: %q %v", snippets[0].Code(), snippets[0].Aliases())
+		}
+		if snippets[1].Title() != "Retry (2)" || !snippets[1].HasAlias("Retry") {
+			t.Errorf("expected unique name and title prefix, got %q %v", snippets[1].Title(), snippets[1].Aliases())
+		}
+		if snippets[1].Code() != "fmt.Println({{1:msg}})" {
+			t.Errorf("expected named placeholder as numbered tab stop, got %q", snippets[1].Code())
+		}
+	})
+}
+
+func TestVSCodeLanguage(t *testing.T) {
+	if got := VSCodeLanguage("zsh"); got != "shellscript" {
+		t.Errorf("expected shellscript, got %q", got)
+	}
+	if got := LanguageFromVSCode("typescriptreact"); got != "typescript" {
+		t.Errorf("expected typescript, got %q", got)
+	}
+	if got := LanguageFromVSCode("Python"); got != "python" {
+		t.Errorf("expected python, got %q", got)
+	}
+}
@@ -0,0 +1,164 @@
+package exchange
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// yasnippetModes maps snip languages to Emacs major modes where the mode
+// is not the language name followed by "-mode".
+var yasnippetModes = map[string]string{
+	"bash":       "sh-mode",
+	"cpp":        "c++-mode",
+	"javascript": "js-mode",
+}
+
+// yasnippetLanguages maps Emacs mode names, without "-mode", to snip
+// languages where CanonicalLanguage does not already do so.
+var yasnippetLanguages = map[string]string{
+	"js2":              "javascript",
+	"js3":              "javascript",
+	"rjsx":             "javascript",
+	"cperl":            "perl",
+	"gfm":              "markdown",
+	"makefile-gmake":   "makefile",
+	"makefile-bsdmake": "makefile",
+	"fundamental":      "text",
+	"prog":             "text",
+}
+
+// yasnippetDialect is the yasnippet snippet syntax. Backquotes hold Emacs
+// Lisp, and defaults starting with '$' are mirror transformations.
+var yasnippetDialect = textMateDialect{
+	escapes:        "$`\\",
+	interpolation:  func(string) string { return "elisp" },
+	mirrorDefaults: true,
+}
+
+// YasnippetMode returns the Emacs major mode for a snip language.
+func YasnippetMode(language string) string {
+	language = domain.CanonicalLanguage(language)
+	if mode, ok := yasnippetModes[language]; ok {
+		return mode
+	}
+	return language + "-mode"
+}
+
+// LanguageFromYasnippet returns the snip language for an Emacs major mode.
+// Tree-sitter modes map to the same language as the classic mode.
+func LanguageFromYasnippet(mode string) string {
+	mode = strings.TrimSuffix(strings.ToLower(mode), "-mode")
+	mode = strings.TrimSuffix(mode, "-ts")
+	if language, ok := yasnippetLanguages[mode]; ok {
+		return language
+	}
+	return domain.CanonicalLanguage(mode)
+}
+
+// ParseYasnippet reads a yasnippet snippet file, stored as
+// `<mode>/<name>`. The language comes from the mode directory. The key
+// becomes an alias and the name the title; a file without a name uses the
+// key or file name. Command snippets hold Emacs Lisp rather than text and
+// are skipped.
+func ParseYasnippet(filename string, data []byte) ([]*domain.Snippet, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	directives := make(map[string][]string)
+	body := text
+	if header, rest, ok := cutYasnippetHeader(text); ok {
+		body = rest
+		for _, line := range strings.Split(header, "\n") {
+			key, value, ok := strings.Cut(strings.TrimSpace(strings.TrimPrefix(line, "#")), ":")
+			if !ok || strings.ContainsAny(key, " \t") {
+				continue
+			}
+			directives[key] = append(directives[key], strings.TrimSpace(value))
+		}
+	}
+	directive := func(key string) string {
+		if values := directives[key]; len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	if directive("type") == "command" {
+		return nil, nil
+	}
+
+	key := directive("key")
+	if key == "" {
+		key = filepath.Base(filename)
+	}
+	title := directive("name")
+	if title == "" {
+		title = key
+	}
+
+	language := LanguageFromYasnippet(filepath.Base(filepath.Dir(filename)))
+	code := fromTextMate(strings.TrimSuffix(body, "\n"), yasnippetDialect)
+	snippet, err := domain.NewSnippet(title, language, code)
+	if err != nil {
+		return nil, err
+	}
+	snippet.SetDescription(strings.Join(directives["description"], "\n"))
+	if err := snippet.AddAlias(key); err != nil {
+		return nil, err
+	}
+	return []*domain.Snippet{snippet}, nil
+}
+
+// cutYasnippetHeader splits a snippet file at its "# --" line, reporting
+// false if there is no header.
+func cutYasnippetHeader(text string) (header, body string, ok bool) {
+	if strings.HasPrefix(text, "# --\n") {
+		return "", text[len("# --\n"):], true
+	}
+	if i := strings.Index(text, "\n# --\n"); i >= 0 {
+		return text[:i], text[i+len("\n# --\n"):], true
+	}
+	if strings.HasSuffix(text, "\n# --") {
+		return strings.TrimSuffix(text, "\n# --"), "", true
+	}
+	return "", "", false
+}
+
+// WriteYasnippet writes snippets as yasnippet files, one `<mode>/<key>` file
+// per snippet. The first alias becomes the key, or a key made from the title
+// if there is none. The snip description is kept in description directives,
+// which yasnippet ignores.
+func WriteYasnippet(snippets []*domain.Snippet) (Files, error) {
+	files := make(Files)
+	for _, snippet := range snippets {
+		key := trigger(snippet.Aliases(), snippet.Title())
+		if strings.Contains(key, "\n") {
+			return nil, fmt.Errorf("snippet %d: key %q spans several lines", snippet.ID(), key)
+		}
+
+		dir := YasnippetMode(snippet.Language())
+		name := path.Join(dir, fileName(key, "snippet"))
+		for n := 2; files[name] != nil; n++ {
+			name = path.Join(dir, fmt.Sprintf("%s-%d", fileName(key, "snippet"), n))
+		}
+
+		var b strings.Builder
+		b.WriteString("# -*- mode: snippet -*-\n")
+		fmt.Fprintf(&b, "# name: %s\n", snippet.Title())
+		fmt.Fprintf(&b, "# key: %s\n", key)
+		if description := snippet.Description(); description != "" {
+			for _, line := range strings.Split(description, "\n") {
+				fmt.Fprintf(&b, "# description: %s\n", line)
+			}
+		}
+		b.WriteString("# --\n")
+		b.WriteString(toTextMate(snippet.Code(), yasnippetDialect))
+		b.WriteString("\n")
+
+		files[name] = []byte(b.String())
+	}
+	return files, nil
+}
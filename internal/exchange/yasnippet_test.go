@@ -0,0 +1,113 @@
+package exchange
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// parseYasnippetFile parses a sample file from testdata/yasnippet.
+func parseYasnippetFile(t *testing.T, name string) []*domain.Snippet {
+	t.Helper()
+	filename := filepath.Join("testdata", "yasnippet", name)
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read sample: %v", err)
+	}
+	snippets, err := ParseYasnippet(filename, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return snippets
+}
+
+func TestParseYasnippet(t *testing.T) {
+	t.Run("reads header directives", func(t *testing.T) {
+		snippets := parseYasnippetFile(t, "go-mode/iferr")
+		if len(snippets) != 1 {
+			t.Fatalf("expected 1 snippet, got %d", len(snippets))
+		}
+		iferr := snippets[0]
+		if iferr.Title() != "If error" || iferr.Language() != "go" || !iferr.HasAlias("iferr") {
+			t.Errorf("unexpected snippet: %s %v", iferr, iferr.Aliases())
+		}
+		if want := "if err != nil {\n\treturn {{1:nil}}, fmt.Errorf(\"{{2:context}}: %w\", err)\n}\n{{0}}"; iferr.Code() != want {
+			t.Errorf("expected code %q, got %q", want, iferr.Code())
+		}
+	})
+
+	t.Run("drops elisp and mirror transformations", func(t *testing.T) {
+		main := parseYasnippetFile(t, "go-mode/main")[0]
+		if main.Description() != "Entry point of a program" {
+			t.Errorf("unexpected description %q", main.Description())
+		}
+		want := "func main() {\n\t{{1}}()\n\tfmt.Println(\"{{elisp}}: {{1}}\")\n}"
+		if main.Code() != want {
+			t.Errorf("expected code %q, got %q", want, main.Code())
+		}
+	})
+
+	t.Run("maps modes to languages", func(t *testing.T) {
+		ifdir := parseYasnippetFile(t, "sh-mode/ifdir")[0]
+		if ifdir.Language() != "bash" || !ifdir.HasAlias("ifd") {
+			t.Errorf("unexpected snippet: %s %v", ifdir, ifdir.Aliases())
+		}
+		if !strings.Contains(ifdir.Code(), `echo "$HOME is $HOME"`) {
+			t.Errorf("expected escaped and plain $HOME to stay literal, got %q", ifdir.Code())
+		}
+		for mode, want := range map[string]string{"js2-mode": "javascript", "python-ts-mode": "python", "c++-mode": "cpp"} {
+			if got := LanguageFromYasnippet(mode); got != want {
+				t.Errorf("%s: expected %q, got %q", mode, want, got)
+			}
+		}
+	})
+
+	t.Run("skips command snippets", func(t *testing.T) {
+		if snippets := parseYasnippetFile(t, "go-mode/reload"); len(snippets) != 0 {
+			t.Errorf("expected no snippets, got %d", len(snippets))
+		}
+	})
+
+	t.Run("uses the file name without a header", func(t *testing.T) {
+		snippets, err := ParseYasnippet("snippets/rust-mode/dbg", []byte("dbg!($1)\n"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if snippets[0].Title() != "dbg" || !snippets[0].HasAlias("dbg") || snippets[0].Code() != "dbg!({{1}})" {
+			t.Errorf("unexpected snippet: %s %q", snippets[0], snippets[0].Code())
+		}
+	})
+}
+
+func TestWriteYasnippet(t *testing.T) {
+	t.Run("round-trips snippets", func(t *testing.T) {
+		first, _ := domain.NewSnippet("Log value", "javascript", "console.log(`{{1:value}}`, {{1}}); // costs $5")
+		first.AddAlias("log")
+		first.SetDescription("Log a value\nwith its name")
+		second, _ := domain.NewSnippet("Log value", "javascript", "console.log({{1}})")
+		second.AddAlias("log")
+
+		files, err := WriteYasnippet([]*domain.Snippet{first, second})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(files) != 2 || files["js-mode/log"] == nil || files["js-mode/log-2"] == nil {
+			t.Fatalf("expected unique files in js-mode, got %v", files)
+		}
+
+		for name, want := range map[string]*domain.Snippet{"js-mode/log": first, "js-mode/log-2": second} {
+			snippets, err := ParseYasnippet(name, files[name])
+			if err != nil || len(snippets) != 1 {
+				t.Fatalf("failed to parse %s: %v\n%s", name, err, files[name])
+			}
+			got := snippets[0]
+			if got.Title() != want.Title() || got.Code() != want.Code() || got.Description() != want.Description() ||
+				got.Language() != "javascript" || !got.HasAlias("log") {
+				t.Errorf("%s did not round-trip: %q %q", name, got.Code(), got.Description())
+			}
+		}
+	})
+}
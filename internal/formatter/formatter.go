@@ -0,0 +1,264 @@
+// Package formatter validates and formats snippet code before it is saved.
+package formatter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/7-Dany/snip/internal/domain"
+	"github.com/7-Dany/snip/internal/runner"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTimeout is how long an external formatter may run.
+const DefaultTimeout = 10 * time.Second
+
+// SyntaxError reports code that does not parse.
+type SyntaxError struct {
+	Language string
+	Line     int // 1-based; 0 if unknown
+	Message  string
+}
+
+// Error implements the error interface.
+func (e *SyntaxError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s syntax error on line %d: %s", e.Language, e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s syntax error: %s", e.Language, e.Message)
+}
+
+// Format validates code and returns it formatted.
+//
+// If command is not empty it names an external formatter, which receives the
+// code on stdin and must write the formatted code to stdout; it replaces the
+// built-in step for the language. Otherwise Go code is parsed and gofmt'ed,
+// JSON and YAML are checked for well-formedness, and other languages are
+// returned unchanged.
+func Format(language, code string, command []string, timeout time.Duration) (string, error) {
+	if len(command) > 0 {
+		return formatExternal(command, code, timeout)
+	}
+
+	switch domain.CanonicalLanguage(language) {
+	case "go":
+		return formatGo(code)
+	case "json":
+		return code, checkJSON(code)
+	case "yaml":
+		return code, checkYAML(code)
+	default:
+		return code, nil
+	}
+}
+
+// goWrapping turns a Go fragment into a parsable file.
+type goWrapping struct {
+	prefix string
+	suffix string
+	indent bool // The fragment is indented by one tab inside the wrapper
+}
+
+// goWrappings try a complete file, top-level declarations, then statements.
+var goWrappings = []goWrapping{
+	{},
+	{prefix: "package p\n"},
+	{prefix: "package p\nfunc _() {\n", suffix: "\n}\n", indent: true},
+}
+
+// formatGo parses and formats Go code. Snippets are often fragments, so code
+// without a package clause is also tried as declarations and as statements.
+func formatGo(code string) (string, error) {
+	wrappings := goWrappings
+	if hasPackageClause(code) {
+		wrappings = wrappings[:1]
+	}
+
+	var best *SyntaxError
+	for _, w := range wrappings {
+		src := w.prefix + code + w.suffix
+		if _, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments); err != nil {
+			// Report the attempt that got furthest before failing. Errors in
+			// the wrapper's suffix are reported on the last line of the code.
+			syntaxErr := goSyntaxError(err, strings.Count(w.prefix, "\n"))
+			syntaxErr.Line = min(syntaxErr.Line, strings.Count(code, "\n")+1)
+			if best == nil || syntaxErr.Line > best.Line {
+				best = syntaxErr
+			}
+			continue
+		}
+
+		formatted, err := format.Source([]byte(src))
+		if err != nil {
+			return code, goSyntaxError(err, strings.Count(w.prefix, "\n"))
+		}
+		return unwrapGo(string(formatted), w, code), nil
+	}
+	return code, best
+}
+
+// hasPackageClause reports whether code starts with a package clause,
+// ignoring comments and blank lines.
+func hasPackageClause(code string) bool {
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	s.Init(fset.AddFile("", fset.Base(), len(code)), []byte(code), nil, 0)
+	_, tok, _ := s.Scan()
+	return tok == token.PACKAGE
+}
+
+// goSyntaxError converts a go/parser error, shifting its line by the
+// number of lines a wrapper added in front of the code.
+func goSyntaxError(err error, lineOffset int) *SyntaxError {
+	var list scanner.ErrorList
+	if errors.As(err, &list) && len(list) > 0 {
+		return &SyntaxError{
+			Language: "go",
+			Line:     max(0, list[0].Pos.Line-lineOffset),
+			Message:  list[0].Msg,
+		}
+	}
+	return &SyntaxError{Language: "go", Message: err.Error()}
+}
+
+// unwrapGo removes a wrapper from formatted code. The result keeps the
+// trailing newline only if the original code had one.
+func unwrapGo(formatted string, w goWrapping, original string) string {
+	if w.prefix != "" {
+		formatted = strings.TrimLeft(strings.TrimPrefix(formatted, "package p\n"), "\n")
+	}
+	if w.indent {
+		formatted = strings.TrimPrefix(formatted, "func _() {\n")
+		formatted = strings.TrimSuffix(strings.TrimRight(formatted, "\n"), "}")
+
+		// Lines inside raw strings were not indented by gofmt
+		raw := rawStringLines(formatted)
+		lines := strings.Split(strings.TrimRight(formatted, "\n"), "\n")
+		for i, line := range lines {
+			if !raw[i] {
+				lines[i] = strings.TrimPrefix(line, "\t")
+			}
+		}
+		formatted = strings.Join(lines, "\n") + "\n"
+	}
+
+	if !strings.HasSuffix(original, "\n") {
+		formatted = strings.TrimRight(formatted, "\n")
+	}
+	return formatted
+}
+
+// rawStringLines returns the 0-based indexes of the lines of src that
+// begin inside a raw string literal.
+func rawStringLines(src string) map[int]bool {
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	s.Init(file, []byte(src), nil, 0)
+
+	raw := make(map[int]bool)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return raw
+		}
+		if tok == token.STRING && strings.HasPrefix(lit, "`") {
+			first := file.Line(pos)
+			for line := first + 1; line <= first+strings.Count(lit, "\n"); line++ {
+				raw[line-1] = true
+			}
+		}
+	}
+}
+
+// checkJSON reports whether code is a single well-formed JSON value.
+func checkJSON(code string) error {
+	var value any
+	err := json.Unmarshal([]byte(code), &value)
+	if err == nil {
+		return nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return &SyntaxError{
+			Language: "json",
+			Line:     lineAt(code, int(syntaxErr.Offset)),
+			Message:  syntaxErr.Error(),
+		}
+	}
+	return &SyntaxError{Language: "json", Message: err.Error()}
+}
+
+// lineAt returns the 1-based line of a byte offset in code.
+func lineAt(code string, offset int) int {
+	offset = min(max(offset, 0), len(code))
+	return strings.Count(code[:offset], "\n") + 1
+}
+
+// yamlLine matches the position prefix of yaml.v3 errors.
+var yamlLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// checkYAML reports whether every document in code is well-formed YAML.
+func checkYAML(code string) error {
+	decoder := yaml.NewDecoder(strings.NewReader(code))
+	for {
+		var value any
+		err := decoder.Decode(&value)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			message := err.Error()
+			line := 0
+			if m := yamlLine.FindStringSubmatch(message); m != nil {
+				line, _ = strconv.Atoi(m[1])
+				message = strings.TrimPrefix(message, m[0])
+			}
+			return &SyntaxError{Language: "yaml", Line: line, Message: strings.TrimPrefix(message, "yaml: ")}
+		}
+	}
+}
+
+// formatExternal pipes code through an external formatter.
+func formatExternal(command []string, code string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := runner.Command(ctx, command[0], command[1:]...)
+	cmd.Stdin = strings.NewReader(code)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := runner.RunCommand(cmd); err != nil {
+		if ctx.Err() != nil {
+			return code, fmt.Errorf("formatter %s timed out after %s", command[0], timeout)
+		}
+		if details := strings.TrimSpace(stderr.String()); details != "" {
+			return code, fmt.Errorf("formatter %s failed: %s", command[0], details)
+		}
+		return code, fmt.Errorf("formatter %s failed: %w", command[0], err)
+	}
+
+	formatted := stdout.String()
+	if strings.TrimSpace(formatted) == "" {
+		return code, fmt.Errorf("formatter %s produced no output", command[0])
+	}
+	if !strings.HasSuffix(code, "\n") {
+		formatted = strings.TrimRight(formatted, "\n")
+	}
+	return formatted, nil
+}
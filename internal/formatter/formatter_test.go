@@ -0,0 +1,129 @@
+package formatter
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	t.Run("formats a Go file", func(t *testing.T) {
+		got, err := Format("go", "package main\nfunc main(){}", nil, time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "package main\n\nfunc main() {}"; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("formats Go declarations", func(t *testing.T) {
+		got, err := Format("golang", "type T struct{A int\nBB string}\n", nil, time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "type T struct {\n\tA  int\n\tBB string\n}\n"; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("formats Go statements", func(t *testing.T) {
+		got, err := Format("go", "x:=1\nif x>0{\nfmt.Println( x)}", nil, time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "x := 1\nif x > 0 {\n\tfmt.Println(x)\n}"; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("keeps the indentation of raw strings in Go statements", func(t *testing.T) {
+		code := "script:=`\n\tset -e\n\tmake`\nrun( script)"
+		got, err := Format("go", code, nil, time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "script := `\n\tset -e\n\tmake`\nrun(script)"; got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("reports Go syntax errors with their line", func(t *testing.T) {
+		code := "x := 1\ny := (\nz := 3"
+		got, err := Format("go", code, nil, time.Second)
+
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Fatalf("expected SyntaxError, got %v", err)
+		}
+		if syntaxErr.Line < 2 || syntaxErr.Line > 3 {
+			t.Errorf("expected error on line 2 or 3, got %d", syntaxErr.Line)
+		}
+		if got != code {
+			t.Error("expected code to be returned unchanged on error")
+		}
+	})
+
+	t.Run("checks JSON", func(t *testing.T) {
+		if _, err := Format("json", `{"a": [1, 2]}`, nil, time.Second); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		_, err := Format("json", "{\n\"a\": 1,\n}", nil, time.Second)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) || syntaxErr.Line != 3 {
+			t.Errorf("expected syntax error on line 3, got %v", err)
+		}
+	})
+
+	t.Run("checks YAML", func(t *testing.T) {
+		if _, err := Format("yml", "a: 1\nb:\n  - c\n---\nd: 2\n", nil, time.Second); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		_, err := Format("yaml", "a: 1\n b: 2\n", nil, time.Second)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) || syntaxErr.Line != 2 {
+			t.Errorf("expected syntax error on line 2, got %v", err)
+		}
+	})
+
+	t.Run("leaves other languages unchanged", func(t *testing.T) {
+		code := "def f( ):\n  return 1"
+		if got, err := Format("python", code, nil, time.Second); err != nil || got != code {
+			t.Errorf("expected unchanged code, got %q, %v", got, err)
+		}
+	})
+
+	t.Run("runs an external formatter", func(t *testing.T) {
+		if _, err := exec.LookPath("tr"); err != nil {
+			t.Skip("tr not available")
+		}
+
+		got, err := Format("python", "x = 1", []string{"tr", "a-z", "A-Z"}, time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "X = 1" {
+			t.Errorf("expected %q, got %q", "X = 1", got)
+		}
+	})
+
+	t.Run("reports external formatter failures", func(t *testing.T) {
+		if _, err := exec.LookPath("sh"); err != nil {
+			t.Skip("sh not available")
+		}
+
+		_, err := Format("go", "x", []string{"sh", "-c", "echo bad input >&2; exit 1"}, time.Second)
+		if err == nil || !strings.Contains(err.Error(), "bad input") {
+			t.Errorf("expected error with stderr, got %v", err)
+		}
+
+		_, err = Format("go", "x", []string{"sh", "-c", "cat >/dev/null"}, time.Second)
+		if err == nil {
+			t.Error("expected error for empty output")
+		}
+	})
+}
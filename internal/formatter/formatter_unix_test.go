@@ -0,0 +1,50 @@
+//go:build unix
+
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestFormat_timeout(t *testing.T) {
+	t.Run("kills processes started by the formatter", func(t *testing.T) {
+		if _, err := exec.LookPath("sh"); err != nil {
+			t.Skip("sh not available")
+		}
+		pidFile := filepath.Join(t.TempDir(), "pid")
+
+		script := fmt.Sprintf("sleep 30 & echo $! > %s; wait", pidFile)
+		_, err := Format("go", "x", []string{"sh", "-c", script}, 200*time.Millisecond)
+		if err == nil || !strings.Contains(err.Error(), "timed out") {
+			t.Fatalf("expected a timeout, got %v", err)
+		}
+
+		data, _ := os.ReadFile(pidFile)
+		pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			t.Fatalf("expected the child PID, got %q", data)
+		}
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			// Killed processes may linger unreaped as zombies
+			stat, _ := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+			if errors.Is(syscall.Kill(pid, 0), syscall.ESRCH) || strings.Contains(string(stat), ") Z") {
+				break
+			}
+			if time.Now().After(deadline) {
+				syscall.Kill(pid, syscall.SIGKILL)
+				t.Fatalf("expected child process %d to be killed", pid)
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	})
+}
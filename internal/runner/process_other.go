@@ -0,0 +1,12 @@
+//go:build !unix
+
+package runner
+
+import "os/exec"
+
+// setProcessGroup leaves cmd as is; without process groups, cancelling it
+// kills the command itself.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup does nothing without process groups.
+func killProcessGroup(cmd *exec.Cmd) error { return nil }
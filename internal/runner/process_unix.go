@@ -0,0 +1,27 @@
+//go:build unix
+
+package runner
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup starts cmd in a process group of its own and makes
+// cancelling it kill the whole group, so processes it starts, such as the
+// program built by "go run", do not outlive a timeout.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return killProcessGroup(cmd)
+	}
+}
+
+// killProcessGroup kills every process left in the process group of a
+// command started with setProcessGroup.
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
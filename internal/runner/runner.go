@@ -0,0 +1,158 @@
+// Package runner executes snippet code in a temporary workspace.
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// FilePlaceholder is replaced by the path of the snippet file in a command.
+const FilePlaceholder = "{file}"
+
+// MaxOutputBytes caps how much of stdout and stderr is kept, each.
+const MaxOutputBytes = 1 << 20
+
+// waitDelay is how long to wait for output pipes to close after the
+// process has been killed, e.g. when a child process keeps them open.
+const waitDelay = time.Second
+
+// ErrNoCommand is returned when a runner has an empty command.
+var ErrNoCommand = errors.New("runner command is empty")
+
+// Result holds the outcome of running a snippet.
+type Result struct {
+	Stdout    string
+	Stderr    string
+	ExitCode  int
+	Duration  time.Duration
+	TimedOut  bool
+	Truncated bool // Output exceeded MaxOutputBytes and was cut off
+}
+
+// Run writes code to a file in a new temporary directory and executes
+// command with it. Every command argument equal to FilePlaceholder is replaced
+// by the file path; if there is none, the path is appended. args are passed
+// after the file. The process is killed after timeout, together with any
+// process it started; processes left running in the background are killed
+// when it exits.
+//
+// A non-zero exit code or a timeout is reported in the Result, not as an
+// error. Errors are returned only if the workspace cannot be prepared or
+// the command cannot be started.
+func Run(command []string, extension, code string, args []string, timeout time.Duration) (*Result, error) {
+	if len(command) == 0 {
+		return nil, ErrNoCommand
+	}
+
+	workspace, err := os.MkdirTemp("", "snip-run-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+	defer os.RemoveAll(workspace)
+
+	file := filepath.Join(workspace, "main"+extension)
+	if err := os.WriteFile(file, []byte(code), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write snippet file: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := Command(ctx, command[0], commandArgs(command[1:], file, args)...)
+	cmd.Dir = workspace
+
+	stdout := &limitedBuffer{limit: MaxOutputBytes}
+	stderr := &limitedBuffer{limit: MaxOutputBytes}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	err = RunCommand(cmd)
+	result := &Result{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Duration:  time.Since(start),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.TimedOut = true
+		result.ExitCode = -1
+		return result, nil
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return result, nil
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+		return result, nil
+	default:
+		return nil, fmt.Errorf("failed to run %s: %w", command[0], err)
+	}
+}
+
+// Command returns a command that is killed when ctx is done, together with
+// the processes it started on systems with process groups. Wait gives up
+// on output pipes held open by such processes after waitDelay.
+func Command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.WaitDelay = waitDelay
+	setProcessGroup(cmd)
+	return cmd
+}
+
+// RunCommand runs a command created with Command and then kills the
+// processes it left running in the background.
+func RunCommand(cmd *exec.Cmd) error {
+	err := cmd.Run()
+	killProcessGroup(cmd)
+	return err
+}
+
+// commandArgs builds the argument list for a runner command.
+func commandArgs(base []string, file string, args []string) []string {
+	result := make([]string, 0, len(base)+1+len(args))
+	replaced := false
+	for _, arg := range base {
+		if arg == FilePlaceholder {
+			arg = file
+			replaced = true
+		}
+		result = append(result, arg)
+	}
+	if !replaced {
+		result = append(result, file)
+	}
+	return append(result, args...)
+}
+
+// limitedBuffer is an io.Writer that keeps at most limit bytes and
+// silently discards the rest.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.buf.Len(); remaining < len(p) {
+		b.truncated = true
+		if remaining > 0 {
+			b.buf.Write(p[:remaining])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
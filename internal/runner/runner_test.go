@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"errors"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requireShell(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Run("captures stdout, stderr and exit code", func(t *testing.T) {
+		requireShell(t)
+
+		result, err := Run([]string{"sh"}, ".sh", "echo out\necho err >&2\nexit 3", nil, 5*time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Stdout != "out\n" {
+			t.Errorf("expected stdout %q, got %q", "out\n", result.Stdout)
+		}
+		if result.Stderr != "err\n" {
+			t.Errorf("expected stderr %q, got %q", "err\n", result.Stderr)
+		}
+		if result.ExitCode != 3 {
+			t.Errorf("expected exit code 3, got %d", result.ExitCode)
+		}
+		if result.TimedOut {
+			t.Error("expected no timeout")
+		}
+	})
+
+	t.Run("passes arguments after the file", func(t *testing.T) {
+		requireShell(t)
+
+		result, err := Run([]string{"sh"}, ".sh", `echo "$1-$2"`, []string{"a", "b"}, 5*time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Stdout != "a-b\n" {
+			t.Errorf("expected %q, got %q", "a-b\n", result.Stdout)
+		}
+	})
+
+	t.Run("runs in a workspace containing the snippet file", func(t *testing.T) {
+		requireShell(t)
+
+		result, err := Run([]string{"sh", "{file}"}, ".sh", "ls", nil, 5*time.Second)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.TrimSpace(result.Stdout) != "main.sh" {
+			t.Errorf("expected workspace to contain main.sh, got %q", result.Stdout)
+		}
+	})
+
+	t.Run("kills the process after the timeout", func(t *testing.T) {
+		requireShell(t)
+
+		result, err := Run([]string{"sh"}, ".sh", "sleep 5", nil, 100*time.Millisecond)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.TimedOut {
+			t.Error("expected timeout")
+		}
+		if result.Duration > 3*time.Second {
+			t.Errorf("expected process to be killed promptly, took %v", result.Duration)
+		}
+	})
+
+	t.Run("returns error for missing command", func(t *testing.T) {
+		if _, err := Run([]string{"snip-no-such-command"}, "", "", nil, time.Second); err == nil {
+			t.Error("expected error for missing command")
+		}
+	})
+
+	t.Run("returns error for empty command", func(t *testing.T) {
+		if _, err := Run(nil, "", "", nil, time.Second); !errors.Is(err, ErrNoCommand) {
+			t.Errorf("expected ErrNoCommand, got %v", err)
+		}
+	})
+}
+
+func TestCommandArgs(t *testing.T) {
+	t.Run("appends file when there is no placeholder", func(t *testing.T) {
+		got := commandArgs([]string{"run"}, "/tmp/main.go", []string{"x"})
+		want := []string{"run", "/tmp/main.go", "x"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("replaces placeholder", func(t *testing.T) {
+		got := commandArgs([]string{"-c", FilePlaceholder, "--"}, "/tmp/main.c", nil)
+		want := []string{"-c", "/tmp/main.c", "--"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	b := &limitedBuffer{limit: 5}
+
+	n, err := b.Write([]byte("abc"))
+	if n != 3 || err != nil {
+		t.Fatalf("unexpected write result: %d, %v", n, err)
+	}
+	n, err = b.Write([]byte("defg"))
+	if n != 4 || err != nil {
+		t.Fatalf("expected overflowing write to report full length, got %d, %v", n, err)
+	}
+
+	if b.String() != "abcde" {
+		t.Errorf("expected %q, got %q", "abcde", b.String())
+	}
+	if !b.truncated {
+		t.Error("expected buffer to be truncated")
+	}
+}
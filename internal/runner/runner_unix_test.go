@@ -0,0 +1,56 @@
+//go:build unix
+
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// processGone reports whether the process with pid has exited. Processes
+// that exited but were not reaped yet count as gone.
+func processGone(pid int) bool {
+	if err := syscall.Kill(pid, 0); errors.Is(err, syscall.ESRCH) {
+		return true
+	}
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return false
+	}
+	// The state follows the command name in parentheses, e.g. "42 (sleep) Z"
+	fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+	return len(fields) > 0 && fields[0] == "Z"
+}
+
+func TestRun_processGroup(t *testing.T) {
+	t.Run("kills processes started by the snippet after the timeout", func(t *testing.T) {
+		requireShell(t)
+
+		result, err := Run([]string{"sh"}, ".sh", "sleep 30 &\necho $!\nwait", nil, 200*time.Millisecond)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.TimedOut {
+			t.Fatal("expected timeout")
+		}
+
+		pid, err := strconv.Atoi(strings.TrimSpace(result.Stdout))
+		if err != nil {
+			t.Fatalf("expected the child PID, got %q", result.Stdout)
+		}
+		deadline := time.Now().Add(2 * time.Second)
+		for !processGone(pid) {
+			if time.Now().After(deadline) {
+				syscall.Kill(pid, syscall.SIGKILL)
+				t.Fatalf("expected child process %d to be killed", pid)
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	})
+}
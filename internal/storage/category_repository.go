@@ -1,6 +1,10 @@
 package storage
 
-import "github.com/7-Dany/snip/internal/domain"
+import (
+	"fmt"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
 
 // categoryRepository implements domain.CategoryRepository using the internal store.
 type categoryRepository struct {
@@ -36,6 +40,8 @@ func (r *categoryRepository) FindByID(id int) (*domain.Category, error) {
 }
 
 // FindByName finds a category by its name.
+// Subcategories may share a name under different parents; in that case the
+// first match is returned and FindByPath should be used instead.
 func (r *categoryRepository) FindByName(name string) (*domain.Category, error) {
 	r.store.mu.Lock()
 	defer r.store.mu.Unlock()
@@ -48,15 +54,31 @@ func (r *categoryRepository) FindByName(name string) (*domain.Category, error) {
 	return nil, ErrNotFound
 }
 
+// FindByPath finds a category by its slash-separated path, e.g. "backend/db/postgres".
+func (r *categoryRepository) FindByPath(path string) (*domain.Category, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if category := domain.NewCategoryTree(r.store.categories).FindByPath(path); category != nil {
+		return category, nil
+	}
+	return nil, ErrNotFound
+}
+
 // Create adds a new category and assigns it an ID.
-// Returns ErrDuplicateName if a category with the same name exists.
+// Returns ErrDuplicateName if a sibling category with the same name exists,
+// or ErrInvalidParent if the parent category does not exist.
 func (r *categoryRepository) Create(category *domain.Category) error {
 	r.store.mu.Lock()
 	defer r.store.mu.Unlock()
 
-	// Check for duplicate name
+	if !r.parentExists(category.ParentID()) {
+		return ErrInvalidParent
+	}
+
+	// Check for duplicate name among siblings
 	for _, existing := range r.store.categories {
-		if existing.Name() == category.Name() {
+		if existing.Name() == category.Name() && existing.ParentID() == category.ParentID() {
 			return ErrDuplicateName
 		}
 	}
@@ -68,16 +90,15 @@ func (r *categoryRepository) Create(category *domain.Category) error {
 }
 
 // Update replaces an existing category.
-// Returns ErrDuplicateName if another category with the same name exists.
+// Returns ErrDuplicateName if another sibling category with the same name exists,
+// ErrInvalidParent if the parent category does not exist, or
+// domain.ErrCategoryCycle if the new parent is the category or one of its descendants.
 func (r *categoryRepository) Update(category *domain.Category) error {
 	r.store.mu.Lock()
 	defer r.store.mu.Unlock()
 
-	// Check for duplicate name (excluding current category)
-	for _, existing := range r.store.categories {
-		if existing.Name() == category.Name() && existing.ID() != category.ID() {
-			return ErrDuplicateName
-		}
+	if err := r.checkPlacement(category.ID(), category.Name(), category.ParentID()); err != nil {
+		return err
 	}
 
 	for i, existing := range r.store.categories {
@@ -89,16 +110,92 @@ func (r *categoryRepository) Update(category *domain.Category) error {
 	return ErrNotFound
 }
 
+// Move moves a category under another one, or to the top level with
+// parentID 0. The move is checked like Update before the category is
+// changed, so a failed move leaves the store unchanged.
+func (r *categoryRepository) Move(id, parentID int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, category := range r.store.categories {
+		if category.ID() == id {
+			if err := r.checkPlacement(id, category.Name(), parentID); err != nil {
+				return err
+			}
+			return category.SetParent(parentID)
+		}
+	}
+	return ErrNotFound
+}
+
 // Delete removes a category by ID.
+// Direct subcategories are moved up to the deleted category's parent.
+// Returns ErrDuplicateName, and deletes nothing, if a subcategory has the
+// same name as a category already under that parent.
 func (r *categoryRepository) Delete(id int) error {
 	r.store.mu.Lock()
 	defer r.store.mu.Unlock()
 
 	for i, category := range r.store.categories {
-		if category.ID() == id {
-			r.store.categories = append(r.store.categories[:i], r.store.categories[i+1:]...)
-			return nil
+		if category.ID() != id {
+			continue
+		}
+		for _, child := range r.store.categories {
+			if child.ParentID() != id {
+				continue
+			}
+			if r.siblingExists(child.ID(), child.Name(), category.ParentID()) {
+				return fmt.Errorf("%w: subcategory '%s' would clash with a category under the same parent; rename or move it first", ErrDuplicateName, child.Name())
+			}
 		}
+
+		r.store.categories = append(r.store.categories[:i], r.store.categories[i+1:]...)
+		for _, child := range r.store.categories {
+			if child.ParentID() == id {
+				child.SetParent(category.ParentID())
+			}
+		}
+		return nil
 	}
 	return ErrNotFound
 }
+
+// checkPlacement reports whether the category with the given ID may be named
+// name under parentID. The caller must hold the store lock.
+func (r *categoryRepository) checkPlacement(id int, name string, parentID int) error {
+	if !r.parentExists(parentID) {
+		return ErrInvalidParent
+	}
+	if err := domain.NewCategoryTree(r.store.categories).CheckParent(id, parentID); err != nil {
+		return err
+	}
+	if r.siblingExists(id, name, parentID) {
+		return ErrDuplicateName
+	}
+	return nil
+}
+
+// siblingExists reports whether a category other than id is named name
+// under parentID. The caller must hold the store lock.
+func (r *categoryRepository) siblingExists(id int, name string, parentID int) bool {
+	for _, existing := range r.store.categories {
+		if existing.Name() == name && existing.ParentID() == parentID && existing.ID() != id {
+			return true
+		}
+	}
+	return false
+}
+
+// parentExists reports whether parentID is 0 (no parent) or refers to a stored category.
+// The caller must hold the store lock.
+func (r *categoryRepository) parentExists(parentID int) bool {
+	if parentID == 0 {
+		return true
+	}
+	for _, existing := range r.store.categories {
+		if existing.ID() == parentID {
+			return true
+		}
+	}
+	return false
+}
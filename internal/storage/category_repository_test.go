@@ -1,7 +1,10 @@
 package storage
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
 )
 
 func TestCategoryRepository_List(t *testing.T) {
@@ -131,6 +134,63 @@ func TestCategoryRepository_FindByName(t *testing.T) {
 	})
 }
 
+func TestCategoryRepository_FindByPath(t *testing.T) {
+	t.Run("finds nested category", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		backend := mustCreateCategory(t, "backend")
+		repo.Create(backend)
+		db := mustCreateCategory(t, "db")
+		db.SetParent(backend.ID())
+		repo.Create(db)
+		postgres := mustCreateCategory(t, "postgres")
+		postgres.SetParent(db.ID())
+		repo.Create(postgres)
+
+		found, err := repo.FindByPath("backend/db/postgres")
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if found.ID() != postgres.ID() {
+			t.Errorf("expected ID %d, got %d", postgres.ID(), found.ID())
+		}
+	})
+
+	t.Run("finds top-level category", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		category := mustCreateCategory(t, "algorithms")
+		repo.Create(category)
+
+		found, err := repo.FindByPath("algorithms")
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if found.ID() != category.ID() {
+			t.Errorf("expected ID %d, got %d", category.ID(), found.ID())
+		}
+	})
+
+	t.Run("returns ErrNotFound for unknown path", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		repo.Create(mustCreateCategory(t, "backend"))
+
+		_, err := repo.FindByPath("backend/db")
+
+		if err != ErrNotFound {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
 func TestCategoryRepository_Create(t *testing.T) {
 	t.Run("assigns ID to category", func(t *testing.T) {
 		s := newStore("test.json")
@@ -210,6 +270,62 @@ func TestCategoryRepository_Create(t *testing.T) {
 			t.Errorf("expected ErrDuplicateName, got %v", err)
 		}
 	})
+
+	t.Run("allows same name under different parents", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		backend := mustCreateCategory(t, "backend")
+		frontend := mustCreateCategory(t, "frontend")
+		repo.Create(backend)
+		repo.Create(frontend)
+
+		db1 := mustCreateCategory(t, "db")
+		db1.SetParent(backend.ID())
+		db2 := mustCreateCategory(t, "db")
+		db2.SetParent(frontend.ID())
+
+		if err := repo.Create(db1); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if err := repo.Create(db2); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("returns ErrDuplicateName for duplicate sibling", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		backend := mustCreateCategory(t, "backend")
+		repo.Create(backend)
+
+		db1 := mustCreateCategory(t, "db")
+		db1.SetParent(backend.ID())
+		repo.Create(db1)
+
+		db2 := mustCreateCategory(t, "db")
+		db2.SetParent(backend.ID())
+		err := repo.Create(db2)
+
+		if err != ErrDuplicateName {
+			t.Errorf("expected ErrDuplicateName, got %v", err)
+		}
+	})
+
+	t.Run("returns ErrInvalidParent for nonexistent parent", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		category := mustCreateCategory(t, "orphan")
+		category.SetParent(42)
+
+		err := repo.Create(category)
+
+		if err != ErrInvalidParent {
+			t.Errorf("expected ErrInvalidParent, got %v", err)
+		}
+	})
 }
 
 func TestCategoryRepository_Update(t *testing.T) {
@@ -281,6 +397,113 @@ func TestCategoryRepository_Update(t *testing.T) {
 	})
 }
 
+func TestCategoryRepository_UpdateParent(t *testing.T) {
+	t.Run("moves category under another", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		backend := mustCreateCategory(t, "backend")
+		db := mustCreateCategory(t, "db")
+		repo.Create(backend)
+		repo.Create(db)
+
+		db.SetParent(backend.ID())
+		if err := repo.Update(db); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		found, err := repo.FindByPath("backend/db")
+		if err != nil || found.ID() != db.ID() {
+			t.Errorf("expected to find moved category, got %v, %v", found, err)
+		}
+	})
+
+	t.Run("returns ErrCategoryCycle when moving under a descendant", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		backend := mustCreateCategory(t, "backend")
+		repo.Create(backend)
+		db := mustCreateCategory(t, "db")
+		db.SetParent(backend.ID())
+		repo.Create(db)
+		postgres := mustCreateCategory(t, "postgres")
+		postgres.SetParent(db.ID())
+		repo.Create(postgres)
+
+		backend.SetParent(postgres.ID())
+		err := repo.Update(backend)
+
+		if !errors.Is(err, domain.ErrCategoryCycle) {
+			t.Errorf("expected ErrCategoryCycle, got %v", err)
+		}
+	})
+
+	t.Run("returns ErrInvalidParent for nonexistent parent", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		category := mustCreateCategory(t, "db")
+		repo.Create(category)
+
+		category.SetParent(42)
+		err := repo.Update(category)
+
+		if err != ErrInvalidParent {
+			t.Errorf("expected ErrInvalidParent, got %v", err)
+		}
+	})
+}
+
+func TestCategoryRepository_Move(t *testing.T) {
+	t.Run("moves category under another", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		backend := mustCreateCategory(t, "backend")
+		db := mustCreateCategory(t, "db")
+		repo.Create(backend)
+		repo.Create(db)
+
+		if err := repo.Move(db.ID(), backend.ID()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if found, err := repo.FindByPath("backend/db"); err != nil || found != db {
+			t.Errorf("expected to find moved category, got %v, %v", found, err)
+		}
+	})
+
+	t.Run("leaves the category unchanged when the move fails", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		backend := mustCreateCategory(t, "backend")
+		repo.Create(backend)
+		db := mustCreateCategory(t, "db")
+		db.SetParent(backend.ID())
+		repo.Create(db)
+		other := mustCreateCategory(t, "db")
+		repo.Create(other)
+
+		if err := repo.Move(backend.ID(), db.ID()); !errors.Is(err, domain.ErrCategoryCycle) {
+			t.Errorf("expected ErrCategoryCycle, got %v", err)
+		}
+		if err := repo.Move(other.ID(), backend.ID()); err != ErrDuplicateName {
+			t.Errorf("expected ErrDuplicateName, got %v", err)
+		}
+		if err := repo.Move(db.ID(), 42); err != ErrInvalidParent {
+			t.Errorf("expected ErrInvalidParent, got %v", err)
+		}
+		if err := repo.Move(42, 0); err != ErrNotFound {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+
+		if backend.ParentID() != 0 || other.ParentID() != 0 || db.ParentID() != backend.ID() {
+			t.Errorf("expected parents unchanged, got %d, %d, %d", backend.ParentID(), other.ParentID(), db.ParentID())
+		}
+	})
+}
+
 func TestCategoryRepository_Delete(t *testing.T) {
 	t.Run("deletes existing category", func(t *testing.T) {
 		s := newStore("test.json")
@@ -340,6 +563,51 @@ func TestCategoryRepository_Delete(t *testing.T) {
 		}
 	})
 
+	t.Run("moves subcategories up to the deleted category's parent", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		backend := mustCreateCategory(t, "backend")
+		repo.Create(backend)
+		db := mustCreateCategory(t, "db")
+		db.SetParent(backend.ID())
+		repo.Create(db)
+		postgres := mustCreateCategory(t, "postgres")
+		postgres.SetParent(db.ID())
+		repo.Create(postgres)
+
+		if err := repo.Delete(db.ID()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if postgres.ParentID() != backend.ID() {
+			t.Errorf("expected parent ID %d, got %d", backend.ID(), postgres.ParentID())
+		}
+	})
+
+	t.Run("refuses to delete when a subcategory clashes with a sibling", func(t *testing.T) {
+		s := newStore("test.json")
+		repo := newCategoryRepository(s)
+
+		a := mustCreateCategory(t, "a")
+		repo.Create(a)
+		ax := mustCreateCategory(t, "x")
+		ax.SetParent(a.ID())
+		repo.Create(ax)
+		x := mustCreateCategory(t, "x")
+		repo.Create(x)
+
+		if err := repo.Delete(a.ID()); !errors.Is(err, ErrDuplicateName) {
+			t.Fatalf("expected ErrDuplicateName, got %v", err)
+		}
+		if _, err := repo.FindByID(a.ID()); err != nil || ax.ParentID() != a.ID() {
+			t.Errorf("expected nothing deleted or moved, got %v and parent %d", err, ax.ParentID())
+		}
+		if found, err := repo.FindByPath("x"); err != nil || found != x {
+			t.Errorf("expected the root x, got %v, %v", found, err)
+		}
+	})
+
 	t.Run("does not reuse deleted IDs", func(t *testing.T) {
 		s := newStore("test.json")
 		repo := newCategoryRepository(s)
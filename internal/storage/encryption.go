@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Encryption errors.
+var (
+	ErrEncrypted       = errors.New("storage file is encrypted; a passphrase is required")
+	ErrDecryptFailed   = errors.New("cannot decrypt storage file: wrong passphrase or the file was modified")
+	ErrEmptyPassphrase = errors.New("passphrase cannot be empty")
+)
+
+// encryptionFormat identifies encrypted storage files.
+const encryptionFormat = "snip-encrypted-v1"
+
+// kdfName is the key derivation function recorded in encrypted files.
+const kdfName = "pbkdf2-sha256"
+
+// kdfIterations is the PBKDF2 work factor for new keys. Files record their
+// own iteration count, so it can be raised without breaking old files.
+var kdfIterations = 600_000
+
+// maxIterationsFactor bounds the iteration count accepted from a file, as a
+// multiple of kdfIterations, so a corrupted or tampered header cannot stall
+// key derivation before the authentication check catches it.
+const maxIterationsFactor = 10
+
+const (
+	keySize  = 32 // AES-256
+	saltSize = 16
+)
+
+// envelope is the on-disk format of an encrypted storage file. The header
+// fields are authenticated together with the ciphertext.
+type envelope struct {
+	Format     string `json:"format"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// additionalData returns the header bytes bound to the ciphertext, so that
+// changing the KDF parameters is detected like changing the data.
+func (e envelope) additionalData() []byte {
+	return fmt.Appendf(nil, "%s|%s|%d|%x", e.Format, e.KDF, e.Iterations, e.Salt)
+}
+
+// encryptionKey is a key derived from a passphrase, with the parameters
+// needed to derive it again.
+type encryptionKey struct {
+	key        []byte
+	salt       []byte
+	iterations int
+}
+
+// newEncryptionKey derives a key from passphrase with a fresh random salt.
+func newEncryptionKey(passphrase string) (*encryptionKey, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return deriveKey(passphrase, salt, kdfIterations)
+}
+
+// deriveKey derives a key from passphrase with the given parameters.
+func deriveKey(passphrase string, salt []byte, iterations int) (*encryptionKey, error) {
+	if passphrase == "" {
+		return nil, ErrEmptyPassphrase
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, keySize)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptionKey{key: key, salt: salt, iterations: iterations}, nil
+}
+
+// isEncrypted reports whether raw file contents are an encrypted envelope.
+func isEncrypted(raw []byte) bool {
+	if !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		return false
+	}
+	var header struct {
+		Format string `json:"format"`
+	}
+	return json.Unmarshal(raw, &header) == nil && header.Format == encryptionFormat
+}
+
+// encrypt seals plaintext with k into an encrypted envelope.
+func encrypt(plaintext []byte, k *encryptionKey) ([]byte, error) {
+	gcm, err := newGCM(k.key)
+	if err != nil {
+		return nil, err
+	}
+
+	e := envelope{
+		Format:     encryptionFormat,
+		KDF:        kdfName,
+		Iterations: k.iterations,
+		Salt:       k.salt,
+		Nonce:      make([]byte, gcm.NonceSize()),
+	}
+	if _, err := rand.Read(e.Nonce); err != nil {
+		return nil, err
+	}
+	e.Ciphertext = gcm.Seal(nil, e.Nonce, plaintext, e.additionalData())
+
+	return json.MarshalIndent(e, "", "  ")
+}
+
+// decrypt opens an encrypted envelope with passphrase. It returns the
+// plaintext and the key, which is reused for later saves.
+func decrypt(raw []byte, passphrase string) ([]byte, *encryptionKey, error) {
+	var e envelope
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return nil, nil, ErrDecryptFailed
+	}
+	if e.Format != encryptionFormat || e.KDF != kdfName ||
+		e.Iterations <= 0 || e.Iterations > maxIterationsFactor*kdfIterations {
+		return nil, nil, ErrDecryptFailed
+	}
+
+	k, err := deriveKey(passphrase, e.Salt, e.Iterations)
+	if err != nil {
+		return nil, nil, err
+	}
+	gcm, err := newGCM(k.key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(e.Nonce) != gcm.NonceSize() {
+		return nil, nil, ErrDecryptFailed
+	}
+
+	plaintext, err := gcm.Open(nil, e.Nonce, e.Ciphertext, e.additionalData())
+	if err != nil {
+		return nil, nil, ErrDecryptFailed
+	}
+	return plaintext, k, nil
+}
+
+// newGCM returns an AES-GCM cipher for key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
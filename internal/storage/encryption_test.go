@@ -0,0 +1,285 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useFastKDF lowers the PBKDF2 work factor for the duration of a test.
+func useFastKDF(t *testing.T) {
+	t.Helper()
+	previous := kdfIterations
+	kdfIterations = 1000
+	t.Cleanup(func() { kdfIterations = previous })
+}
+
+// newEncryptedFile saves a library with one snippet encrypted with passphrase
+// and returns the file path.
+func newEncryptedFile(t *testing.T, passphrase string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "snippets.json")
+
+	repos := New(path)
+	if err := repos.Snippets.Create(mustCreateSnippet(t, "deploy", "bash", "echo top-secret")); err != nil {
+		t.Fatalf("failed to create snippet: %v", err)
+	}
+	if err := repos.Encrypt(passphrase); err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+	return path
+}
+
+// loadWith loads the file at path with passphrase.
+func loadWith(path, passphrase string) (*Repositories, error) {
+	repos := New(path)
+	repos.SetPassphrase(passphrase)
+	return repos, repos.Load()
+}
+
+// tamper rewrites one field of the encrypted envelope at path.
+func tamper(t *testing.T, path string, edit func(e *envelope)) {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	var e envelope
+	if err := json.Unmarshal(raw, &e); err != nil {
+		t.Fatalf("failed to parse envelope: %v", err)
+	}
+	edit(&e)
+	raw, _ = json.Marshal(e)
+	if err := os.WriteFile(path, raw, 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+func TestRepositories_Encrypt(t *testing.T) {
+	t.Run("encrypted data round-trips", func(t *testing.T) {
+		useFastKDF(t)
+		path := newEncryptedFile(t, "correct horse")
+
+		repos, err := loadWith(path, "correct horse")
+		if err != nil {
+			t.Fatalf("failed to load: %v", err)
+		}
+		if !repos.Encrypted() {
+			t.Error("expected loaded repositories to stay encrypted")
+		}
+		snippet, err := repos.Snippets.FindByID(1)
+		if err != nil || snippet.Code() != "echo top-secret" {
+			t.Errorf("expected snippet to be decrypted, got %v, %v", snippet, err)
+		}
+	})
+
+	t.Run("file holds no plain text and is private", func(t *testing.T) {
+		useFastKDF(t)
+		path := newEncryptedFile(t, "correct horse")
+
+		raw, _ := os.ReadFile(path)
+		if strings.Contains(string(raw), "top-secret") || strings.Contains(string(raw), "deploy") {
+			t.Error("expected encrypted file to hide snippet data")
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("failed to stat file: %v", err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("expected mode 0600, got %v", info.Mode().Perm())
+		}
+		if encrypted, err := IsEncrypted(path); err != nil || !encrypted {
+			t.Errorf("expected IsEncrypted to be true, got %v, %v", encrypted, err)
+		}
+	})
+
+	t.Run("later saves stay encrypted", func(t *testing.T) {
+		useFastKDF(t)
+		path := newEncryptedFile(t, "correct horse")
+
+		repos, err := loadWith(path, "correct horse")
+		if err != nil {
+			t.Fatalf("failed to load: %v", err)
+		}
+		if err := repos.Snippets.Create(mustCreateSnippet(t, "second", "go", "package main")); err != nil {
+			t.Fatalf("failed to create snippet: %v", err)
+		}
+		if err := repos.Save(); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+
+		if encrypted, _ := IsEncrypted(path); !encrypted {
+			t.Error("expected file to stay encrypted after save")
+		}
+		reloaded, err := loadWith(path, "correct horse")
+		if err != nil {
+			t.Fatalf("failed to reload: %v", err)
+		}
+		if snippets, _ := reloaded.Snippets.List(); len(snippets) != 2 {
+			t.Errorf("expected 2 snippets, got %d", len(snippets))
+		}
+	})
+
+	t.Run("changes the passphrase", func(t *testing.T) {
+		useFastKDF(t)
+		path := newEncryptedFile(t, "old passphrase")
+
+		repos, _ := loadWith(path, "old passphrase")
+		if err := repos.Encrypt("new passphrase"); err != nil {
+			t.Fatalf("failed to re-encrypt: %v", err)
+		}
+
+		if _, err := loadWith(path, "old passphrase"); !errors.Is(err, ErrDecryptFailed) {
+			t.Errorf("expected old passphrase to fail, got %v", err)
+		}
+		if _, err := loadWith(path, "new passphrase"); err != nil {
+			t.Errorf("expected new passphrase to work, got %v", err)
+		}
+	})
+
+	t.Run("rejects an empty passphrase", func(t *testing.T) {
+		repos := New(filepath.Join(t.TempDir(), "snippets.json"))
+		if err := repos.Encrypt(""); !errors.Is(err, ErrEmptyPassphrase) {
+			t.Errorf("expected ErrEmptyPassphrase, got %v", err)
+		}
+		if repos.Encrypted() {
+			t.Error("expected repositories to stay unencrypted")
+		}
+	})
+}
+
+func TestRepositories_Decrypt(t *testing.T) {
+	useFastKDF(t)
+	path := newEncryptedFile(t, "correct horse")
+
+	repos, _ := loadWith(path, "correct horse")
+	if err := repos.Decrypt(); err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+
+	if encrypted, _ := IsEncrypted(path); encrypted {
+		t.Error("expected file to be plain after Decrypt")
+	}
+	plain := New(path)
+	if err := plain.Load(); err != nil {
+		t.Fatalf("failed to load plain file: %v", err)
+	}
+	if snippet, err := plain.Snippets.FindByID(1); err != nil || snippet.Code() != "echo top-secret" {
+		t.Errorf("expected snippet in plain file, got %v, %v", snippet, err)
+	}
+}
+
+func TestLoad_Encrypted(t *testing.T) {
+	t.Run("requires a passphrase", func(t *testing.T) {
+		useFastKDF(t)
+		path := newEncryptedFile(t, "correct horse")
+
+		if err := New(path).Load(); !errors.Is(err, ErrEncrypted) {
+			t.Errorf("expected ErrEncrypted, got %v", err)
+		}
+	})
+
+	t.Run("rejects a wrong passphrase", func(t *testing.T) {
+		useFastKDF(t)
+		path := newEncryptedFile(t, "correct horse")
+
+		if _, err := loadWith(path, "battery staple"); !errors.Is(err, ErrDecryptFailed) {
+			t.Errorf("expected ErrDecryptFailed, got %v", err)
+		}
+	})
+
+	t.Run("detects a modified ciphertext", func(t *testing.T) {
+		useFastKDF(t)
+		path := newEncryptedFile(t, "correct horse")
+		tamper(t, path, func(e *envelope) { e.Ciphertext[len(e.Ciphertext)/2] ^= 0x01 })
+
+		if _, err := loadWith(path, "correct horse"); !errors.Is(err, ErrDecryptFailed) {
+			t.Errorf("expected ErrDecryptFailed, got %v", err)
+		}
+	})
+
+	t.Run("detects a truncated ciphertext", func(t *testing.T) {
+		useFastKDF(t)
+		path := newEncryptedFile(t, "correct horse")
+		tamper(t, path, func(e *envelope) { e.Ciphertext = e.Ciphertext[:len(e.Ciphertext)-1] })
+
+		if _, err := loadWith(path, "correct horse"); !errors.Is(err, ErrDecryptFailed) {
+			t.Errorf("expected ErrDecryptFailed, got %v", err)
+		}
+	})
+
+	t.Run("detects a modified nonce", func(t *testing.T) {
+		useFastKDF(t)
+		path := newEncryptedFile(t, "correct horse")
+		tamper(t, path, func(e *envelope) { e.Nonce[0] ^= 0x01 })
+
+		if _, err := loadWith(path, "correct horse"); !errors.Is(err, ErrDecryptFailed) {
+			t.Errorf("expected ErrDecryptFailed, got %v", err)
+		}
+	})
+
+	t.Run("detects modified key derivation parameters", func(t *testing.T) {
+		useFastKDF(t)
+		path := newEncryptedFile(t, "correct horse")
+		tamper(t, path, func(e *envelope) { e.Iterations++ })
+
+		if _, err := loadWith(path, "correct horse"); !errors.Is(err, ErrDecryptFailed) {
+			t.Errorf("expected ErrDecryptFailed after changing iterations, got %v", err)
+		}
+
+		path = newEncryptedFile(t, "correct horse")
+		tamper(t, path, func(e *envelope) { e.Salt[0] ^= 0x01 })
+
+		if _, err := loadWith(path, "correct horse"); !errors.Is(err, ErrDecryptFailed) {
+			t.Errorf("expected ErrDecryptFailed after changing salt, got %v", err)
+		}
+	})
+
+	t.Run("rejects excessive iteration counts before deriving a key", func(t *testing.T) {
+		useFastKDF(t)
+		path := newEncryptedFile(t, "correct horse")
+		tamper(t, path, func(e *envelope) { e.Iterations = math.MaxInt32 })
+
+		if _, err := loadWith(path, "correct horse"); !errors.Is(err, ErrDecryptFailed) {
+			t.Errorf("expected ErrDecryptFailed for a huge iteration count, got %v", err)
+		}
+	})
+
+	t.Run("plain files ignore the passphrase", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snippets.json")
+		plain := New(path)
+		if err := plain.Snippets.Create(mustCreateSnippet(t, "deploy", "bash", "echo hi")); err != nil {
+			t.Fatalf("failed to create snippet: %v", err)
+		}
+
+		repos, err := loadWith(path, "correct horse")
+		if err != nil {
+			t.Fatalf("failed to load plain file: %v", err)
+		}
+		if repos.Encrypted() {
+			t.Error("expected plain file to stay unencrypted")
+		}
+	})
+}
+
+func TestIsEncrypted(t *testing.T) {
+	t.Run("missing file is not encrypted", func(t *testing.T) {
+		encrypted, err := IsEncrypted(filepath.Join(t.TempDir(), "missing.json"))
+		if err != nil || encrypted {
+			t.Errorf("expected false, nil; got %v, %v", encrypted, err)
+		}
+	})
+
+	t.Run("plain JSON is not encrypted", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snippets.json")
+		os.WriteFile(path, []byte(`{"snippets": []}`), 0644)
+
+		if encrypted, _ := IsEncrypted(path); encrypted {
+			t.Error("expected plain JSON not to be encrypted")
+		}
+	})
+}
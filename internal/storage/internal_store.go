@@ -3,8 +3,10 @@ package storage
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/7-Dany/snip/internal/domain"
 )
@@ -13,6 +15,9 @@ import (
 var (
 	ErrNotFound      = errors.New("entity not found")
 	ErrDuplicateName = errors.New("entity with this name already exists")
+	ErrInvalidParent = errors.New("parent category does not exist")
+	ErrIDOutOfRange  = errors.New("ID is outside the library's ID range")
+	ErrReadOnly      = errors.New("library is read-only")
 )
 
 // store is the internal data structure for all entities.
@@ -30,6 +35,14 @@ type store struct {
 	nextSnippetID  int
 	nextCategoryID int
 	nextTagID      int
+	idBase         int // IDs start above it, for libraries stacked over another
+
+	readOnly bool      // Never saved; IDs in the file are shifted by idBase
+	modTime  time.Time // Modification time of the file when loaded
+	stale    bool      // Reload on the next refresh, after a rejected write
+
+	passphrase string         // Unlocks an encrypted file on load
+	key        *encryptionKey // Encrypts saved data; nil saves plain JSON
 }
 
 // data is the JSON structure for persistence.
@@ -58,6 +71,10 @@ func newStore(filepath string) *store {
 // save persists all data to the JSON file atomically.
 func (s *store) save() error {
 	s.mu.RLock()
+	if s.readOnly {
+		s.mu.RUnlock()
+		return nil
+	}
 	s.idMu.Lock()
 
 	d := data{
@@ -69,6 +86,8 @@ func (s *store) save() error {
 		NextTagID:      s.nextTagID,
 	}
 
+	key := s.key
+
 	s.idMu.Unlock()
 	s.mu.RUnlock()
 
@@ -77,8 +96,19 @@ func (s *store) save() error {
 		return err
 	}
 
+	perm := os.FileMode(0644)
+	if key != nil {
+		if jsonData, err = encrypt(jsonData, key); err != nil {
+			return err
+		}
+		perm = 0600
+	}
+
 	tmpFile := s.filepath + ".tmp"
-	if err := os.WriteFile(tmpFile, jsonData, 0644); err != nil {
+	if err := os.WriteFile(tmpFile, jsonData, perm); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpFile, perm); err != nil {
 		return err
 	}
 
@@ -86,24 +116,49 @@ func (s *store) save() error {
 }
 
 // load reads all data from the JSON file into memory.
-// If the file doesn't exist, this is not an error.
+// If the file doesn't exist, this is not an error. An encrypted file is
+// decrypted with the passphrase, and later saves stay encrypted.
 func (s *store) load() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, err := os.Stat(s.filepath); os.IsNotExist(err) {
+	info, err := os.Stat(s.filepath)
+	if os.IsNotExist(err) {
 		return nil
 	}
+	if err == nil {
+		s.modTime = info.ModTime()
+	}
 
 	jsonData, err := os.ReadFile(s.filepath)
 	if err != nil {
 		return err
 	}
 
+	if isEncrypted(jsonData) {
+		if s.passphrase == "" {
+			return ErrEncrypted
+		}
+		var key *encryptionKey
+		if jsonData, key, err = decrypt(jsonData, s.passphrase); err != nil {
+			return err
+		}
+		s.key = key
+	}
+
+	if s.readOnly {
+		if jsonData, err = shiftIDs(jsonData, s.idBase); err != nil {
+			return err
+		}
+	}
+
 	var d data
 	if err := json.Unmarshal(jsonData, &d); err != nil {
 		return err
 	}
+	if err := s.checkIDRange(&d); err != nil {
+		return err
+	}
 
 	s.snippets = d.Snippets
 	s.categories = d.Categories
@@ -128,6 +183,109 @@ func (s *store) load() error {
 	return nil
 }
 
+// open loads the store and normalizes snippet languages stored under an
+// alias, saving the file if any changed. Read-only stores are normalized in
+// memory only.
+func (s *store) open() error {
+	if err := s.load(); err != nil {
+		return err
+	}
+	if s.normalizeLanguages() {
+		return s.save()
+	}
+	return nil
+}
+
+// checkIDRange reports an error if loaded data has IDs outside the store's
+// ID range, and moves the ID counters above its base.
+func (s *store) checkIDRange(d *data) error {
+	limit := idLimit(s.idBase)
+	outside := func(id int) bool {
+		return (s.idBase > 0 && id <= s.idBase) || (limit > 0 && id > limit)
+	}
+	for _, snippet := range d.Snippets {
+		if outside(snippet.ID()) {
+			return fmt.Errorf("%s: snippet %d: %w", s.filepath, snippet.ID(), ErrIDOutOfRange)
+		}
+	}
+	for _, category := range d.Categories {
+		if outside(category.ID()) {
+			return fmt.Errorf("%s: category %d: %w", s.filepath, category.ID(), ErrIDOutOfRange)
+		}
+	}
+	for _, tag := range d.Tags {
+		if outside(tag.ID()) {
+			return fmt.Errorf("%s: tag %d: %w", s.filepath, tag.ID(), ErrIDOutOfRange)
+		}
+	}
+	if s.idBase == 0 {
+		return nil
+	}
+	d.NextSnippetID = max(d.NextSnippetID, s.idBase+1)
+	d.NextCategoryID = max(d.NextCategoryID, s.idBase+1)
+	d.NextTagID = max(d.NextTagID, s.idBase+1)
+	return nil
+}
+
+// idLimit returns the highest ID of a library numbered above idBase, which
+// is the ID base of the next library that can be stacked over it, or 0 if
+// there is none.
+func idLimit(idBase int) int {
+	switch {
+	case idBase < ProjectIDBase:
+		return ProjectIDBase
+	case idBase < TeamIDBase:
+		return TeamIDBase
+	}
+	return 0
+}
+
+// refresh reloads a read-only store whose file changed since it was loaded,
+// or after a rejected write. If the file can't be loaded, for example while
+// it is being replaced, the data loaded before is kept.
+func (s *store) refresh() {
+	s.mu.RLock()
+	readOnly, stale, modTime := s.readOnly, s.stale, s.modTime
+	s.mu.RUnlock()
+	if !readOnly {
+		return
+	}
+
+	info, err := os.Stat(s.filepath)
+	if err != nil || (!stale && info.ModTime().Equal(modTime)) {
+		return
+	}
+	if s.open() == nil {
+		s.mu.Lock()
+		s.stale = false
+		s.mu.Unlock()
+	}
+}
+
+// reject marks a read-only store stale, so that changes made to its
+// entities in memory are dropped, and returns ErrReadOnly.
+func (s *store) reject() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stale = true
+	return fmt.Errorf("%s: %w", s.filepath, ErrReadOnly)
+}
+
+// normalizeLanguages rewrites snippet languages to their canonical names.
+// It reports whether any snippet changed.
+func (s *store) normalizeLanguages() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := false
+	for _, snippet := range s.snippets {
+		if snippet.NormalizeLanguage() {
+			changed = true
+		}
+	}
+	return changed
+}
+
 // nextSnippetIDAndIncrement returns the next snippet ID and increments the counter.
 // This method is thread-safe and can be called concurrently.
 func (s *store) nextSnippetIDAndIncrement() int {
@@ -0,0 +1,577 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// Origins name the libraries of a layered library.
+const (
+	OriginGlobal  = "global"
+	OriginProject = "project"
+)
+
+// ProjectIDBase is the ID above which a project library numbers its
+// snippets, categories and tags, so they never collide with the IDs of the
+// global library it is stacked over.
+const ProjectIDBase = 100000
+
+// layer is one library of a layered library.
+type layer struct {
+	origin string
+	repos  *Repositories
+}
+
+// NewLayer creates repositories for a library that is stacked over another
+// one with AddLayer. Its IDs start above idBase, and loading a file with
+// IDs at or below it fails with ErrIDOutOfRange.
+func NewLayer(filepath string, idBase int) *Repositories {
+	r := New(filepath)
+	r.store.idBase = idBase
+	r.store.nextSnippetID = idBase + 1
+	r.store.nextCategoryID = idBase + 1
+	r.store.nextTagID = idBase + 1
+	return r
+}
+
+// AddLayer stacks a library created with NewLayer or NewReadOnlyLayer
+// over r. Afterwards r's repositories list the entities of all libraries,
+// topmost first, and route changes by ID to the library that holds the
+// entity; new entities go to the global library. Load and Save cover every
+// library, while encryption applies to the global one only.
+func (r *Repositories) AddLayer(origin string, repos *Repositories) {
+	if len(r.layers) == 0 {
+		global := &Repositories{Snippets: r.Snippets, Categories: r.Categories, Tags: r.Tags, store: r.store}
+		r.layers = []layer{{origin: OriginGlobal, repos: global}}
+	}
+	r.layers = append([]layer{{origin: origin, repos: repos}}, r.layers...)
+
+	r.Snippets = &layeredSnippetRepository{layers: r.layers, repos: r}
+	r.Categories = &layeredCategoryRepository{layers: r.layers}
+	r.Tags = &layeredTagRepository{layers: r.layers}
+}
+
+// Layered reports whether libraries are stacked over the global one.
+func (r *Repositories) Layered() bool {
+	return len(r.layers) > 0
+}
+
+// Layer returns the repositories of one library of r, or nil if r has no
+// library with that origin. Without layers, r is the global library.
+func (r *Repositories) Layer(origin string) *Repositories {
+	if !r.Layered() && origin == OriginGlobal {
+		return r
+	}
+	for _, l := range r.layers {
+		if l.origin == origin {
+			return l.repos
+		}
+	}
+	return nil
+}
+
+// Origin returns the origin of the library a snippet, category or tag ID
+// belongs to.
+func (r *Repositories) Origin(id int) string {
+	if !r.Layered() {
+		return OriginGlobal
+	}
+	return layerOf(r.layers, id).origin
+}
+
+// Adopt creates snippet in r. Its category and tags may belong to another
+// library of from; they are replaced by r's category with the same path and
+// r's tags with the same names, created if needed, so that r stays
+// self-contained.
+func (r *Repositories) Adopt(snippet *domain.Snippet, from *Repositories) error {
+	if err := r.localize(snippet, from); err != nil {
+		return err
+	}
+	return r.Snippets.Create(snippet)
+}
+
+// localize replaces the category and tags of snippet that r does not hold
+// like Adopt does, without storing the snippet.
+func (r *Repositories) localize(snippet *domain.Snippet, from *Repositories) error {
+	categories, tags, err := r.localIDs([]*domain.Snippet{snippet}, from)
+	if err != nil {
+		return err
+	}
+	remap(snippet, categories, tags)
+	return nil
+}
+
+// localIDs maps the category and tag IDs of snippets that r does not hold
+// to r's category with the same path and r's tags with the same names,
+// created if needed. IDs that from does not know either map to 0.
+func (r *Repositories) localIDs(snippets []*domain.Snippet, from *Repositories) (map[int]int, map[int]int, error) {
+	categories := make(map[int]int)
+	tags := make(map[int]int)
+	for _, snippet := range snippets {
+		if id := snippet.CategoryID(); id > 0 {
+			if _, done := categories[id]; !done {
+				if _, err := r.Categories.FindByID(id); errors.Is(err, ErrNotFound) {
+					local, err := r.adoptCategory(id, from)
+					if err != nil {
+						return nil, nil, err
+					}
+					categories[id] = local
+				}
+			}
+		}
+
+		for _, id := range snippet.Tags() {
+			if _, done := tags[id]; done {
+				continue
+			}
+			if _, err := r.Tags.FindByID(id); !errors.Is(err, ErrNotFound) {
+				continue
+			}
+			local, err := r.adoptTag(id, from)
+			if err != nil {
+				return nil, nil, err
+			}
+			tags[id] = local
+		}
+	}
+	return categories, tags, nil
+}
+
+// remap replaces the category and tag IDs of snippet that have an entry in
+// categories and tags. IDs mapped to 0 are removed.
+func remap(snippet *domain.Snippet, categories, tags map[int]int) {
+	if local, ok := categories[snippet.CategoryID()]; ok {
+		snippet.SetCategory(local)
+	}
+	for _, id := range snippet.Tags() {
+		if local, ok := tags[id]; ok {
+			snippet.RemoveTag(id)
+			if local > 0 {
+				snippet.AddTag(local)
+			}
+		}
+	}
+}
+
+// adoptTag returns the ID of r's tag with the name of tag id in from,
+// creating it if needed. It returns 0 if from has no such tag.
+func (r *Repositories) adoptTag(id int, from *Repositories) (int, error) {
+	tag, err := from.Tags.FindByID(id)
+	if err != nil {
+		return 0, nil
+	}
+	local, err := r.Tags.FindByName(tag.Name())
+	if errors.Is(err, ErrNotFound) {
+		if local, err = domain.NewTag(tag.Name()); err == nil {
+			err = r.Tags.Create(local)
+		}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("tag %q: %w", tag.Name(), err)
+	}
+	return local.ID(), nil
+}
+
+// adoptCategory returns the ID of r's category with the path of category
+// id in from, creating the missing categories along the path. It returns 0
+// if from has no such category.
+func (r *Repositories) adoptCategory(id int, from *Repositories) (int, error) {
+	categories, err := from.Categories.List()
+	if err != nil {
+		return 0, err
+	}
+	tree := domain.NewCategoryTree(categories)
+	category, err := from.Categories.FindByID(id)
+	if err != nil {
+		return 0, nil
+	}
+	path := append(tree.Ancestors(id), category)
+
+	local, err := r.Categories.List()
+	if err != nil {
+		return 0, err
+	}
+	parentID := 0
+	for _, c := range path {
+		found := 0
+		for _, existing := range local {
+			if existing.Name() == c.Name() && existing.ParentID() == parentID {
+				found = existing.ID()
+				break
+			}
+		}
+		if found == 0 {
+			created, err := domain.NewCategory(c.Name())
+			if err != nil {
+				return 0, err
+			}
+			if err := created.SetParent(parentID); err != nil {
+				return 0, err
+			}
+			if err := r.Categories.Create(created); err != nil {
+				return 0, fmt.Errorf("category %q: %w", c.Name(), err)
+			}
+			found = created.ID()
+		}
+		parentID = found
+	}
+	return parentID, nil
+}
+
+// layerOf returns the layer that holds an ID: the one with the highest ID
+// base below it.
+func layerOf(layers []layer, id int) layer {
+	owner := layers[len(layers)-1]
+	for _, l := range layers {
+		if base := l.repos.store.idBase; base < id && base > owner.repos.store.idBase {
+			owner = l
+		}
+	}
+	return owner
+}
+
+// globalLayer returns the layer that receives new entities.
+func globalLayer(layers []layer) layer {
+	return layers[len(layers)-1]
+}
+
+// layeredSnippetRepository implements domain.SnippetRepository over the
+// snippet repositories of several libraries.
+type layeredSnippetRepository struct {
+	layers []layer
+	repos  *Repositories // The layered repositories, to resolve IDs of any library
+}
+
+// merge collects the snippets find returns for every library, topmost
+// library first.
+func (r *layeredSnippetRepository) merge(find func(domain.SnippetRepository) ([]*domain.Snippet, error)) ([]*domain.Snippet, error) {
+	refreshLayers(r.layers)
+	result := make([]*domain.Snippet, 0)
+	for _, l := range r.layers {
+		snippets, err := find(l.repos.Snippets)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, snippets...)
+	}
+	return result, nil
+}
+
+// List returns the snippets of all libraries.
+func (r *layeredSnippetRepository) List() ([]*domain.Snippet, error) {
+	return r.merge(domain.SnippetRepository.List)
+}
+
+// FindByID finds a snippet by its ID in the library that holds it.
+func (r *layeredSnippetRepository) FindByID(id int) (*domain.Snippet, error) {
+	refreshLayers(r.layers)
+	return layerOf(r.layers, id).repos.Snippets.FindByID(id)
+}
+
+// FindByCategory finds the snippets of all libraries in a category,
+// including its subcategories.
+func (r *layeredSnippetRepository) FindByCategory(categoryID int) ([]*domain.Snippet, error) {
+	return r.merge(func(repo domain.SnippetRepository) ([]*domain.Snippet, error) {
+		return repo.FindByCategory(categoryID)
+	})
+}
+
+// FindByTag finds the snippets of all libraries with a tag.
+func (r *layeredSnippetRepository) FindByTag(tagID int) ([]*domain.Snippet, error) {
+	return r.merge(func(repo domain.SnippetRepository) ([]*domain.Snippet, error) {
+		return repo.FindByTag(tagID)
+	})
+}
+
+// FindByLanguage finds the snippets of all libraries with a language.
+func (r *layeredSnippetRepository) FindByLanguage(language string) ([]*domain.Snippet, error) {
+	return r.merge(func(repo domain.SnippetRepository) ([]*domain.Snippet, error) {
+		return repo.FindByLanguage(language)
+	})
+}
+
+// FindFavorites finds the favorite snippets of all libraries.
+func (r *layeredSnippetRepository) FindFavorites() ([]*domain.Snippet, error) {
+	return r.merge(domain.SnippetRepository.FindFavorites)
+}
+
+// FindPinned finds the pinned snippets of all libraries.
+func (r *layeredSnippetRepository) FindPinned() ([]*domain.Snippet, error) {
+	return r.merge(domain.SnippetRepository.FindPinned)
+}
+
+// FindRecent finds used snippets of all libraries, most recently used
+// first. A limit of 0 or less returns all used snippets.
+func (r *layeredSnippetRepository) FindRecent(limit int) ([]*domain.Snippet, error) {
+	result, err := r.merge(func(repo domain.SnippetRepository) ([]*domain.Snippet, error) {
+		return repo.FindRecent(limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortRecent(result)
+	return applyLimit(result, limit), nil
+}
+
+// FindMostUsed finds used snippets of all libraries, most used first.
+// A limit of 0 or less returns all used snippets.
+func (r *layeredSnippetRepository) FindMostUsed(limit int) ([]*domain.Snippet, error) {
+	result, err := r.merge(func(repo domain.SnippetRepository) ([]*domain.Snippet, error) {
+		return repo.FindMostUsed(limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortMostUsed(result)
+	return applyLimit(result, limit), nil
+}
+
+// FindDuplicates finds the snippets of all libraries whose code is
+// identical to code in the same language.
+func (r *layeredSnippetRepository) FindDuplicates(code, language string) ([]*domain.Snippet, error) {
+	return r.merge(func(repo domain.SnippetRepository) ([]*domain.Snippet, error) {
+		return repo.FindDuplicates(code, language)
+	})
+}
+
+// Search finds the snippets of all libraries matching the query.
+func (r *layeredSnippetRepository) Search(query string) ([]*domain.Snippet, error) {
+	return r.merge(func(repo domain.SnippetRepository) ([]*domain.Snippet, error) {
+		return repo.Search(query)
+	})
+}
+
+// Create adds a new snippet to the global library, with a category and
+// tags of other libraries adopted as in Adopt.
+func (r *layeredSnippetRepository) Create(snippet *domain.Snippet) error {
+	return globalLayer(r.layers).repos.Adopt(snippet, r.repos)
+}
+
+// Update replaces an existing snippet in the library that holds it. A
+// category or tags of other libraries are replaced by the library's own,
+// as in Adopt, so no library refers to IDs of another.
+func (r *layeredSnippetRepository) Update(snippet *domain.Snippet) error {
+	owner := layerOf(r.layers, snippet.ID()).repos
+	if _, err := owner.Snippets.FindByID(snippet.ID()); err != nil {
+		return err
+	}
+	if !owner.store.readOnly {
+		if err := owner.localize(snippet, r.repos); err != nil {
+			return err
+		}
+	}
+	return owner.Snippets.Update(snippet)
+}
+
+// Delete removes a snippet by ID from the library that holds it.
+func (r *layeredSnippetRepository) Delete(id int) error {
+	return layerOf(r.layers, id).repos.Snippets.Delete(id)
+}
+
+// BulkUpdate applies edit to every snippet with the given IDs, in the
+// libraries that hold them. Unknown IDs, read-only snippets and failing
+// edits are reported before any library is changed. A category or tags of
+// other libraries set by edit are replaced as in Update.
+func (r *layeredSnippetRepository) BulkUpdate(ids []int, edit domain.SnippetEdit) error {
+	edited := make(map[*Repositories][]*domain.Snippet)
+	for _, id := range ids {
+		snippet, err := r.FindByID(id)
+		if err != nil {
+			return fmt.Errorf("snippet %d: %w", id, err)
+		}
+		owner := layerOf(r.layers, id).repos
+		if owner.store.readOnly {
+			return fmt.Errorf("snippet %d: %w", id, ErrReadOnly)
+		}
+		clone := snippet.Clone()
+		if err := edit(clone); err != nil {
+			return fmt.Errorf("snippet %d: %w", id, err)
+		}
+		edited[owner] = append(edited[owner], clone)
+	}
+
+	for _, l := range r.layers {
+		owned := r.owned(l, ids)
+		if len(owned) == 0 {
+			continue
+		}
+		// Resolve other libraries' IDs before the edit runs, since it
+		// must not call back into the repository
+		categories, tags, err := l.repos.localIDs(edited[l.repos], r.repos)
+		if err != nil {
+			return err
+		}
+		err = l.repos.Snippets.BulkUpdate(owned, func(s *domain.Snippet) error {
+			if err := edit(s); err != nil {
+				return err
+			}
+			remap(s, categories, tags)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BulkDelete removes every snippet with the given IDs from the libraries
+// that hold them. If any ID is unknown or read-only, nothing is deleted.
+func (r *layeredSnippetRepository) BulkDelete(ids []int) error {
+	for _, id := range ids {
+		if _, err := r.FindByID(id); err != nil {
+			return fmt.Errorf("snippet %d: %w", id, err)
+		}
+		if layerOf(r.layers, id).repos.store.readOnly {
+			return fmt.Errorf("snippet %d: %w", id, ErrReadOnly)
+		}
+	}
+	for _, l := range r.layers {
+		if owned := r.owned(l, ids); len(owned) > 0 {
+			if err := l.repos.Snippets.BulkDelete(owned); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// owned returns the IDs held by layer l.
+func (r *layeredSnippetRepository) owned(l layer, ids []int) []int {
+	var owned []int
+	for _, id := range ids {
+		if layerOf(r.layers, id).repos == l.repos {
+			owned = append(owned, id)
+		}
+	}
+	return owned
+}
+
+// layeredCategoryRepository implements domain.CategoryRepository over the
+// category repositories of several libraries.
+type layeredCategoryRepository struct {
+	layers []layer
+}
+
+// List returns the categories of all libraries, topmost library first.
+func (r *layeredCategoryRepository) List() ([]*domain.Category, error) {
+	refreshLayers(r.layers)
+	result := make([]*domain.Category, 0)
+	for _, l := range r.layers {
+		categories, err := l.repos.Categories.List()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, categories...)
+	}
+	return result, nil
+}
+
+// FindByID finds a category by its ID in the library that holds it.
+func (r *layeredCategoryRepository) FindByID(id int) (*domain.Category, error) {
+	refreshLayers(r.layers)
+	return layerOf(r.layers, id).repos.Categories.FindByID(id)
+}
+
+// FindByName finds a category by its name, in the topmost library that
+// has one.
+func (r *layeredCategoryRepository) FindByName(name string) (*domain.Category, error) {
+	refreshLayers(r.layers)
+	for _, l := range r.layers {
+		if category, err := l.repos.Categories.FindByName(name); !errors.Is(err, ErrNotFound) {
+			return category, err
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// FindByPath finds a category by its path, in the topmost library that
+// has one.
+func (r *layeredCategoryRepository) FindByPath(path string) (*domain.Category, error) {
+	refreshLayers(r.layers)
+	for _, l := range r.layers {
+		if category, err := l.repos.Categories.FindByPath(path); !errors.Is(err, ErrNotFound) {
+			return category, err
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Create adds a new category to the library of its parent, or to the
+// global library for top-level categories.
+func (r *layeredCategoryRepository) Create(category *domain.Category) error {
+	l := globalLayer(r.layers)
+	if category.ParentID() > 0 {
+		l = layerOf(r.layers, category.ParentID())
+	}
+	return l.repos.Categories.Create(category)
+}
+
+// Update replaces an existing category in the library that holds it.
+func (r *layeredCategoryRepository) Update(category *domain.Category) error {
+	return layerOf(r.layers, category.ID()).repos.Categories.Update(category)
+}
+
+// Move moves a category within the library that holds it.
+func (r *layeredCategoryRepository) Move(id, parentID int) error {
+	return layerOf(r.layers, id).repos.Categories.Move(id, parentID)
+}
+
+// Delete removes a category by ID from the library that holds it.
+func (r *layeredCategoryRepository) Delete(id int) error {
+	return layerOf(r.layers, id).repos.Categories.Delete(id)
+}
+
+// layeredTagRepository implements domain.TagRepository over the tag
+// repositories of several libraries.
+type layeredTagRepository struct {
+	layers []layer
+}
+
+// List returns the tags of all libraries, topmost library first.
+func (r *layeredTagRepository) List() ([]*domain.Tag, error) {
+	refreshLayers(r.layers)
+	result := make([]*domain.Tag, 0)
+	for _, l := range r.layers {
+		tags, err := l.repos.Tags.List()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tags...)
+	}
+	return result, nil
+}
+
+// FindByID finds a tag by its ID in the library that holds it.
+func (r *layeredTagRepository) FindByID(id int) (*domain.Tag, error) {
+	refreshLayers(r.layers)
+	return layerOf(r.layers, id).repos.Tags.FindByID(id)
+}
+
+// FindByName finds a tag by its name, in the topmost library that has one.
+func (r *layeredTagRepository) FindByName(name string) (*domain.Tag, error) {
+	refreshLayers(r.layers)
+	for _, l := range r.layers {
+		if tag, err := l.repos.Tags.FindByName(name); !errors.Is(err, ErrNotFound) {
+			return tag, err
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Create adds a new tag to the global library.
+func (r *layeredTagRepository) Create(tag *domain.Tag) error {
+	return globalLayer(r.layers).repos.Tags.Create(tag)
+}
+
+// Update replaces an existing tag in the library that holds it.
+func (r *layeredTagRepository) Update(tag *domain.Tag) error {
+	return layerOf(r.layers, tag.ID()).repos.Tags.Update(tag)
+}
+
+// Delete removes a tag by ID from the library that holds it.
+func (r *layeredTagRepository) Delete(id int) error {
+	return layerOf(r.layers, id).repos.Tags.Delete(id)
+}
@@ -0,0 +1,283 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// newLayeredRepos creates a global library with a project library stacked
+// over it, both saved in a temporary directory.
+func newLayeredRepos(t *testing.T) (*Repositories, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	globalFile := filepath.Join(dir, "global.json")
+	projectFile := filepath.Join(dir, "project.json")
+
+	repos := New(globalFile)
+	repos.AddLayer(OriginProject, NewLayer(projectFile, ProjectIDBase))
+	if err := repos.Load(); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	return repos, globalFile, projectFile
+}
+
+func TestRepositories_AddLayer(t *testing.T) {
+	t.Run("lists and routes snippets of both libraries", func(t *testing.T) {
+		repos, _, _ := newLayeredRepos(t)
+		global := mustCreateSnippet(t, "global", "go", "fmt.Println()")
+		project := mustCreateSnippet(t, "project", "go", "make build")
+
+		if err := repos.Snippets.Create(global); err != nil {
+			t.Fatalf("failed to create: %v", err)
+		}
+		if err := repos.Layer(OriginProject).Snippets.Create(project); err != nil {
+			t.Fatalf("failed to create: %v", err)
+		}
+
+		if global.ID() != 1 || project.ID() != ProjectIDBase+1 {
+			t.Errorf("expected IDs 1 and %d, got %d and %d", ProjectIDBase+1, global.ID(), project.ID())
+		}
+		if repos.Origin(global.ID()) != OriginGlobal || repos.Origin(project.ID()) != OriginProject {
+			t.Errorf("unexpected origins %q and %q", repos.Origin(global.ID()), repos.Origin(project.ID()))
+		}
+
+		snippets, _ := repos.Snippets.List()
+		if len(snippets) != 2 || snippets[0] != project {
+			t.Fatalf("expected project snippet first of 2, got %v", snippets)
+		}
+		if found, err := repos.Snippets.FindByID(project.ID()); err != nil || found != project {
+			t.Errorf("expected to find project snippet, got %v, %v", found, err)
+		}
+		if results, _ := repos.Snippets.FindByLanguage("go"); len(results) != 2 {
+			t.Errorf("expected 2 Go snippets, got %d", len(results))
+		}
+
+		if err := repos.Snippets.Delete(project.ID()); err != nil {
+			t.Fatalf("failed to delete: %v", err)
+		}
+		if projectSnippets, _ := repos.Layer(OriginProject).Snippets.List(); len(projectSnippets) != 0 {
+			t.Errorf("expected project library to be empty, got %d", len(projectSnippets))
+		}
+	})
+
+	t.Run("merges recent snippets", func(t *testing.T) {
+		repos, _, _ := newLayeredRepos(t)
+		global := mustCreateSnippet(t, "global", "go", "a")
+		project := mustCreateSnippet(t, "project", "go", "b")
+		repos.Snippets.Create(global)
+		repos.Layer(OriginProject).Snippets.Create(project)
+
+		global.RecordCopy()
+		project.RecordCopy()
+		recent, _ := repos.Snippets.FindRecent(1)
+		if len(recent) != 1 || recent[0] != project {
+			t.Errorf("expected the project snippet as most recent, got %v", recent)
+		}
+	})
+
+	t.Run("bulk updates across libraries", func(t *testing.T) {
+		repos, _, _ := newLayeredRepos(t)
+		global := mustCreateSnippet(t, "global", "go", "a")
+		project := mustCreateSnippet(t, "project", "go", "b")
+		repos.Snippets.Create(global)
+		repos.Layer(OriginProject).Snippets.Create(project)
+
+		err := repos.Snippets.BulkUpdate([]int{global.ID(), project.ID()}, func(s *domain.Snippet) error {
+			s.SetPinned(true)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("failed to bulk update: %v", err)
+		}
+		pinned, _ := repos.Snippets.FindPinned()
+		if len(pinned) != 2 {
+			t.Errorf("expected 2 pinned snippets, got %d", len(pinned))
+		}
+
+		err = repos.Snippets.BulkDelete([]int{project.ID(), 999})
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+		if snippets, _ := repos.Snippets.List(); len(snippets) != 2 {
+			t.Errorf("expected nothing deleted, got %d snippets", len(snippets))
+		}
+	})
+
+	t.Run("keeps updated snippets referring to their own library", func(t *testing.T) {
+		repos, _, _ := newLayeredRepos(t)
+		project := repos.Layer(OriginProject)
+		category := mustCreateCategory(t, "build")
+		project.Categories.Create(category)
+		tag := mustCreateTag(t, "make")
+		project.Tags.Create(tag)
+		global := mustCreateSnippet(t, "global", "bash", "make")
+		repos.Snippets.Create(global)
+
+		// A form may offer the project's category and tags for a global snippet
+		global.SetCategory(category.ID())
+		global.AddTag(tag.ID())
+		if err := repos.Snippets.Update(global); err != nil {
+			t.Fatalf("failed to update: %v", err)
+		}
+
+		personal := repos.Layer(OriginGlobal)
+		local, err := personal.Categories.FindByPath("build")
+		if err != nil || global.CategoryID() != local.ID() || local.ID() > ProjectIDBase {
+			t.Errorf("expected the global category build, got %d (%v)", global.CategoryID(), err)
+		}
+		localTag, err := personal.Tags.FindByName("make")
+		if err != nil || !global.HasTag(localTag.ID()) || global.HasTag(tag.ID()) {
+			t.Errorf("expected the global make tag, got %v (%v)", global.Tags(), err)
+		}
+	})
+
+	t.Run("keeps bulk edited snippets referring to their own library", func(t *testing.T) {
+		repos, _, _ := newLayeredRepos(t)
+		project := repos.Layer(OriginProject)
+		category := mustCreateCategory(t, "build")
+		project.Categories.Create(category)
+		tag := mustCreateTag(t, "make")
+		project.Tags.Create(tag)
+		global := mustCreateSnippet(t, "global", "bash", "make")
+		repos.Snippets.Create(global)
+		local := mustCreateSnippet(t, "project", "bash", "make test")
+		project.Snippets.Create(local)
+
+		err := repos.Snippets.BulkUpdate([]int{global.ID(), local.ID()}, func(s *domain.Snippet) error {
+			s.SetCategory(category.ID())
+			s.AddTag(tag.ID())
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("failed to bulk update: %v", err)
+		}
+
+		if global.CategoryID() == category.ID() || global.CategoryID() > ProjectIDBase || global.HasTag(tag.ID()) {
+			t.Errorf("expected global references, got category %d and tags %v", global.CategoryID(), global.Tags())
+		}
+		if local.CategoryID() != category.ID() || !local.HasTag(tag.ID()) {
+			t.Errorf("expected the project snippet to keep project references, got %d and %v", local.CategoryID(), local.Tags())
+		}
+		if path, err := repos.Layer(OriginGlobal).Categories.FindByPath("build"); err != nil || path.ID() != global.CategoryID() {
+			t.Errorf("expected the global category build, got %v (%v)", path, err)
+		}
+	})
+
+	t.Run("saves and loads every library", func(t *testing.T) {
+		repos, globalFile, projectFile := newLayeredRepos(t)
+		repos.Snippets.Create(mustCreateSnippet(t, "global", "go", "a"))
+		repos.Layer(OriginProject).Snippets.Create(mustCreateSnippet(t, "project", "go", "b"))
+		if err := repos.Save(); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+
+		reloaded := New(globalFile)
+		reloaded.AddLayer(OriginProject, NewLayer(projectFile, ProjectIDBase))
+		if err := reloaded.Load(); err != nil {
+			t.Fatalf("failed to load: %v", err)
+		}
+		snippets, _ := reloaded.Snippets.List()
+		if len(snippets) != 2 {
+			t.Fatalf("expected 2 snippets, got %d", len(snippets))
+		}
+
+		next := mustCreateSnippet(t, "next", "go", "c")
+		reloaded.Layer(OriginProject).Snippets.Create(next)
+		if next.ID() != ProjectIDBase+2 {
+			t.Errorf("expected ID %d, got %d", ProjectIDBase+2, next.ID())
+		}
+	})
+
+	t.Run("rejects layer files with IDs outside the range", func(t *testing.T) {
+		dir := t.TempDir()
+		plain := New(filepath.Join(dir, "plain.json"))
+		plain.Snippets.Create(mustCreateSnippet(t, "plain", "go", "a"))
+		plain.Save()
+
+		layer := NewLayer(filepath.Join(dir, "plain.json"), ProjectIDBase)
+		if err := layer.Load(); !errors.Is(err, ErrIDOutOfRange) {
+			t.Errorf("expected ErrIDOutOfRange, got %v", err)
+		}
+	})
+
+	t.Run("rejects library files with IDs of a stacked library", func(t *testing.T) {
+		dir := t.TempDir()
+		project := NewLayer(filepath.Join(dir, "project.json"), ProjectIDBase)
+		project.Snippets.Create(mustCreateSnippet(t, "project", "go", "a"))
+		project.Save()
+
+		global := New(filepath.Join(dir, "project.json"))
+		if err := global.Load(); !errors.Is(err, ErrIDOutOfRange) {
+			t.Errorf("expected ErrIDOutOfRange, got %v", err)
+		}
+	})
+
+	t.Run("without layers", func(t *testing.T) {
+		repos := New(filepath.Join(t.TempDir(), "test.json"))
+		if repos.Layered() || repos.Layer(OriginGlobal) != repos || repos.Layer(OriginProject) != nil {
+			t.Error("expected a single global library")
+		}
+		if repos.Origin(1) != OriginGlobal {
+			t.Errorf("expected global origin, got %q", repos.Origin(1))
+		}
+	})
+}
+
+func TestRepositories_Adopt(t *testing.T) {
+	t.Run("copies category and tags into the library", func(t *testing.T) {
+		repos, _, projectFile := newLayeredRepos(t)
+		backend := mustCreateCategory(t, "backend")
+		repos.Categories.Create(backend)
+		db, _ := domain.NewCategory("db")
+		db.SetParent(backend.ID())
+		repos.Categories.Create(db)
+		tag := mustCreateTag(t, "sql")
+		repos.Tags.Create(tag)
+
+		snippet := mustCreateSnippet(t, "query", "sql", "SELECT 1")
+		snippet.SetCategory(db.ID())
+		snippet.AddTag(tag.ID())
+
+		project := repos.Layer(OriginProject)
+		if err := project.Adopt(snippet, repos); err != nil {
+			t.Fatalf("failed to adopt: %v", err)
+		}
+
+		if repos.Origin(snippet.ID()) != OriginProject {
+			t.Errorf("expected project snippet, got ID %d", snippet.ID())
+		}
+		category, err := project.Categories.FindByPath("backend/db")
+		if err != nil || snippet.CategoryID() != category.ID() {
+			t.Errorf("expected project category backend/db, got %d (%v)", snippet.CategoryID(), err)
+		}
+		local, err := project.Tags.FindByName("sql")
+		if err != nil || !snippet.HasTag(local.ID()) || snippet.HasTag(tag.ID()) {
+			t.Errorf("expected the project's sql tag, got %v (%v)", snippet.Tags(), err)
+		}
+
+		repos.Save()
+		data, _ := os.ReadFile(projectFile)
+		if len(data) == 0 {
+			t.Error("expected the project library to be saved")
+		}
+	})
+
+	t.Run("keeps references within the library", func(t *testing.T) {
+		repos := New(filepath.Join(t.TempDir(), "test.json"))
+		tag := mustCreateTag(t, "go")
+		repos.Tags.Create(tag)
+
+		snippet := mustCreateSnippet(t, "main", "go", "func main() {}")
+		snippet.AddTag(tag.ID())
+		if err := repos.Adopt(snippet, repos); err != nil {
+			t.Fatalf("failed to adopt: %v", err)
+		}
+		if tags, _ := repos.Tags.List(); len(tags) != 1 || !snippet.HasTag(tag.ID()) {
+			t.Errorf("expected the existing tag to be kept, got %v", snippet.Tags())
+		}
+	})
+}
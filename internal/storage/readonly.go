@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// OriginTeam names a shared library stacked read-only over the global one.
+const OriginTeam = "team"
+
+// TeamIDBase is the ID base of a team library. Its file is numbered from 1
+// like any library, and its IDs are shifted above TeamIDBase when loaded.
+const TeamIDBase = 1000000
+
+// NewReadOnlyLayer creates repositories for a library that is stacked over
+// another one with AddLayer and never changed. Its IDs are shifted above
+// idBase on load, so any library file can be used. Writes fail with
+// ErrReadOnly, Save leaves the file alone, and the library is reloaded when
+// its file changes.
+func NewReadOnlyLayer(filepath string, idBase int) *Repositories {
+	r := NewLayer(filepath, idBase)
+	r.store.readOnly = true
+	r.Snippets = &readOnlySnippetRepository{SnippetRepository: r.Snippets, store: r.store}
+	r.Categories = &readOnlyCategoryRepository{CategoryRepository: r.Categories, store: r.store}
+	r.Tags = &readOnlyTagRepository{TagRepository: r.Tags, store: r.store}
+	return r
+}
+
+// ReadOnly reports whether a snippet, category or tag ID belongs to a
+// read-only library.
+func (r *Repositories) ReadOnly(id int) bool {
+	if !r.Layered() {
+		return r.store.readOnly
+	}
+	return layerOf(r.layers, id).repos.store.readOnly
+}
+
+// Fork copies a snippet into the global library, along with its category
+// path and tags, and returns the copy. It is how snippets of a read-only
+// library are changed.
+func (r *Repositories) Fork(id int) (*domain.Snippet, error) {
+	snippet, err := r.Snippets.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	fork := snippet.Clone()
+	fork.SetID(0)
+	if err := r.Layer(OriginGlobal).Adopt(fork, r); err != nil {
+		return nil, err
+	}
+	return fork, nil
+}
+
+// RecordUsage saves a snippet after RecordView or RecordCopy. The usage of
+// read-only snippets is kept in memory only.
+func (r *Repositories) RecordUsage(snippet *domain.Snippet) error {
+	if r.ReadOnly(snippet.ID()) {
+		return nil
+	}
+	return r.Snippets.Update(snippet)
+}
+
+// refreshLayers reloads the read-only libraries whose file changed.
+func refreshLayers(layers []layer) {
+	for _, l := range layers {
+		l.repos.store.refresh()
+	}
+}
+
+// shiftIDs adds base to the IDs in library JSON data, and to the category,
+// parent and tag IDs referring to them. It works on the JSON so that the
+// entities' modification times stay as they are.
+func shiftIDs(jsonData []byte, base int) ([]byte, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(jsonData, &raw); err != nil {
+		return nil, err
+	}
+
+	refs := map[string][]string{
+		"snippets":   {"id", "category_id", "tags"},
+		"categories": {"id", "parent_id"},
+		"tags":       {"id"},
+	}
+	for key, fields := range refs {
+		if raw[key] == nil {
+			continue
+		}
+		var entities []map[string]any
+		if err := json.Unmarshal(raw[key], &entities); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		for _, entity := range entities {
+			for _, field := range fields {
+				if value, ok := entity[field]; ok {
+					entity[field] = shiftID(value, base)
+				}
+			}
+		}
+		shifted, err := json.Marshal(entities)
+		if err != nil {
+			return nil, err
+		}
+		raw[key] = shifted
+	}
+	return json.Marshal(raw)
+}
+
+// shiftID adds base to a decoded JSON ID, or to each ID of a list. Zero
+// means no reference and is kept.
+func shiftID(value any, base int) any {
+	switch v := value.(type) {
+	case float64:
+		if v > 0 {
+			return v + float64(base)
+		}
+	case []any:
+		for i := range v {
+			v[i] = shiftID(v[i], base)
+		}
+	}
+	return value
+}
+
+// readOnlySnippetRepository rejects changes to the snippets of a read-only
+// library.
+type readOnlySnippetRepository struct {
+	domain.SnippetRepository
+	store *store
+}
+
+func (r *readOnlySnippetRepository) Create(*domain.Snippet) error { return r.store.reject() }
+func (r *readOnlySnippetRepository) Update(*domain.Snippet) error { return r.store.reject() }
+func (r *readOnlySnippetRepository) Delete(int) error             { return r.store.reject() }
+
+func (r *readOnlySnippetRepository) BulkUpdate([]int, domain.SnippetEdit) error {
+	return r.store.reject()
+}
+
+func (r *readOnlySnippetRepository) BulkDelete([]int) error { return r.store.reject() }
+
+// readOnlyCategoryRepository rejects changes to the categories of a
+// read-only library.
+type readOnlyCategoryRepository struct {
+	domain.CategoryRepository
+	store *store
+}
+
+func (r *readOnlyCategoryRepository) Create(*domain.Category) error { return r.store.reject() }
+func (r *readOnlyCategoryRepository) Update(*domain.Category) error { return r.store.reject() }
+func (r *readOnlyCategoryRepository) Move(int, int) error           { return r.store.reject() }
+func (r *readOnlyCategoryRepository) Delete(int) error              { return r.store.reject() }
+
+// readOnlyTagRepository rejects changes to the tags of a read-only library.
+type readOnlyTagRepository struct {
+	domain.TagRepository
+	store *store
+}
+
+func (r *readOnlyTagRepository) Create(*domain.Tag) error { return r.store.reject() }
+func (r *readOnlyTagRepository) Update(*domain.Tag) error { return r.store.reject() }
+func (r *readOnlyTagRepository) Delete(int) error         { return r.store.reject() }
@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
+
+// newTeamRepos saves a team library with a tagged snippet in a category,
+// numbered from 1 like any library, and stacks it read-only over an empty
+// global library.
+func newTeamRepos(t *testing.T) (*Repositories, string) {
+	t.Helper()
+	dir := t.TempDir()
+	teamFile := filepath.Join(dir, "team.json")
+
+	team := New(teamFile)
+	category := mustCreateCategory(t, "deploy")
+	team.Categories.Create(category)
+	tag := mustCreateTag(t, "k8s")
+	team.Tags.Create(tag)
+	snippet := mustCreateSnippet(t, "rollout", "bash", "kubectl rollout status")
+	snippet.SetCategory(category.ID())
+	snippet.AddTag(tag.ID())
+	team.Snippets.Create(snippet)
+	if err := team.Save(); err != nil {
+		t.Fatalf("failed to save team library: %v", err)
+	}
+
+	repos := New(filepath.Join(dir, "personal.json"))
+	repos.AddLayer(OriginTeam, NewReadOnlyLayer(teamFile, TeamIDBase))
+	if err := repos.Load(); err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	return repos, teamFile
+}
+
+func TestNewReadOnlyLayer(t *testing.T) {
+	t.Run("shifts IDs and references above the base", func(t *testing.T) {
+		repos, _ := newTeamRepos(t)
+
+		snippets, _ := repos.Snippets.List()
+		if len(snippets) != 1 {
+			t.Fatalf("expected 1 snippet, got %d", len(snippets))
+		}
+		snippet := snippets[0]
+		if snippet.ID() != TeamIDBase+1 || snippet.CategoryID() != TeamIDBase+1 || !snippet.HasTag(TeamIDBase+1) {
+			t.Errorf("expected shifted IDs, got %d, category %d, tags %v", snippet.ID(), snippet.CategoryID(), snippet.Tags())
+		}
+		if repos.Origin(snippet.ID()) != OriginTeam || !repos.ReadOnly(snippet.ID()) {
+			t.Errorf("expected a read-only team snippet, got origin %q", repos.Origin(snippet.ID()))
+		}
+		if category, err := repos.Categories.FindByPath("deploy"); err != nil || category.ID() != TeamIDBase+1 {
+			t.Errorf("expected team category, got %v (%v)", category, err)
+		}
+	})
+
+	t.Run("rejects changes", func(t *testing.T) {
+		repos, teamFile := newTeamRepos(t)
+		before, _ := os.ReadFile(teamFile)
+		snippet, _ := repos.Snippets.FindByID(TeamIDBase + 1)
+
+		snippet.SetCode("kubectl delete")
+		if err := repos.Snippets.Update(snippet); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("expected ErrReadOnly, got %v", err)
+		}
+		if err := repos.Snippets.Delete(snippet.ID()); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("expected ErrReadOnly, got %v", err)
+		}
+		if err := repos.Layer(OriginTeam).Snippets.Create(mustCreateSnippet(t, "new", "go", "a")); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("expected ErrReadOnly, got %v", err)
+		}
+		if err := repos.Tags.Delete(TeamIDBase + 1); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("expected ErrReadOnly, got %v", err)
+		}
+		sub, _ := domain.NewCategory("prod")
+		sub.SetParent(TeamIDBase + 1)
+		if err := repos.Categories.Create(sub); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("expected ErrReadOnly, got %v", err)
+		}
+
+		// The rejected change is dropped from memory and never saved
+		reloaded, _ := repos.Snippets.FindByID(TeamIDBase + 1)
+		if reloaded.Code() != "kubectl rollout status" {
+			t.Errorf("expected the team code back, got %q", reloaded.Code())
+		}
+		if err := repos.Save(); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+		if after, _ := os.ReadFile(teamFile); string(after) != string(before) {
+			t.Error("expected the team file to be left alone")
+		}
+	})
+
+	t.Run("rejects bulk changes before any library changes", func(t *testing.T) {
+		repos, _ := newTeamRepos(t)
+		personal := mustCreateSnippet(t, "mine", "go", "a")
+		repos.Snippets.Create(personal)
+
+		err := repos.Snippets.BulkUpdate([]int{personal.ID(), TeamIDBase + 1}, func(s *domain.Snippet) error {
+			s.SetPinned(true)
+			return nil
+		})
+		if !errors.Is(err, ErrReadOnly) || personal.IsPinned() {
+			t.Errorf("expected ErrReadOnly and no change, got %v", err)
+		}
+		if err := repos.Snippets.BulkDelete([]int{personal.ID(), TeamIDBase + 1}); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("expected ErrReadOnly, got %v", err)
+		}
+		if snippets, _ := repos.Snippets.List(); len(snippets) != 2 {
+			t.Errorf("expected nothing deleted, got %d snippets", len(snippets))
+		}
+	})
+
+	t.Run("reloads when the file changes", func(t *testing.T) {
+		repos, teamFile := newTeamRepos(t)
+
+		team := New(teamFile)
+		team.Load()
+		team.Snippets.Create(mustCreateSnippet(t, "logs", "bash", "kubectl logs"))
+		team.Save()
+		later := time.Now().Add(time.Minute)
+		os.Chtimes(teamFile, later, later)
+
+		snippets, _ := repos.Snippets.List()
+		if len(snippets) != 2 {
+			t.Fatalf("expected 2 snippets after the change, got %d", len(snippets))
+		}
+		if _, err := repos.Snippets.FindByID(TeamIDBase + 2); err != nil {
+			t.Errorf("expected the new team snippet, got %v", err)
+		}
+	})
+
+	t.Run("normalizes languages when it reloads", func(t *testing.T) {
+		repos, teamFile := newTeamRepos(t)
+
+		data, _ := os.ReadFile(teamFile)
+		data = bytes.Replace(data, []byte(`"language": "bash"`), []byte(`"language": "py"`), 1)
+		os.WriteFile(teamFile, data, 0644)
+		later := time.Now().Add(time.Minute)
+		os.Chtimes(teamFile, later, later)
+
+		snippet, err := repos.Snippets.FindByID(TeamIDBase + 1)
+		if err != nil || snippet.Language() != "python" {
+			t.Errorf("expected language 'python', got %v (%v)", snippet, err)
+		}
+		if saved, _ := os.ReadFile(teamFile); !bytes.Equal(saved, data) {
+			t.Error("expected the team file to be left alone")
+		}
+	})
+}
+
+func TestRepositories_Fork(t *testing.T) {
+	t.Run("copies a team snippet into the personal library", func(t *testing.T) {
+		repos, _ := newTeamRepos(t)
+
+		fork, err := repos.Fork(TeamIDBase + 1)
+		if err != nil {
+			t.Fatalf("failed to fork: %v", err)
+		}
+		if fork.ID() != 1 || repos.ReadOnly(fork.ID()) || fork.Code() != "kubectl rollout status" {
+			t.Errorf("expected a personal copy, got %v", fork)
+		}
+
+		personal := repos.Layer(OriginGlobal)
+		category, err := personal.Categories.FindByPath("deploy")
+		if err != nil || fork.CategoryID() != category.ID() {
+			t.Errorf("expected personal category deploy, got %d (%v)", fork.CategoryID(), err)
+		}
+		tag, err := personal.Tags.FindByName("k8s")
+		if err != nil || !fork.HasTag(tag.ID()) || len(fork.Tags()) != 1 {
+			t.Errorf("expected the personal k8s tag, got %v (%v)", fork.Tags(), err)
+		}
+
+		fork.SetCode("kubectl rollout status --watch")
+		if err := repos.Snippets.Update(fork); err != nil {
+			t.Errorf("expected the fork to be editable, got %v", err)
+		}
+		if original, _ := repos.Snippets.FindByID(TeamIDBase + 1); original.Code() != "kubectl rollout status" {
+			t.Errorf("expected the team snippet unchanged, got %q", original.Code())
+		}
+	})
+
+	t.Run("reports unknown snippets", func(t *testing.T) {
+		repos, _ := newTeamRepos(t)
+		if _, err := repos.Fork(42); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
@@ -1,7 +1,11 @@
 // Package storage provides JSON file-based persistence for SNIP entities.
 package storage
 
-import "github.com/7-Dany/snip/internal/domain"
+import (
+	"os"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
 
 // Repositories bundles all repository implementations with shared state.
 // All repositories share the same underlying store for data consistency.
@@ -10,6 +14,7 @@ type Repositories struct {
 	Categories domain.CategoryRepository
 	Tags       domain.TagRepository
 	store      *store
+	layers     []layer // Libraries stacked with AddLayer, topmost first
 }
 
 // New creates repositories with all implementations sharing the same store.
@@ -24,13 +29,120 @@ func New(filepath string) *Repositories {
 	}
 }
 
-// Save persists all data to the JSON file atomically.
+// Save persists all data to the JSON file atomically, and that of stacked
+// libraries to theirs.
 func (r *Repositories) Save() error {
-	return r.store.save()
+	if err := r.store.save(); err != nil {
+		return err
+	}
+	for _, l := range r.layers {
+		if l.repos.store != r.store {
+			if err := l.repos.Save(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
-// Load reads all data from the JSON file into memory.
-// If the file doesn't exist, this is not an error.
+// Load reads all data from the JSON file into memory, and that of stacked
+// libraries from theirs. If a file doesn't exist, this is not an error.
+// Snippet languages stored under an alias are normalized and saved.
 func (r *Repositories) Load() error {
-	return r.store.load()
+	if err := r.store.open(); err != nil {
+		return err
+	}
+	for _, l := range r.layers {
+		if l.repos.store != r.store {
+			if err := l.repos.Load(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SetPassphrase sets the passphrase used by Load to decrypt an encrypted file.
+func (r *Repositories) SetPassphrase(passphrase string) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.passphrase = passphrase
+}
+
+// Encrypted reports whether data is saved encrypted.
+func (r *Repositories) Encrypted() bool {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return r.store.key != nil
+}
+
+// Encrypt saves all data encrypted with a key derived from passphrase.
+// Later saves stay encrypted. Calling Encrypt on encrypted data changes
+// the passphrase.
+func (r *Repositories) Encrypt(passphrase string) error {
+	key, err := newEncryptionKey(passphrase)
+	if err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	previous := r.store.key
+	r.store.key = key
+	r.store.mu.Unlock()
+
+	if err := r.store.save(); err != nil {
+		r.store.mu.Lock()
+		r.store.key = previous
+		r.store.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Decrypt saves all data as plain JSON. Later saves stay unencrypted.
+func (r *Repositories) Decrypt() error {
+	r.store.mu.Lock()
+	previous := r.store.key
+	r.store.key = nil
+	r.store.mu.Unlock()
+
+	if err := r.store.save(); err != nil {
+		r.store.mu.Lock()
+		r.store.key = previous
+		r.store.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// IsEncrypted reports whether the file at filepath is encrypted.
+// A missing file is not encrypted.
+func IsEncrypted(filepath string) (bool, error) {
+	raw, err := os.ReadFile(filepath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return isEncrypted(raw), nil
+}
+
+// Stats computes library statistics across all repositories.
+// recentLimit caps the recently added and updated lists; 0 or less means no limit.
+func (r *Repositories) Stats(recentLimit int) (*domain.LibraryStats, error) {
+	snippets, err := r.Snippets.List()
+	if err != nil {
+		return nil, err
+	}
+	categories, err := r.Categories.List()
+	if err != nil {
+		return nil, err
+	}
+	tags, err := r.Tags.List()
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewLibraryStats(snippets, categories, tags, recentLimit), nil
 }
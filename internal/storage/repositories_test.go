@@ -3,6 +3,7 @@ package storage
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/7-Dany/snip/internal/domain"
@@ -156,6 +157,38 @@ func TestRepositories_SaveAndLoad(t *testing.T) {
 		}
 	})
 
+	t.Run("migrates language aliases on load", func(t *testing.T) {
+		tempFile := filepath.Join(t.TempDir(), "legacy.json")
+		legacy := `{"snippets":[
+			{"id":1,"title":"a","language":"JS","code":"x"},
+			{"id":2,"title":"b","language":"go","code":"y"}
+		],"next_snippet_id":3,"next_category_id":1,"next_tag_id":1}`
+		if err := os.WriteFile(tempFile, []byte(legacy), 0644); err != nil {
+			t.Fatalf("failed to write data file: %v", err)
+		}
+
+		repos := New(tempFile)
+		if err := repos.Load(); err != nil {
+			t.Fatalf("failed to load: %v", err)
+		}
+
+		snippet, err := repos.Snippets.FindByID(1)
+		if err != nil {
+			t.Fatalf("failed to find snippet: %v", err)
+		}
+		if snippet.Language() != "javascript" {
+			t.Errorf("expected language 'javascript', got %q", snippet.Language())
+		}
+
+		data, err := os.ReadFile(tempFile)
+		if err != nil {
+			t.Fatalf("failed to read data file: %v", err)
+		}
+		if !strings.Contains(string(data), `"language": "javascript"`) {
+			t.Error("expected migrated language to be saved")
+		}
+	})
+
 	t.Run("creates file if it doesn't exist on save", func(t *testing.T) {
 		tempFile := filepath.Join(t.TempDir(), "new.json")
 		repos := New(tempFile)
@@ -178,6 +211,38 @@ func TestRepositories_SaveAndLoad(t *testing.T) {
 	})
 }
 
+func TestRepositories_Stats(t *testing.T) {
+	t.Run("computes stats from all repositories", func(t *testing.T) {
+		repos := New(filepath.Join(t.TempDir(), "test.json"))
+
+		category := mustCreateCategory(t, "algorithms")
+		if err := repos.Categories.Create(category); err != nil {
+			t.Fatalf("failed to create category: %v", err)
+		}
+
+		snippet := mustCreateSnippet(t, "quicksort", "go", "func quicksort() {\n}")
+		snippet.SetCategory(category.ID())
+		if err := repos.Snippets.Create(snippet); err != nil {
+			t.Fatalf("failed to create snippet: %v", err)
+		}
+
+		stats, err := repos.Stats(5)
+		if err != nil {
+			t.Fatalf("Stats failed: %v", err)
+		}
+
+		if stats.TotalSnippets != 1 || stats.TotalCategories != 1 || stats.TotalTags != 0 {
+			t.Errorf("unexpected totals: %+v", stats)
+		}
+		if stats.TotalLines != 2 {
+			t.Errorf("expected 2 lines, got %d", stats.TotalLines)
+		}
+		if len(stats.Untagged) != 1 {
+			t.Errorf("expected 1 untagged snippet, got %d", len(stats.Untagged))
+		}
+	})
+}
+
 // mustCreateCategory creates a category or fails the test.
 func mustCreateCategory(t *testing.T, name string) *domain.Category {
 	t.Helper()
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/7-Dany/snip/internal/domain"
@@ -18,7 +20,7 @@ func newSearchIndex(s *store) *searchIndex {
 }
 
 // search finds snippets matching the given query string.
-// It searches across title, language, code, and description fields.
+// It searches across title, language, code, description, and alias fields.
 // The search is case-insensitive and matches partial strings.
 func (idx *searchIndex) search(query string) []*domain.Snippet {
 	if query == "" {
@@ -45,11 +47,14 @@ func (idx *searchIndex) matches(snippet *domain.Snippet, query string) bool {
 	return strings.Contains(strings.ToLower(snippet.Title()), query) ||
 		strings.Contains(strings.ToLower(snippet.Language()), query) ||
 		strings.Contains(strings.ToLower(snippet.Code()), query) ||
-		strings.Contains(strings.ToLower(snippet.Description()), query)
+		strings.Contains(strings.ToLower(snippet.Description()), query) ||
+		slices.ContainsFunc(snippet.Aliases(), func(alias string) bool {
+			return strings.Contains(strings.ToLower(alias), query)
+		})
 }
 
 // findByLanguage finds all snippets with the given language.
-// The search is case-insensitive.
+// The language may be given by any alias, in any case.
 func (idx *searchIndex) findByLanguage(language string) []*domain.Snippet {
 	if language == "" {
 		return nil
@@ -58,11 +63,11 @@ func (idx *searchIndex) findByLanguage(language string) []*domain.Snippet {
 	idx.store.mu.Lock()
 	defer idx.store.mu.Unlock()
 
-	language = strings.ToLower(language)
+	language = domain.CanonicalLanguage(language)
 	results := make([]*domain.Snippet, 0)
 
 	for _, snippet := range idx.store.snippets {
-		if strings.EqualFold(snippet.Language(), language) {
+		if domain.CanonicalLanguage(snippet.Language()) == language {
 			results = append(results, snippet)
 		}
 	}
@@ -70,15 +75,24 @@ func (idx *searchIndex) findByLanguage(language string) []*domain.Snippet {
 	return results
 }
 
-// findByCategory finds all snippets in the given category.
+// findByCategory finds all snippets in the given category or any of its subcategories.
+// A categoryID of 0 matches uncategorized snippets only.
 func (idx *searchIndex) findByCategory(categoryID int) []*domain.Snippet {
 	idx.store.mu.Lock()
 	defer idx.store.mu.Unlock()
 
+	categoryIDs := map[int]bool{categoryID: true}
+	if categoryID != 0 {
+		tree := domain.NewCategoryTree(idx.store.categories)
+		for _, id := range tree.Descendants(categoryID) {
+			categoryIDs[id] = true
+		}
+	}
+
 	results := make([]*domain.Snippet, 0)
 
 	for _, snippet := range idx.store.snippets {
-		if snippet.CategoryID() == categoryID {
+		if categoryIDs[snippet.CategoryID()] {
 			results = append(results, snippet)
 		}
 	}
@@ -101,3 +115,116 @@ func (idx *searchIndex) findByTag(tagID int) []*domain.Snippet {
 
 	return results
 }
+
+// findDuplicates finds all snippets in language whose code is identical
+// to code after normalization.
+func (idx *searchIndex) findDuplicates(code, language string) []*domain.Snippet {
+	idx.store.mu.Lock()
+	defer idx.store.mu.Unlock()
+
+	results := make([]*domain.Snippet, 0)
+	hash := domain.CodeHash(code, language)
+
+	for _, snippet := range idx.store.snippets {
+		if !strings.EqualFold(snippet.Language(), language) {
+			continue
+		}
+		if domain.CodeHash(snippet.Code(), language) == hash {
+			results = append(results, snippet)
+		}
+	}
+
+	return results
+}
+
+// findFavorites finds all snippets marked as favorites.
+func (idx *searchIndex) findFavorites() []*domain.Snippet {
+	idx.store.mu.Lock()
+	defer idx.store.mu.Unlock()
+
+	results := make([]*domain.Snippet, 0)
+
+	for _, snippet := range idx.store.snippets {
+		if snippet.IsFavorite() {
+			results = append(results, snippet)
+		}
+	}
+
+	return results
+}
+
+// findPinned finds all pinned snippets.
+func (idx *searchIndex) findPinned() []*domain.Snippet {
+	idx.store.mu.Lock()
+	defer idx.store.mu.Unlock()
+
+	results := make([]*domain.Snippet, 0)
+
+	for _, snippet := range idx.store.snippets {
+		if snippet.IsPinned() {
+			results = append(results, snippet)
+		}
+	}
+
+	return results
+}
+
+// findRecent finds snippets that have been used, most recently used first.
+// A limit of 0 or less returns all used snippets.
+func (idx *searchIndex) findRecent(limit int) []*domain.Snippet {
+	results := idx.usedSnippets()
+	sortRecent(results)
+	return applyLimit(results, limit)
+}
+
+// findMostUsed finds snippets that have been used, most used first.
+// Ties are broken by the most recent use. A limit of 0 or less returns
+// all used snippets.
+func (idx *searchIndex) findMostUsed(limit int) []*domain.Snippet {
+	results := idx.usedSnippets()
+	sortMostUsed(results)
+	return applyLimit(results, limit)
+}
+
+// usedSnippets returns all snippets with at least one recorded use.
+func (idx *searchIndex) usedSnippets() []*domain.Snippet {
+	idx.store.mu.Lock()
+	defer idx.store.mu.Unlock()
+
+	results := make([]*domain.Snippet, 0)
+
+	for _, snippet := range idx.store.snippets {
+		if snippet.UseCount() > 0 {
+			results = append(results, snippet)
+		}
+	}
+
+	return results
+}
+
+// sortRecent orders snippets most recently used first.
+func sortRecent(snippets []*domain.Snippet) {
+	sort.SliceStable(snippets, func(i, j int) bool {
+		return snippets[i].LastUsedAt().After(snippets[j].LastUsedAt())
+	})
+}
+
+// sortMostUsed orders snippets most used first, breaking ties by the most
+// recent use.
+func sortMostUsed(snippets []*domain.Snippet) {
+	sort.SliceStable(snippets, func(i, j int) bool {
+		if snippets[i].UseCount() != snippets[j].UseCount() {
+			return snippets[i].UseCount() > snippets[j].UseCount()
+		}
+		return snippets[i].LastUsedAt().After(snippets[j].LastUsedAt())
+	})
+}
+
+// applyLimit truncates snippets to at most limit entries.
+// A limit of 0 or less leaves the slice unchanged.
+func applyLimit(snippets []*domain.Snippet, limit int) []*domain.Snippet {
+	if limit > 0 && len(snippets) > limit {
+		return snippets[:limit]
+	}
+	return snippets
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"testing"
+	"time"
 
 	"github.com/7-Dany/snip/internal/domain"
 )
@@ -73,6 +74,22 @@ func TestSearchIndex_Search(t *testing.T) {
 		}
 	})
 
+	t.Run("finds snippets by alias", func(t *testing.T) {
+		s := newStore("test.json")
+		idx := newSearchIndex(s)
+
+		snippet := mustCreateSnippet(t, "loop", "go", "for {}")
+		snippet.AddAlias("fori")
+		snippet.SetID(1)
+		s.snippets = []*domain.Snippet{snippet}
+
+		results := idx.search("FORI")
+
+		if len(results) != 1 {
+			t.Errorf("expected 1 result, got %d", len(results))
+		}
+	})
+
 	t.Run("search is case insensitive", func(t *testing.T) {
 		s := newStore("test.json")
 		idx := newSearchIndex(s)
@@ -169,6 +186,21 @@ func TestSearchIndex_FindByLanguage(t *testing.T) {
 		}
 	})
 
+	t.Run("matches language aliases", func(t *testing.T) {
+		s := newStore("test.json")
+		idx := newSearchIndex(s)
+
+		snippet := mustCreateSnippet(t, "test", "javascript", "code")
+		snippet.SetID(1)
+		s.snippets = []*domain.Snippet{snippet}
+
+		results := idx.findByLanguage("JS")
+
+		if len(results) != 1 {
+			t.Errorf("expected 1 result, got %d", len(results))
+		}
+	})
+
 	t.Run("returns empty for no matches", func(t *testing.T) {
 		s := newStore("test.json")
 		idx := newSearchIndex(s)
@@ -235,6 +267,38 @@ func TestSearchIndex_FindByCategory(t *testing.T) {
 		}
 	})
 
+	t.Run("includes snippets in subcategories", func(t *testing.T) {
+		s := newStore("test.json")
+		idx := newSearchIndex(s)
+
+		backend := mustCreateCategory(t, "backend")
+		backend.SetID(1)
+		db := mustCreateCategory(t, "db")
+		db.SetID(2)
+		db.SetParent(1)
+		postgres := mustCreateCategory(t, "postgres")
+		postgres.SetID(3)
+		postgres.SetParent(2)
+		s.categories = []*domain.Category{backend, db, postgres}
+
+		snippet1 := mustCreateSnippet(t, "test1", "go", "code1")
+		snippet1.SetID(1)
+		snippet1.SetCategory(1)
+		snippet2 := mustCreateSnippet(t, "test2", "sql", "code2")
+		snippet2.SetID(2)
+		snippet2.SetCategory(3)
+		snippet3 := mustCreateSnippet(t, "test3", "go", "code3")
+		snippet3.SetID(3)
+		s.snippets = []*domain.Snippet{snippet1, snippet2, snippet3}
+
+		if results := idx.findByCategory(1); len(results) != 2 {
+			t.Errorf("expected 2 results for parent, got %d", len(results))
+		}
+		if results := idx.findByCategory(2); len(results) != 1 {
+			t.Errorf("expected 1 result for intermediate category, got %d", len(results))
+		}
+	})
+
 	t.Run("finds uncategorized snippets", func(t *testing.T) {
 		s := newStore("test.json")
 		idx := newSearchIndex(s)
@@ -309,3 +373,137 @@ func TestSearchIndex_FindByTag(t *testing.T) {
 		}
 	})
 }
+
+func TestSearchIndex_FindFavoritesAndPinned(t *testing.T) {
+	s := newStore("test.json")
+	idx := newSearchIndex(s)
+
+	snippet1 := mustCreateSnippet(t, "test1", "go", "code1")
+	snippet1.SetID(1)
+	snippet1.SetFavorite(true)
+	snippet2 := mustCreateSnippet(t, "test2", "go", "code2")
+	snippet2.SetID(2)
+	snippet2.SetPinned(true)
+	snippet3 := mustCreateSnippet(t, "test3", "go", "code3")
+	snippet3.SetID(3)
+	snippet3.SetFavorite(true)
+	snippet3.SetPinned(true)
+	s.snippets = []*domain.Snippet{snippet1, snippet2, snippet3}
+
+	t.Run("finds favorite snippets", func(t *testing.T) {
+		results := idx.findFavorites()
+
+		if len(results) != 2 || results[0].ID() != 1 || results[1].ID() != 3 {
+			t.Errorf("expected favorites [1 3], got %v", results)
+		}
+	})
+
+	t.Run("finds pinned snippets", func(t *testing.T) {
+		results := idx.findPinned()
+
+		if len(results) != 2 || results[0].ID() != 2 || results[1].ID() != 3 {
+			t.Errorf("expected pinned [2 3], got %v", results)
+		}
+	})
+}
+
+func TestSearchIndex_FindRecent(t *testing.T) {
+	s := newStore("test.json")
+	idx := newSearchIndex(s)
+
+	snippet1 := mustCreateSnippet(t, "test1", "go", "code1")
+	snippet1.SetID(1)
+	snippet2 := mustCreateSnippet(t, "test2", "go", "code2")
+	snippet2.SetID(2)
+	snippet3 := mustCreateSnippet(t, "never used", "go", "code3")
+	snippet3.SetID(3)
+	s.snippets = []*domain.Snippet{snippet1, snippet2, snippet3}
+
+	snippet1.RecordView()
+	time.Sleep(2 * time.Millisecond)
+	snippet2.RecordCopy()
+
+	t.Run("orders by last use and skips unused snippets", func(t *testing.T) {
+		results := idx.findRecent(0)
+
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %d", len(results))
+		}
+		if results[0].ID() != 2 || results[1].ID() != 1 {
+			t.Errorf("expected order [2 1], got [%d %d]", results[0].ID(), results[1].ID())
+		}
+	})
+
+	t.Run("applies limit", func(t *testing.T) {
+		results := idx.findRecent(1)
+
+		if len(results) != 1 || results[0].ID() != 2 {
+			t.Errorf("expected only snippet 2, got %v", results)
+		}
+	})
+}
+
+func TestSearchIndex_FindMostUsed(t *testing.T) {
+	s := newStore("test.json")
+	idx := newSearchIndex(s)
+
+	snippet1 := mustCreateSnippet(t, "test1", "go", "code1")
+	snippet1.SetID(1)
+	snippet1.RecordView()
+	snippet2 := mustCreateSnippet(t, "test2", "go", "code2")
+	snippet2.SetID(2)
+	snippet2.RecordView()
+	snippet2.RecordCopy()
+	snippet2.RecordCopy()
+	snippet3 := mustCreateSnippet(t, "never used", "go", "code3")
+	snippet3.SetID(3)
+	s.snippets = []*domain.Snippet{snippet1, snippet2, snippet3}
+
+	t.Run("orders by use count", func(t *testing.T) {
+		results := idx.findMostUsed(10)
+
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %d", len(results))
+		}
+		if results[0].ID() != 2 || results[1].ID() != 1 {
+			t.Errorf("expected order [2 1], got [%d %d]", results[0].ID(), results[1].ID())
+		}
+	})
+
+	t.Run("applies limit", func(t *testing.T) {
+		if results := idx.findMostUsed(1); len(results) != 1 {
+			t.Errorf("expected 1 result, got %d", len(results))
+		}
+	})
+}
+
+func TestSearchIndex_FindDuplicates(t *testing.T) {
+	s := newStore("test.json")
+	idx := newSearchIndex(s)
+
+	snippet1 := mustCreateSnippet(t, "test1", "go", "x := 1\ny := 2")
+	snippet1.SetID(1)
+	snippet2 := mustCreateSnippet(t, "test2", "go", "x:=1\r\n\r\n  y := 2  \r\n")
+	snippet2.SetID(2)
+	snippet3 := mustCreateSnippet(t, "test3", "go", "x := 3")
+	snippet3.SetID(3)
+	s.snippets = []*domain.Snippet{snippet1, snippet2, snippet3}
+
+	t.Run("finds snippets with identical normalized code", func(t *testing.T) {
+		results := idx.findDuplicates("x = 1\n", "go")
+		if len(results) != 0 {
+			t.Errorf("expected 0 results, got %d", len(results))
+		}
+
+		results = idx.findDuplicates("x := 1\n\ny := 2\n", "go")
+		if len(results) != 2 || results[0].ID() != 1 || results[1].ID() != 2 {
+			t.Errorf("expected snippets [1 2], got %v", results)
+		}
+	})
+
+	t.Run("only matches snippets in the same language", func(t *testing.T) {
+		if results := idx.findDuplicates("x := 1\n\ny := 2\n", "python"); len(results) != 0 {
+			t.Errorf("expected 0 results, got %d", len(results))
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package storage
 
-import "github.com/7-Dany/snip/internal/domain"
+import (
+	"fmt"
+
+	"github.com/7-Dany/snip/internal/domain"
+)
 
 // snippetRepository implements domain.SnippetRepository using the internal store.
 type snippetRepository struct {
@@ -39,7 +43,7 @@ func (r *snippetRepository) FindByID(id int) (*domain.Snippet, error) {
 	return nil, ErrNotFound
 }
 
-// FindByCategory finds all snippets in a category.
+// FindByCategory finds all snippets in a category, including its subcategories.
 func (r *snippetRepository) FindByCategory(categoryID int) ([]*domain.Snippet, error) {
 	return r.index.findByCategory(categoryID), nil
 }
@@ -54,6 +58,34 @@ func (r *snippetRepository) FindByLanguage(language string) ([]*domain.Snippet,
 	return r.index.findByLanguage(language), nil
 }
 
+// FindFavorites finds all snippets marked as favorites.
+func (r *snippetRepository) FindFavorites() ([]*domain.Snippet, error) {
+	return r.index.findFavorites(), nil
+}
+
+// FindPinned finds all pinned snippets.
+func (r *snippetRepository) FindPinned() ([]*domain.Snippet, error) {
+	return r.index.findPinned(), nil
+}
+
+// FindRecent finds used snippets, most recently used first.
+// A limit of 0 or less returns all used snippets.
+func (r *snippetRepository) FindRecent(limit int) ([]*domain.Snippet, error) {
+	return r.index.findRecent(limit), nil
+}
+
+// FindMostUsed finds used snippets, most used first.
+// A limit of 0 or less returns all used snippets.
+func (r *snippetRepository) FindMostUsed(limit int) ([]*domain.Snippet, error) {
+	return r.index.findMostUsed(limit), nil
+}
+
+// FindDuplicates finds snippets in language whose code is identical to
+// code, ignoring trivial formatting differences.
+func (r *snippetRepository) FindDuplicates(code, language string) ([]*domain.Snippet, error) {
+	return r.index.findDuplicates(code, language), nil
+}
+
 // Search finds snippets matching the query.
 func (r *snippetRepository) Search(query string) ([]*domain.Snippet, error) {
 	return r.index.search(query), nil
@@ -97,3 +129,84 @@ func (r *snippetRepository) Delete(id int) error {
 	}
 	return ErrNotFound
 }
+
+// BulkUpdate applies edit to every snippet with the given IDs.
+// The operation is atomic: if any ID is unknown or any edit fails,
+// no snippet is changed. Edits are tried on clones first and then copied
+// into the stored snippets, so pointers handed out before stay current.
+// edit must not call back into the repository.
+func (r *snippetRepository) BulkUpdate(ids []int, edit domain.SnippetEdit) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	positions, err := r.positionsOf(ids)
+	if err != nil {
+		return err
+	}
+
+	updated := make(map[int]*domain.Snippet, len(positions))
+	for _, i := range positions {
+		clone := r.store.snippets[i].Clone()
+		if err := edit(clone); err != nil {
+			return fmt.Errorf("snippet %d: %w", clone.ID(), err)
+		}
+		updated[i] = clone
+	}
+
+	for i, snippet := range updated {
+		*r.store.snippets[i] = *snippet
+	}
+	return nil
+}
+
+// BulkDelete removes every snippet with the given IDs.
+// The operation is atomic: if any ID is unknown, nothing is deleted.
+func (r *snippetRepository) BulkDelete(ids []int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	positions, err := r.positionsOf(ids)
+	if err != nil {
+		return err
+	}
+
+	remove := make(map[int]bool, len(positions))
+	for _, i := range positions {
+		remove[i] = true
+	}
+
+	kept := make([]*domain.Snippet, 0, len(r.store.snippets)-len(remove))
+	for i, snippet := range r.store.snippets {
+		if !remove[i] {
+			kept = append(kept, snippet)
+		}
+	}
+	r.store.snippets = kept
+	return nil
+}
+
+// positionsOf returns the indexes of the snippets with the given IDs.
+// Duplicate IDs are ignored. It returns ErrNotFound naming the first unknown ID.
+// The caller must hold the store lock.
+func (r *snippetRepository) positionsOf(ids []int) ([]int, error) {
+	index := make(map[int]int, len(r.store.snippets))
+	for i, snippet := range r.store.snippets {
+		index[snippet.ID()] = i
+	}
+
+	seen := make(map[int]bool, len(ids))
+	positions := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		i, ok := index[id]
+		if !ok {
+			return nil, fmt.Errorf("snippet %d: %w", id, ErrNotFound)
+		}
+		positions = append(positions, i)
+	}
+	return positions, nil
+}
@@ -1,7 +1,10 @@
 package storage
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/7-Dany/snip/internal/domain"
 )
 
 func TestSnippetRepository_List(t *testing.T) {
@@ -440,3 +443,109 @@ func TestSnippetRepository_Delete(t *testing.T) {
 		}
 	})
 }
+
+func TestSnippetRepository_BulkUpdate(t *testing.T) {
+	setup := func(t *testing.T) *snippetRepository {
+		t.Helper()
+		repo := newSnippetRepository(newStore("test.json"))
+		for _, title := range []string{"one", "two", "three"} {
+			repo.Create(mustCreateSnippet(t, title, "go", "code"))
+		}
+		return repo
+	}
+
+	t.Run("applies edit to all selected snippets", func(t *testing.T) {
+		repo := setup(t)
+
+		if err := repo.BulkUpdate([]int{1, 3, 3}, domain.AddTagEdit(7)); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		tagged, _ := repo.FindByTag(7)
+		if len(tagged) != 2 || tagged[0].ID() != 1 || tagged[1].ID() != 3 {
+			t.Errorf("expected snippets [1 3] tagged, got %v", tagged)
+		}
+	})
+
+	t.Run("keeps the stored snippets", func(t *testing.T) {
+		repo := setup(t)
+		before, _ := repo.FindByID(2)
+
+		if err := repo.BulkUpdate([]int{2}, domain.SetCategoryEdit(5)); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		after, _ := repo.FindByID(2)
+		if after != before || before.CategoryID() != 5 {
+			t.Errorf("expected the same snippet to be edited, got category %d", before.CategoryID())
+		}
+	})
+
+	t.Run("changes nothing when an ID is unknown", func(t *testing.T) {
+		repo := setup(t)
+
+		err := repo.BulkUpdate([]int{1, 99}, domain.SetCategoryEdit(5))
+		if !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+
+		found, _ := repo.FindByID(1)
+		if found.CategoryID() != 0 {
+			t.Error("expected snippet 1 to be unchanged")
+		}
+	})
+
+	t.Run("changes nothing when an edit fails", func(t *testing.T) {
+		repo := setup(t)
+
+		calls := 0
+		failSecond := func(s *domain.Snippet) error {
+			calls++
+			if calls == 2 {
+				return domain.ErrEmptyLanguage
+			}
+			return s.SetLanguage("python")
+		}
+
+		err := repo.BulkUpdate([]int{1, 2}, failSecond)
+		if !errors.Is(err, domain.ErrEmptyLanguage) {
+			t.Fatalf("expected ErrEmptyLanguage, got %v", err)
+		}
+
+		found, _ := repo.FindByID(1)
+		if found.Language() != "go" {
+			t.Errorf("expected language to stay %q, got %q", "go", found.Language())
+		}
+	})
+}
+
+func TestSnippetRepository_BulkDelete(t *testing.T) {
+	t.Run("deletes all selected snippets", func(t *testing.T) {
+		repo := newSnippetRepository(newStore("test.json"))
+		for _, title := range []string{"one", "two", "three"} {
+			repo.Create(mustCreateSnippet(t, title, "go", "code"))
+		}
+
+		if err := repo.BulkDelete([]int{1, 3}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		snippets, _ := repo.List()
+		if len(snippets) != 1 || snippets[0].ID() != 2 {
+			t.Errorf("expected only snippet 2 to remain, got %v", snippets)
+		}
+	})
+
+	t.Run("deletes nothing when an ID is unknown", func(t *testing.T) {
+		repo := newSnippetRepository(newStore("test.json"))
+		repo.Create(mustCreateSnippet(t, "one", "go", "code"))
+
+		if err := repo.BulkDelete([]int{1, 99}); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+
+		if snippets, _ := repo.List(); len(snippets) != 1 {
+			t.Errorf("expected 1 snippet, got %d", len(snippets))
+		}
+	})
+}